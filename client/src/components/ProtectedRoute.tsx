@@ -1,14 +1,16 @@
 import { useAuth } from "@/hooks/useAuth";
-import { Outlet, Navigate } from "react-router";
+import { Outlet, Navigate, useLocation } from "react-router";
 
 export const ProtectedRoute: React.FC = () => {
   const { currentUser, isLoading } = useAuth();
+  const location = useLocation();
 
   if (isLoading) {
     return <div>Loading...</div>;
   }
 
-  if (!isLoading && !currentUser) return <Navigate to="/login" replace />;
+  if (!isLoading && !currentUser)
+    return <Navigate to="/login" replace state={{ from: location.pathname }} />;
 
   return <Outlet />;
 };
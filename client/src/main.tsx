@@ -10,6 +10,7 @@ import { AuthProvider } from "@/components/Providers/AuthProvider";
 import { LoginPage } from "@/pages/LoginPage";
 import { VerifyPage } from "@/pages/VerifyPage";
 import { ResetPasswordPage } from "@/pages/ResetPasswordPage";
+import { InvitePage } from "@/pages/InvitePage";
 
 createRoot(document.getElementById("root")!).render(
   <StrictMode>
@@ -24,6 +25,7 @@ createRoot(document.getElementById("root")!).render(
 
             <Route element={<ProtectedRoute />}>
               <Route index element={<HomePage />} />
+              <Route path="invites/:token" element={<InvitePage />} />
             </Route>
           </Route>
         </Routes>
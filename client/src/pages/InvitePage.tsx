@@ -0,0 +1,44 @@
+import { useEffect, useRef, useState } from "react";
+import { Link, useNavigate, useParams } from "react-router";
+import axios from "axios";
+import { api } from "@/api/axiosInstance";
+import { Loader } from "@/components/ui/Loader";
+
+export const InvitePage = () => {
+  const { token } = useParams();
+  const navigate = useNavigate();
+  const [error, setError] = useState<string | null>(null);
+  // StrictMode вызывает эффект дважды, а приглашение принимается только один раз
+  const accepted = useRef(false);
+
+  useEffect(() => {
+    if (!token || accepted.current) return;
+    accepted.current = true;
+
+    api
+      .post(`/v1/api/collections/invites/${token}/accept`)
+      .then(() => navigate("/", { replace: true }))
+      .catch((error) => {
+        if (axios.isAxiosError(error) && error.response?.data.error.message) {
+          setError(error.response.data.error.message);
+        } else {
+          setError("Something went wrong, please try again.");
+        }
+      });
+  }, [token, navigate]);
+
+  return (
+    <main className="flex h-full flex-col items-center justify-center gap-3">
+      {error ? (
+        <>
+          <p className="text-smoke-300 font-medium">{error}</p>
+          <Link to="/" className="text-smoke-300 underline">
+            Back to bookmarks
+          </Link>
+        </>
+      ) : (
+        <Loader />
+      )}
+    </main>
+  );
+};
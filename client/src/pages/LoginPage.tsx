@@ -1,4 +1,4 @@
-import { Link, useNavigate } from "react-router";
+import { Link, useLocation, useNavigate } from "react-router";
 import { Button } from "@/components/ui/Button";
 import { Input } from "@/components/Form/Input";
 import { useEffect } from "react";
@@ -30,12 +30,15 @@ export const LoginPage = () => {
   } = useForm<FormFields>({ resolver: zodResolver(schema), mode: "onChange" });
   const { currentUser, handleLogin } = useAuth();
   const navigate = useNavigate();
+  const location = useLocation();
+  // Страница, с которой перенаправили на вход, например ссылка-приглашение из письма
+  const from: string = location.state?.from ?? "/";
 
   useEffect(() => {
     if (currentUser) {
-      navigate("/");
+      navigate(from, { replace: true });
     }
-  }, [currentUser, navigate]);
+  }, [currentUser, navigate, from]);
 
   const onSubmit: SubmitHandler<FormFields> = async (data) => {
     try {
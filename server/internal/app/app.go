@@ -3,17 +3,22 @@ package app
 import (
 	"context"
 	"log/slog"
+	"net/http"
 	"os"
 	"time"
 
 	"github.com/aerscs/theca-public/internal/config"
 	"github.com/aerscs/theca-public/internal/model"
 	"github.com/aerscs/theca-public/internal/repository"
+	"github.com/aerscs/theca-public/internal/scheduler"
 	"github.com/aerscs/theca-public/internal/server"
 	"github.com/aerscs/theca-public/internal/server/handlers"
 	"github.com/aerscs/theca-public/internal/server/middleware"
 	"github.com/aerscs/theca-public/internal/service"
+	"github.com/aerscs/theca-public/internal/storage/blob"
 	"github.com/aerscs/theca-public/internal/storage/database"
+	"github.com/aerscs/theca-public/internal/utils/httpclient"
+	"github.com/aerscs/theca-public/internal/utils/parsers"
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
 	swaggerfiles "github.com/swaggo/files"
@@ -28,6 +33,7 @@ type Application struct {
 	server         *server.Server
 	authMiddleware middleware.AuthMiddleware
 	db             database.Database
+	scheduler      *scheduler.Scheduler
 }
 
 func New(ctx context.Context, cfg *config.Config, log *slog.Logger) *Application {
@@ -42,7 +48,7 @@ func New(ctx context.Context, cfg *config.Config, log *slog.Logger) *Application
 		log.Error("failed to connect to database", "error", err)
 		os.Exit(1)
 	}
-	if err := db.AutoMigrate(&model.User{}, &model.Bookmark{}); err != nil {
+	if err := db.AutoMigrate(&model.User{}, &model.Bookmark{}, &model.BookmarkPreview{}, &model.IconOverride{}, &model.AppliedSeed{}, &model.URLRewrite{}, &model.BookmarkArchive{}, &model.BookmarkContent{}, &model.Folder{}, &model.ShareLink{}, &model.Collection{}, &model.CollectionMember{}, &model.CollectionInvite{}, &model.Workspace{}, &model.WorkspaceMembership{}, &model.Comment{}, &model.Activity{}, &model.Widget{}, &model.InboxItem{}, &model.UserBlock{}); err != nil {
 		log.Error("failed to migrate database", "error", err)
 		os.Exit(1)
 	}
@@ -64,9 +70,36 @@ func New(ctx context.Context, cfg *config.Config, log *slog.Logger) *Application
 
 	cache := repository.NewRedisRepository(redisClient, log)
 
+	httpClient := httpclient.New(httpclient.Options{
+		UserAgent:            cfg.HTTPUserAgent,
+		HostRateLimit:        float64(cfg.HTTPHostRateLimit),
+		BreakerCooldown:      time.Duration(cfg.HTTPBreakerCooldownSec) * time.Second,
+		MaxConnsPerHost:      cfg.HTTPMaxConnsPerHost,
+		HostBurst:            cfg.HTTPHostRateLimit,
+		BreakerThreshold:     cfg.HTTPBreakerThreshold,
+		AllowPrivateNetworks: cfg.HTTPAllowPrivateNetworks,
+	}, log)
+	parsers.SetHTTPClient(httpClient)
+	model.SetLinkSigningKey(cfg.LinkSigningSecret)
+
 	repo := repository.NewRepository(db.GetDB(), log)
 
-	service := service.NewService(repo, cache, log, cfg)
+	blobs, err := blob.New(cfg)
+	if err != nil {
+		log.Error("failed to initialize blob storage", "error", err)
+		os.Exit(1)
+	}
+
+	service := service.NewService(repo, cache, blobs, log, cfg)
+
+	if err := service.SeedIconOverrides(); err != nil {
+		log.Error("failed to seed icon overrides", "error", err)
+	}
+	if err := service.ReloadIconOverrides(); err != nil {
+		log.Error("failed to load icon overrides, using built-in list", "error", err)
+	}
+
+	scheduler := initScheduler(service, log)
 
 	handlers := handlers.NewHandler(service, log)
 
@@ -74,6 +107,7 @@ func New(ctx context.Context, cfg *config.Config, log *slog.Logger) *Application
 
 	initHandlers(server, handlers, authMiddleware)
 	initSwaggerHandlers(server)
+	initMetricsHandlers(server, httpClient)
 
 	app := &Application{
 		cfg:            cfg,
@@ -81,6 +115,7 @@ func New(ctx context.Context, cfg *config.Config, log *slog.Logger) *Application
 		server:         server,
 		authMiddleware: authMiddleware,
 		db:             db,
+		scheduler:      scheduler,
 	}
 
 	return app
@@ -96,18 +131,121 @@ func initHandlers(server *server.Server, handlers *handlers.Handler, authMiddlew
 	v1.GET("/refresh-tokens", handlers.RefreshTokens)
 	v1.POST("/request-password-reset", handlers.RequestPasswordReset)
 	v1.PATCH("/reset-password", handlers.ResetPassword)
+	v1.GET("/previews/:token", handlers.GetBookmarkPreview)
+	v1.GET("/calendar/:token", handlers.GetCalendarFeed)
+	v1.GET("/digest/unsubscribe/:token", handlers.UnsubscribeDigest)
+	v1.GET("/go/:id", authMiddleware.OptionalJWTMiddleware(), handlers.GoToBookmark)
+	v1.POST("/digest/unsubscribe/:token", handlers.UnsubscribeDigest)
+	v1.GET("/shares/:token", handlers.GetSharedCollection)
+	v1.GET("/s/:token", handlers.GetSharePage)
+	v1.POST("/s/:token", handlers.GetSharePage)
+	v1.GET("/profiles/:username", handlers.GetPublicProfile)
+	v1.GET("/collections/:id", handlers.GetPublicCollection)
+	v1.GET("/collections/:id/feed/:format", handlers.GetCollectionFeed)
+	v1.GET("/feeds/:token/:format", handlers.GetBookmarkFeed)
+	v1.GET("/embed/:token", handlers.GetWidgetPage)
+	v1.GET("/embed/:token/json", handlers.GetWidgetData)
 
 	secV1 := v1.Group("/api", authMiddleware.JWTMiddleware())
 	secV1.DELETE("/logout", handlers.Logout)
 	secV1.GET("/user/me", handlers.GetSelfUser)
-	secV1.GET("/user/:id", handlers.GetUser)
+	secV1.PATCH("/user/me", handlers.UpdateUserSettings)
+	secV1.GET("/user/me/calendar", handlers.GetCalendarFeedURL)
+	secV1.POST("/user/me/calendar/rotate", handlers.RotateCalendarFeedURL)
+	secV1.GET("/user/me/feeds", handlers.GetFeedURLs)
+	secV1.POST("/user/me/feeds/rotate", handlers.RotateFeedURLs)
+	secV1.GET("/user/me/blocked", handlers.GetBlockedUsers)
+	secV1.POST("/user/me/blocked", handlers.BlockUser)
+	secV1.DELETE("/user/me/blocked/:userId", handlers.UnblockUser)
+
+	secV1.GET("/tags", handlers.GetTags)
+
+	folders := secV1.Group("/folders")
+	folders.GET("", handlers.GetFolders)
+	folders.POST("", handlers.CreateFolder)
+	folders.PUT("/:id", handlers.UpdateFolder)
+	folders.DELETE("/:id", handlers.DeleteFolder)
+
+	secV1.GET("/activity", handlers.GetActivity)
+
+	inbox := secV1.Group("/inbox")
+	inbox.GET("", handlers.GetInbox)
+	inbox.POST("/:id/accept", handlers.AcceptInboxItem)
+	inbox.POST("/:id/dismiss", handlers.DismissInboxItem)
+
+	workspaces := secV1.Group("/workspaces")
+	workspaces.GET("", handlers.GetWorkspaces)
+	workspaces.POST("", handlers.CreateWorkspace)
+	workspaces.GET("/:id", handlers.GetWorkspace)
+	workspaces.PUT("/:id", handlers.UpdateWorkspace)
+	workspaces.DELETE("/:id", handlers.DeleteWorkspace)
+	workspaces.GET("/:id/members", handlers.GetWorkspaceMembers)
+	workspaces.POST("/:id/members", handlers.AddWorkspaceMember)
+	workspaces.PUT("/:id/members/:userId", handlers.UpdateWorkspaceMember)
+	workspaces.DELETE("/:id/members/:userId", handlers.RemoveWorkspaceMember)
+
+	collections := secV1.Group("/collections")
+	collections.GET("", handlers.GetCollections)
+	collections.POST("", handlers.CreateCollection)
+	collections.POST("/invites/:token/accept", handlers.AcceptCollectionInvite)
+	collections.PUT("/:id", handlers.UpdateCollection)
+	collections.DELETE("/:id", handlers.DeleteCollection)
+	collections.GET("/:id/bookmarks", handlers.GetCollectionBookmarks)
+	collections.GET("/:id/members", handlers.GetCollectionMembers)
+	collections.PUT("/:id/members/:userId", handlers.UpdateCollectionMember)
+	collections.DELETE("/:id/members/:userId", handlers.RemoveCollectionMember)
+	collections.GET("/:id/invites", handlers.GetCollectionInvites)
+	collections.POST("/:id/invites", handlers.InviteToCollection)
+	collections.DELETE("/:id/invites/:inviteId", handlers.RevokeCollectionInvite)
+	collections.GET("/:id/widgets", handlers.GetWidgets)
+	collections.POST("/:id/widgets", handlers.CreateWidget)
+	collections.PUT("/:id/widgets/:widgetId", handlers.UpdateWidget)
+	collections.DELETE("/:id/widgets/:widgetId", handlers.DeleteWidget)
+	collections.PUT("/:id/template", handlers.PublishTemplate)
+
+	templates := secV1.Group("/templates")
+	templates.GET("", handlers.GetTemplates)
+	templates.GET("/:id", handlers.GetTemplate)
+	templates.POST("/:id/clone", handlers.CloneTemplate)
+
+	shares := secV1.Group("/shares")
+	shares.GET("", handlers.GetShareLinks)
+	shares.POST("", handlers.CreateShareLink)
+	shares.DELETE("/:id", handlers.DeleteShareLink)
 
 	bookmarks := secV1.Group("/bookmarks")
 	bookmarks.POST("", handlers.AddBookmark)
 	bookmarks.GET("", handlers.GetBookmarks)
+	bookmarks.GET("/search", handlers.SearchBookmarks)
+	bookmarks.GET("/top", handlers.GetTopSites)
+	bookmarks.GET("/stats", handlers.GetBookmarkUsage)
+	bookmarks.GET("/stats/domains", handlers.GetDomainUsage)
+	bookmarks.GET("/health", handlers.GetBookmarksHealth)
+	bookmarks.POST("/health/check", handlers.ScheduleBookmarksCheck)
+	bookmarks.GET("/redirects", handlers.GetRedirectSuggestions)
+	bookmarks.POST("/redirects/apply", handlers.ApplyAllRedirectSuggestions)
+	bookmarks.GET("/rewrites", handlers.GetURLRewrites)
+	bookmarks.POST("/rewrites/:id/undo", handlers.UndoURLRewrite)
 	bookmarks.GET("/:id", handlers.GetBookmarkByID)
 	bookmarks.PATCH("/:id", handlers.UpdateBookmark)
 	bookmarks.DELETE("/:id", handlers.DeleteBookmark)
+	bookmarks.POST("/:id/check", handlers.CheckBookmark)
+	bookmarks.POST("/:id/redirect", handlers.ApplyRedirectSuggestion)
+	bookmarks.GET("/:id/reader", handlers.GetReaderView)
+	bookmarks.PATCH("/:id/state", handlers.UpdateBookmarkState)
+	bookmarks.PUT("/:id/progress", handlers.UpdateReadingProgress)
+	bookmarks.PUT("/:id/reminder", handlers.SetBookmarkReminder)
+	bookmarks.DELETE("/:id/reminder", handlers.ClearBookmarkReminder)
+	bookmarks.PUT("/:id/snooze", handlers.SnoozeBookmark)
+	bookmarks.DELETE("/:id/snooze", handlers.UnsnoozeBookmark)
+	bookmarks.GET("/:id/archive", handlers.GetBookmarkArchive)
+	bookmarks.POST("/:id/archive", handlers.ArchiveBookmark)
+	bookmarks.DELETE("/:id/archive", handlers.DeleteBookmarkArchive)
+	bookmarks.GET("/:id/comments", handlers.GetComments)
+	bookmarks.POST("/:id/comments", handlers.CreateComment)
+	bookmarks.PUT("/:id/comments/:commentId", handlers.UpdateComment)
+	bookmarks.DELETE("/:id/comments/:commentId", handlers.DeleteComment)
+	bookmarks.POST("/:id/send", handlers.SendBookmark)
 	bookmarks.PUT("/import", handlers.ImportBookmarks)
 	bookmarks.GET("/export", handlers.ExportBookmarks)
 
@@ -116,15 +254,49 @@ func initHandlers(server *server.Server, handlers *handlers.Handler, authMiddlew
 	bookmarksV2 := secV2.Group("/bookmarks")
 	bookmarksV2.POST("/import", handlers.ImportBookmarksV2)
 	bookmarksV2.GET("/export", handlers.ExportBookmarksV2)
+
+	admin := secV1.Group("/admin", handlers.RequireAdmin())
+	admin.GET("/icon-overrides", handlers.GetIconOverrides)
+	admin.POST("/icon-overrides", handlers.CreateIconOverride)
+	admin.PUT("/icon-overrides/:id", handlers.UpdateIconOverride)
+	admin.DELETE("/icon-overrides/:id", handlers.DeleteIconOverride)
+	admin.PUT("/workspaces/:id/plan", handlers.SetWorkspacePlan)
+	admin.PUT("/collections/:id/template", handlers.SetCollectionTemplate)
 }
 
 func initSwaggerHandlers(server *server.Server) {
 	server.SwaggerRouter().GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 }
 
+// initScheduler регистрирует фоновые задачи
+func initScheduler(service service.Service, log *slog.Logger) *scheduler.Scheduler {
+	s := scheduler.New(log)
+
+	// Переопределения могут меняться на другом инстансе, поэтому периодически перечитываем их
+	s.Every("reload-icon-overrides", 5*time.Minute, func(ctx context.Context) {
+		if err := service.ReloadIconOverrides(); err != nil {
+			log.Error("failed to reload icon overrides", "error", err)
+		}
+	})
+	s.Every("check-links", time.Minute, service.CheckDueBookmarks)
+	s.Every("send-reminders", time.Minute, service.SendDueReminders)
+	s.Every("send-digests", 15*time.Minute, service.SendDigests)
+	s.Every("classify-bookmarks", 5*time.Minute, service.ClassifyBookmarks)
+
+	return s
+}
+
+// initMetricsHandlers регистрирует служебные метрики на внутреннем сервере
+func initMetricsHandlers(server *server.Server, httpClient *httpclient.Client) {
+	server.SwaggerRouter().GET("/metrics/http-client", func(c *gin.Context) {
+		c.JSON(http.StatusOK, httpClient.Metrics())
+	})
+}
+
 func (a *Application) Run() {
 	const op = "app.Run"
 	a.server.Start()
+	a.scheduler.Start(context.Background())
 	log := a.log.With(slog.String("op", op))
 	log.Info("application started",
 		slog.String("timestamp", time.Now().Format(time.RFC3339)),
@@ -138,6 +310,7 @@ func (a *Application) Stop() {
 	log.Info("shutting down application...")
 
 	a.server.Stop()
+	a.scheduler.Stop()
 
 	if a.db != nil {
 		if err := a.db.Close(); err != nil {
@@ -7,30 +7,67 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	PGSSLMode        string
-	SMTPAPIKey       string
-	PGName           string
-	SwaggerAddr      string
-	PGPassword       string
-	PGDB             string
-	SQLitePath       string
-	PublicAddr       string
-	AppName          string
-	LogLevel         string
-	PGUser           string
-	RedisPassword    string
-	RedisAddr        string
+	PGSSLMode     string
+	SMTPAPIKey    string
+	PGName        string
+	SwaggerAddr   string
+	PGPassword    string
+	PGDB          string
+	SQLitePath    string
+	PublicAddr    string
+	AppName       string
+	LogLevel      string
+	PGUser        string
+	RedisPassword string
+	RedisAddr     string
+	// HTTPUserAgent User-Agent исходящих запросов к сайтам закладок
+	HTTPUserAgent string
+	// PublicURL внешний адрес API для ссылок в письмах и подписках на календарь
+	PublicURL string
+	// ClientURL адрес веб-клиента для ссылок в письмах, которые открывают страницы приложения
+	ClientURL string
+	// BlobStorage хранилище снимков страниц: "local" или "s3"
+	BlobStorage      string
+	BlobLocalDir     string
+	S3Endpoint       string
+	S3Region         string
+	S3Bucket         string
+	S3AccessKey      string
+	S3SecretKey      string
 	JWTRefreshSecret []byte
 	JWTAccessSecret  []byte
-	RedisDB          int
-	PGPort           int
-	ShutdownTimeout  int
-	IsLocalRun       bool
+	// LinkSigningSecret ключ подписи ссылок перехода /v1/go для плиток без авторизации
+	LinkSigningSecret []byte
+	// SnapshotMaxSize максимальный размер снимка страницы в байтах
+	SnapshotMaxSize int64
+	// StorageQuota и PremiumStorageQuota лимиты хранилища пользователя в байтах
+	StorageQuota        int64
+	PremiumStorageQuota int64
+	RedisDB             int
+	// Лимиты исходящих запросов к одному хосту и настройки предохранителя
+	HTTPMaxConnsPerHost    int
+	HTTPHostRateLimit      int
+	HTTPBreakerThreshold   int
+	HTTPBreakerCooldownSec int
+	// Проверка ссылок: как часто перепроверять закладку и сколько закладок проверять за минуту
+	LinkCheckIntervalHours int
+	LinkCheckBatchSize     int
+	// Ранжирование «топ сайтов»: период полураспада веса посещения и время жизни кэша
+	FrecencyHalfLifeDays int
+	TopSitesCacheTTLSec  int
+	PGPort               int
+	ShutdownTimeout      int
+	IsLocalRun           bool
+	S3PathStyle          bool
+	// HTTPAllowPrivateNetworks разрешает загрузку страниц с внутренних адресов
+	HTTPAllowPrivateNetworks bool
+	SnapshotsEnabled         bool
 }
 
 func Load() *Config {
@@ -40,25 +77,50 @@ func Load() *Config {
 	refreshSecret := getEnvOrGenerateSecret("JWT_REFRESH_SECRET")
 
 	return &Config{
-		AppName:          "theca",
-		LogLevel:         getEnv("LOG_LEVEL", "INFO"),
-		PGName:           getEnv("PG_NAME", "postgres"),
-		PGUser:           getEnv("PG_USER", "postgres"),
-		PGPassword:       getEnv("PG_PASSWORD", "postgres"),
-		PGDB:             getEnv("PG_DB", "postgres"),
-		PGPort:           getInt("PG_PORT", 5432),
-		PGSSLMode:        getEnv("PG_SSL_MODE", "disable"),
-		IsLocalRun:       parseBool("IS_LOCAL_RUN"),
-		SQLitePath:       getEnv("SQLITE_PATH", "theca_local.db"),
-		PublicAddr:       getEnv("PUBLIC_ADDR", ":8080"),
-		JWTAccessSecret:  []byte(accessSecret),
-		JWTRefreshSecret: []byte(refreshSecret),
-		SwaggerAddr:      getEnv("SWAGGER_ADDR", ":8081"),
-		SMTPAPIKey:       getEnv("SMTP_API_KEY", ""),
-		RedisAddr:        getEnv("REDIS_ADDR", "localhost:6379"),
-		RedisPassword:    getEnv("REDIS_PASSWORD", ""),
-		RedisDB:          getInt("REDIS_DB", 0),
-		ShutdownTimeout:  getInt("SHUTDOWN_TIMEOUT", 5),
+		AppName:                  "theca",
+		LogLevel:                 getEnv("LOG_LEVEL", "INFO"),
+		PGName:                   getEnv("PG_NAME", "postgres"),
+		PGUser:                   getEnv("PG_USER", "postgres"),
+		PGPassword:               getEnv("PG_PASSWORD", "postgres"),
+		PGDB:                     getEnv("PG_DB", "postgres"),
+		PGPort:                   getInt("PG_PORT", 5432),
+		PGSSLMode:                getEnv("PG_SSL_MODE", "disable"),
+		IsLocalRun:               parseBool("IS_LOCAL_RUN"),
+		SQLitePath:               getEnv("SQLITE_PATH", "theca_local.db"),
+		PublicAddr:               getEnv("PUBLIC_ADDR", ":8080"),
+		PublicURL:                strings.TrimRight(getEnv("PUBLIC_URL", "https://theca.oxytocingroup.com"), "/"),
+		ClientURL:                strings.TrimRight(getEnv("CLIENT_URL", "https://theca.oxytocingroup.com"), "/"),
+		JWTAccessSecret:          []byte(accessSecret),
+		JWTRefreshSecret:         []byte(refreshSecret),
+		LinkSigningSecret:        []byte(getEnvOrGenerateSecret("LINK_SIGNING_SECRET")),
+		SwaggerAddr:              getEnv("SWAGGER_ADDR", ":8081"),
+		SMTPAPIKey:               getEnv("SMTP_API_KEY", ""),
+		RedisAddr:                getEnv("REDIS_ADDR", "localhost:6379"),
+		RedisPassword:            getEnv("REDIS_PASSWORD", ""),
+		RedisDB:                  getInt("REDIS_DB", 0),
+		ShutdownTimeout:          getInt("SHUTDOWN_TIMEOUT", 5),
+		StorageQuota:             int64(getInt("STORAGE_QUOTA_MB", 50)) << 20,
+		PremiumStorageQuota:      int64(getInt("PREMIUM_STORAGE_QUOTA_MB", 1024)) << 20,
+		HTTPUserAgent:            getEnv("HTTP_USER_AGENT", ""),
+		HTTPMaxConnsPerHost:      getInt("HTTP_MAX_CONNS_PER_HOST", 4),
+		HTTPHostRateLimit:        getInt("HTTP_HOST_RATE_LIMIT", 5),
+		HTTPBreakerThreshold:     getInt("HTTP_BREAKER_THRESHOLD", 5),
+		HTTPBreakerCooldownSec:   getInt("HTTP_BREAKER_COOLDOWN", 60),
+		LinkCheckIntervalHours:   getInt("LINK_CHECK_INTERVAL_HOURS", 72),
+		LinkCheckBatchSize:       getInt("LINK_CHECK_BATCH_SIZE", 30),
+		FrecencyHalfLifeDays:     getInt("FRECENCY_HALF_LIFE_DAYS", 30),
+		TopSitesCacheTTLSec:      getInt("TOP_SITES_CACHE_TTL", 300),
+		HTTPAllowPrivateNetworks: parseBool("HTTP_ALLOW_PRIVATE_NETWORKS"),
+		BlobStorage:              getEnv("BLOB_STORAGE", "local"),
+		BlobLocalDir:             getEnv("BLOB_LOCAL_DIR", "data/blobs"),
+		S3Endpoint:               getEnv("S3_ENDPOINT", ""),
+		S3Region:                 getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:                 getEnv("S3_BUCKET", ""),
+		S3AccessKey:              getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:              getEnv("S3_SECRET_KEY", ""),
+		S3PathStyle:              parseBool("S3_PATH_STYLE"),
+		SnapshotsEnabled:         getEnv("SNAPSHOTS_ENABLED", "true") == "true",
+		SnapshotMaxSize:          int64(getInt("SNAPSHOT_MAX_SIZE_MB", 10)) << 20,
 	}
 }
 
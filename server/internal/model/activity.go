@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// Действия с закладками в журнале активности
+const (
+	ActivityAdded   = "added"
+	ActivityEdited  = "edited"
+	ActivityMoved   = "moved"
+	ActivityDeleted = "deleted"
+)
+
+// Activity запись журнала активности: кто, когда и что сделал с закладкой.
+// Название и адрес закладки сохраняются на момент действия, чтобы запись пережила удаление закладки.
+// FromCollectionID заполняется, когда закладку перенесли из одной коллекции в другую или убрали из коллекции
+type Activity struct {
+	CreatedAt        time.Time `json:"created_at" gorm:"index"`
+	CollectionID     *uint     `json:"collection_id" gorm:"index"`
+	FromCollectionID *uint     `json:"from_collection_id" gorm:"index"`
+	WorkspaceID      *uint     `json:"workspace_id" gorm:"index"`
+	Action           string    `json:"action" gorm:"size:16;not null"`
+	BookmarkTitle    string    `json:"bookmark_title"`
+	BookmarkURL      string    `json:"bookmark_url"`
+	Fields           []string  `json:"fields" gorm:"serializer:json"`
+	ID               uint      `json:"id"`
+	BookmarkID       uint      `json:"bookmark_id" gorm:"index"`
+	OwnerID          uint      `json:"owner_id" gorm:"index"`
+	ActorID          uint      `json:"actor_id" gorm:"index"`
+}
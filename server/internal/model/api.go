@@ -27,10 +27,45 @@ type LoginResponse struct {
 }
 
 type UserResponse struct {
-	Username  string `json:"username"`
-	Email     string `json:"email"`
-	ID        uint   `json:"id"`
-	IsPremium bool   `json:"is_premium"`
+	Username       string `json:"username"`
+	Email          string `json:"email"`
+	DigestTimezone string `json:"digest_timezone"`
+	DigestLanguage string `json:"digest_language"`
+	DisplayName    string `json:"display_name"`
+	Bio            string `json:"bio"`
+	AvatarURL      string `json:"avatar_url"`
+	StorageUsed    int64  `json:"storage_used"`
+	StorageQuota   int64  `json:"storage_quota"`
+	ID             uint   `json:"id"`
+	DigestWeekday  int    `json:"digest_weekday"`
+	DigestHour     int    `json:"digest_hour"`
+	IsPremium      bool   `json:"is_premium"`
+	DigestEnabled  bool   `json:"digest_enabled"`
+	// AutoRewriteRedirects заменять URL закладок при постоянном редиректе автоматически
+	AutoRewriteRedirects bool `json:"auto_rewrite_redirects"`
+	// TrackVisits учитывать переходы по закладкам в статистике
+	TrackVisits bool `json:"track_visits"`
+	// ProfilePublic профиль доступен другим пользователям по username
+	ProfilePublic bool `json:"profile_public"`
+}
+
+// UpdateUserSettingsRequest запрос на изменение настроек пользователя.
+// Незаданные поля не меняются. DigestWeekday — день недели от 0 (воскресенье) до 6,
+// DigestTimezone — название часового пояса IANA, например Europe/Moscow.
+// Отключение TrackVisits удаляет уже собранную статистику переходов.
+// DisplayName, Bio и AvatarURL видны другим только при включённом ProfilePublic; пустая строка очищает поле
+type UpdateUserSettingsRequest struct {
+	AutoRewriteRedirects *bool   `json:"auto_rewrite_redirects,omitempty"`
+	DigestEnabled        *bool   `json:"digest_enabled,omitempty"`
+	TrackVisits          *bool   `json:"track_visits,omitempty"`
+	ProfilePublic        *bool   `json:"profile_public,omitempty"`
+	DigestWeekday        *int    `json:"digest_weekday,omitempty" binding:"omitempty,min=0,max=6"`
+	DigestHour           *int    `json:"digest_hour,omitempty" binding:"omitempty,min=0,max=23"`
+	DigestTimezone       *string `json:"digest_timezone,omitempty"`
+	DigestLanguage       *string `json:"digest_language,omitempty" binding:"omitempty,oneof=en ru"`
+	DisplayName          *string `json:"display_name,omitempty" binding:"omitempty,max=64"`
+	Bio                  *string `json:"bio,omitempty" binding:"omitempty,max=500"`
+	AvatarURL            *string `json:"avatar_url,omitempty" binding:"omitempty,max=2048"`
 }
 
 type ChangePasswordRequest struct {
@@ -58,11 +93,16 @@ type ResetPasswordRequest struct {
 	Password string `json:"password" binding:"required,min=6"`
 }
 
-// AddBookmarkRequest запрос на добавление закладки
+// AddBookmarkRequest запрос на добавление закладки.
+// Если title не указан, он заполняется из метаданных страницы
 type AddBookmarkRequest struct {
-	Title    string `json:"title" binding:"required"`
-	URL      string `json:"url" binding:"required"`
-	ShowText bool   `json:"show_text"`
+	FolderID     *uint    `json:"folder_id"`
+	CollectionID *uint    `json:"collection_id"`
+	WorkspaceID  *uint    `json:"workspace_id"`
+	Title        string   `json:"title"`
+	URL          string   `json:"url" binding:"required"`
+	Tags         []string `json:"tags"`
+	ShowText     bool     `json:"show_text"`
 }
 
 // UpdateBookmarkRequest запрос на обновление закладки
@@ -72,24 +112,737 @@ type UpdateBookmarkRequest struct {
 	ShowText bool   `json:"show_text" binding:"required"`
 }
 
-// PatchBookmarkRequest запрос на частичное обновление закладки
+// PatchBookmarkRequest запрос на частичное обновление закладки.
+// folder_id = 0 переносит закладку на верхний уровень, collection_id = 0 убирает её из коллекции,
+// tags заменяет список тегов целиком
 type PatchBookmarkRequest struct {
-	Title    *string `json:"title,omitempty"`
-	URL      *string `json:"url,omitempty"`
-	ShowText *bool   `json:"show_text,omitempty"`
+	Title        *string   `json:"title,omitempty"`
+	URL          *string   `json:"url,omitempty"`
+	FolderID     *uint     `json:"folder_id,omitempty"`
+	CollectionID *uint     `json:"collection_id,omitempty"`
+	Tags         *[]string `json:"tags,omitempty"`
+	ShowText     *bool     `json:"show_text,omitempty"`
 }
 
 // BookmarkResponse ответ с данными закладки
 type BookmarkResponse struct {
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	Title     string    `json:"title"`
-	URL       string    `json:"url"`
-	Favicon   string    `json:"favicon"`
-	ID        uint      `json:"id"`
-	ShowText  bool      `json:"show_text"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	SnapshotAt   *time.Time `json:"snapshot_at"`
+	PublishedAt  *time.Time `json:"published_at"`
+	ReadAt       *time.Time `json:"read_at"`
+	ArchivedAt   *time.Time `json:"archived_at"`
+	RemindAt     *time.Time `json:"remind_at"`
+	SnoozedUntil *time.Time `json:"snoozed_until"`
+	LastVisited  *time.Time `json:"last_visited_at"`
+	FolderID     *uint      `json:"folder_id"`
+	CollectionID *uint      `json:"collection_id"`
+	WorkspaceID  *uint      `json:"workspace_id"`
+	Title        string     `json:"title"`
+	URL          string     `json:"url"`
+	Favicon      string     `json:"favicon"`
+	Description  string     `json:"description"`
+	ImageURL     string     `json:"image_url"`
+	SiteName     string     `json:"site_name"`
+	Language     string     `json:"language"`
+	CanonicalURL string     `json:"canonical_url"`
+	Preview      string     `json:"preview"`
+	GoURL        string     `json:"go_url"`
+	HealthStatus string     `json:"health_status"`
+	Author       string     `json:"author"`
+	State        string     `json:"state"`
+	Kind         string     `json:"kind"`
+	Icons        []Icon     `json:"icons"`
+	Tags         []string   `json:"tags"`
+	VisitCount   int64      `json:"visit_count"`
+	ID           uint       `json:"id"`
+	WordCount    int        `json:"word_count"`
+	// ReadingTime примерное время чтения в минутах
+	ReadingTime     int     `json:"reading_time"`
+	ReadingProgress float64 `json:"reading_progress"`
+	ShowText        bool    `json:"show_text"`
+	Favorite        bool    `json:"favorite"`
+}
+
+// NewBookmarkResponse формирует ответ с данными закладки
+func NewBookmarkResponse(bookmark *Bookmark) BookmarkResponse {
+	return BookmarkResponse{
+		ID:              bookmark.ID,
+		Title:           bookmark.Title,
+		URL:             bookmark.URL,
+		ShowText:        bookmark.ShowText,
+		CreatedAt:       bookmark.CreatedAt,
+		UpdatedAt:       bookmark.UpdatedAt,
+		Favicon:         bookmark.Favicon,
+		Description:     bookmark.Description,
+		ImageURL:        bookmark.ImageURL,
+		SiteName:        bookmark.SiteName,
+		Language:        bookmark.Language,
+		CanonicalURL:    bookmark.CanonicalURL,
+		Preview:         PreviewPath(bookmark.PreviewToken),
+		Icons:           bookmark.Icons,
+		HealthStatus:    bookmark.HealthStatus,
+		SnapshotAt:      bookmark.SnapshotAt,
+		Author:          bookmark.Author,
+		PublishedAt:     bookmark.PublishedAt,
+		WordCount:       bookmark.WordCount,
+		ReadingTime:     bookmark.ReadingTime(),
+		State:           bookmark.State,
+		Favorite:        bookmark.Favorite,
+		ReadAt:          bookmark.ReadAt,
+		ArchivedAt:      bookmark.ArchivedAt,
+		ReadingProgress: bookmark.Progress,
+		RemindAt:        bookmark.RemindAt,
+		SnoozedUntil:    bookmark.SnoozedUntil,
+		GoURL:           ClickPath(bookmark.ID),
+		VisitCount:      bookmark.VisitCount,
+		LastVisited:     bookmark.LastVisitedAt,
+		Kind:            bookmark.Kind,
+		FolderID:        bookmark.FolderID,
+		CollectionID:    bookmark.CollectionID,
+		WorkspaceID:     bookmark.WorkspaceID,
+		Tags:            bookmark.Tags,
+	}
+}
+
+// ReaderViewResponse текст статьи для режима чтения
+type ReaderViewResponse struct {
+	PublishedAt *time.Time `json:"published_at"`
+	Title       string     `json:"title"`
+	URL         string     `json:"url"`
+	SiteName    string     `json:"site_name"`
+	Author      string     `json:"author"`
+	Language    string     `json:"language"`
+	// Text абзацы статьи, разделённые пустой строкой
+	Text        string `json:"text"`
+	ID          uint   `json:"id"`
+	WordCount   int    `json:"word_count"`
+	ReadingTime int    `json:"reading_time"`
+	// ReadingProgress сохранённая позиция, с которой можно продолжить чтение
+	ReadingProgress float64 `json:"reading_progress"`
+}
+
+// NewReaderViewResponse формирует ответ режима чтения
+func NewReaderViewResponse(bookmark *Bookmark, content *BookmarkContent) ReaderViewResponse {
+	return ReaderViewResponse{
+		ID:              bookmark.ID,
+		Title:           bookmark.Title,
+		URL:             bookmark.URL,
+		SiteName:        bookmark.SiteName,
+		Author:          bookmark.Author,
+		Language:        bookmark.Language,
+		PublishedAt:     bookmark.PublishedAt,
+		Text:            content.Text,
+		WordCount:       bookmark.WordCount,
+		ReadingTime:     bookmark.ReadingTime(),
+		ReadingProgress: bookmark.Progress,
+	}
+}
+
+// BookmarkListFilter фильтры списка закладок по состоянию, виду содержимого, папке и тегу.
+// Отложенные закладки не попадают в список непрочитанных, пока snoozed не задан явно.
+// folder_id = 0 выбирает закладки вне папок
+type BookmarkListFilter struct {
+	Favorite *bool  `form:"favorite"`
+	Snoozed  *bool  `form:"snoozed"`
+	FolderID *uint  `form:"folder_id"`
+	State    string `form:"state" binding:"omitempty,oneof=unread read archived"`
+	Kind     string `form:"kind" binding:"omitempty,oneof=article video repository documentation pdf image social shop other"`
+	Tag      string `form:"tag"`
+	// WorkspaceID показывает закладки рабочего пространства вместо личных
+	WorkspaceID uint `form:"workspace_id"`
+}
+
+// FolderRequest запрос на создание или изменение папки.
+// parent_id не указан — папка верхнего уровня. workspace_id создаёт папку в рабочем пространстве
+// и при изменении папки не учитывается
+type FolderRequest struct {
+	ParentID    *uint  `json:"parent_id"`
+	WorkspaceID *uint  `json:"workspace_id"`
+	Name        string `json:"name" binding:"required,max=255"`
+}
+
+// WorkspaceQuery выбирает рабочее пространство; 0 — личные закладки и папки
+type WorkspaceQuery struct {
+	WorkspaceID uint `form:"workspace_id"`
+}
+
+// TagResponse тег и количество отмеченных им закладок
+type TagResponse struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// CreateShareLinkRequest запрос на создание общей ссылки.
+// Для kind=folder нужен folder_id, для tag — tag, для selection — bookmark_ids
+type CreateShareLinkRequest struct {
+	ExpiresAt   *time.Time `json:"expires_at"`
+	FolderID    *uint      `json:"folder_id"`
+	Kind        string     `json:"kind" binding:"required,oneof=folder tag selection"`
+	Tag         string     `json:"tag"`
+	Title       string     `json:"title" binding:"max=255"`
+	Password    string     `json:"password" binding:"max=72"`
+	BookmarkIDs []uint     `json:"bookmark_ids"`
+}
+
+// ShareLinkResponse общая ссылка с адресами HTML-страницы и JSON-представления
+type ShareLinkResponse struct {
+	CreatedAt    time.Time  `json:"created_at"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+	LastViewedAt *time.Time `json:"last_viewed_at"`
+	FolderID     *uint      `json:"folder_id"`
+	Kind         string     `json:"kind"`
+	Title        string     `json:"title"`
+	Tag          string     `json:"tag"`
+	URL          string     `json:"url"`
+	APIURL       string     `json:"api_url"`
+	BookmarkIDs  []uint     `json:"bookmark_ids"`
+	ViewCount    int64      `json:"view_count"`
+	ID           uint       `json:"id"`
+	HasPassword  bool       `json:"has_password"`
+}
+
+// NewShareLinkResponse формирует ответ с данными общей ссылки; baseURL — публичный адрес сервиса
+func NewShareLinkResponse(link *ShareLink, baseURL string) ShareLinkResponse {
+	return ShareLinkResponse{
+		ID:           link.ID,
+		Kind:         link.Kind,
+		Title:        link.Title,
+		FolderID:     link.FolderID,
+		Tag:          link.Tag,
+		BookmarkIDs:  link.BookmarkIDs,
+		URL:          baseURL + SharePath(link.Token),
+		APIURL:       baseURL + ShareAPIPath(link.Token),
+		HasPassword:  link.PasswordHash != "",
+		ExpiresAt:    link.ExpiresAt,
+		ViewCount:    link.ViewCount,
+		LastViewedAt: link.LastViewedAt,
+		CreatedAt:    link.CreatedAt,
+	}
+}
+
+// SharedBookmarkResponse закладка в общей ссылке, без служебных полей владельца
+type SharedBookmarkResponse struct {
+	CreatedAt   time.Time `json:"created_at"`
+	Title       string    `json:"title"`
+	URL         string    `json:"url"`
+	Description string    `json:"description"`
+	SiteName    string    `json:"site_name"`
+	ImageURL    string    `json:"image_url"`
+	Favicon     string    `json:"favicon"`
+	Kind        string    `json:"kind"`
+	Tags        []string  `json:"tags"`
+}
+
+// SharedCollectionResponse содержимое общей ссылки
+type SharedCollectionResponse struct {
+	ExpiresAt *time.Time               `json:"expires_at"`
+	Title     string                   `json:"title"`
+	Kind      string                   `json:"kind"`
+	Bookmarks []SharedBookmarkResponse `json:"bookmarks"`
+}
+
+// NewSharedCollectionResponse формирует публичное содержимое общей ссылки
+func NewSharedCollectionResponse(link *ShareLink, bookmarks []Bookmark) SharedCollectionResponse {
+	return SharedCollectionResponse{
+		Title:     link.Title,
+		Kind:      link.Kind,
+		ExpiresAt: link.ExpiresAt,
+		Bookmarks: NewSharedBookmarks(bookmarks),
+	}
+}
+
+// NewSharedBookmarks формирует публичный список закладок
+func NewSharedBookmarks(bookmarks []Bookmark) []SharedBookmarkResponse {
+	response := make([]SharedBookmarkResponse, 0, len(bookmarks))
+	for i := range bookmarks {
+		response = append(response, SharedBookmarkResponse{
+			Title:       bookmarks[i].Title,
+			URL:         bookmarks[i].URL,
+			Description: bookmarks[i].Description,
+			SiteName:    bookmarks[i].SiteName,
+			ImageURL:    bookmarks[i].ImageURL,
+			Favicon:     bookmarks[i].Favicon,
+			Kind:        bookmarks[i].Kind,
+			Tags:        bookmarks[i].Tags,
+			CreatedAt:   bookmarks[i].CreatedAt,
+		})
+	}
+	return response
+}
+
+// UpdateBookmarkStateRequest запрос на смену состояния закладки
+type UpdateBookmarkStateRequest struct {
+	State    *string `json:"state" binding:"omitempty,oneof=unread read archived"`
+	Favorite *bool   `json:"favorite"`
+}
+
+// ReadingProgressRequest запрос на сохранение позиции чтения.
+// Progress — прочитанная доля статьи от 0 до 1
+type ReadingProgressRequest struct {
+	Progress *float64 `json:"progress" binding:"required,min=0,max=1"`
+}
+
+// ReminderRequest время напоминания или окончания откладывания закладки
+type ReminderRequest struct {
+	At time.Time `json:"at" binding:"required"`
+}
+
+// CalendarFeedResponse адрес подписки на календарь напоминаний
+type CalendarFeedResponse struct {
+	URL string `json:"url"`
+}
+
+// Форматы лент закладок
+const (
+	FeedRSS  = "rss"
+	FeedAtom = "atom"
+)
+
+// FeedQuery ограничивает ленту закладок папкой (вместе с подпапками) или тегом; без параметров лента охватывает весь аккаунт
+type FeedQuery struct {
+	Tag      string `form:"tag" binding:"max=64"`
+	FolderID uint   `form:"folder_id"`
+}
+
+// FeedURLsResponse адреса подписки на ленту закладок. Адреса секретные и работают без авторизации
+type FeedURLsResponse struct {
+	RSS  string `json:"rss"`
+	Atom string `json:"atom"`
+}
+
+// UsageStatsFilter параметры статистики переходов
+type UsageStatsFilter struct {
+	Limit int `form:"limit"`
+}
+
+// BookmarkUsageResponse статистика переходов по закладке
+type BookmarkUsageResponse struct {
+	LastVisitedAt *time.Time `json:"last_visited_at"`
+	Title         string     `json:"title"`
+	URL           string     `json:"url"`
+	VisitCount    int64      `json:"visit_count"`
+	ID            uint       `json:"id"`
+}
+
+// DomainUsageResponse статистика переходов по сайту
+type DomainUsageResponse struct {
+	LastVisitedAt *time.Time `json:"last_visited_at"`
+	Domain        string     `json:"domain"`
+	VisitCount    int64      `json:"visit_count"`
+	Bookmarks     int        `json:"bookmarks"`
+}
+
+// TopSitesFilter параметры списка «топ сайтов»
+type TopSitesFilter struct {
+	Limit int `form:"limit"`
+}
+
+// TopSiteResponse закладка в «топ сайтах» новой вкладки
+type TopSiteResponse struct {
+	LastVisitedAt *time.Time `json:"last_visited_at"`
+	Title         string     `json:"title"`
+	URL           string     `json:"url"`
+	Favicon       string     `json:"favicon"`
+	GoURL         string     `json:"go_url"`
+	VisitCount    int64      `json:"visit_count"`
+	Score         float64    `json:"score"`
+	ID            uint       `json:"id"`
+}
+
+// SearchBookmarksRequest параметры поиска по закладкам
+type SearchBookmarksRequest struct {
+	Query string `form:"q" binding:"required"`
+	Limit int    `form:"limit"`
+	// WorkspaceID ищет по закладкам рабочего пространства вместо личных
+	WorkspaceID uint `form:"workspace_id"`
 }
 
 type SendEmailVerificationCodeRequest struct {
 	Email string `json:"email" binding:"required,email"`
 }
+
+// IconOverrideRequest запрос на создание или изменение переопределения иконки
+type IconOverrideRequest struct {
+	Pattern string `json:"pattern" binding:"required"`
+	IconURL string `json:"icon_url" binding:"required,url"`
+}
+
+// BookmarkHealthFilter фильтры списка проверенных ссылок.
+// Status — "broken", "redirected", "unknown", "ok" или "all"; по умолчанию broken и redirected
+type BookmarkHealthFilter struct {
+	Status      string `form:"status"`
+	MinFailures int    `form:"min_failures"`
+	// WorkspaceID показывает закладки рабочего пространства вместо личных
+	WorkspaceID uint `form:"workspace_id"`
+}
+
+// BookmarkHealthResponse результат проверки ссылки закладки
+type BookmarkHealthResponse struct {
+	LastCheckedAt *time.Time `json:"last_checked_at"`
+	Title         string     `json:"title"`
+	URL           string     `json:"url"`
+	FinalURL      string     `json:"final_url"`
+	SuggestedURL  string     `json:"suggested_url"`
+	HealthStatus  string     `json:"health_status"`
+	ID            uint       `json:"id"`
+	HTTPStatus    int        `json:"http_status"`
+	CheckFailures int        `json:"check_failures"`
+}
+
+// NewBookmarkHealthResponse формирует ответ с результатом проверки ссылки
+func NewBookmarkHealthResponse(bookmark *Bookmark) BookmarkHealthResponse {
+	return BookmarkHealthResponse{
+		LastCheckedAt: bookmark.LastCheckedAt,
+		Title:         bookmark.Title,
+		URL:           bookmark.URL,
+		FinalURL:      bookmark.FinalURL,
+		SuggestedURL:  bookmark.SuggestedURL,
+		HealthStatus:  bookmark.HealthStatus,
+		ID:            bookmark.ID,
+		HTTPStatus:    bookmark.HTTPStatus,
+		CheckFailures: bookmark.CheckFailures,
+	}
+}
+
+// URLRewriteReport отчёт о заменах URL закладок
+type URLRewriteReport struct {
+	Rewrites []URLRewrite `json:"rewrites"`
+	Applied  int          `json:"applied"`
+	Undone   int          `json:"undone"`
+}
+
+// CollectionRequest запрос на создание или изменение общей коллекции
+type CollectionRequest struct {
+	Name        string `json:"name" binding:"required,max=255"`
+	Description string `json:"description" binding:"max=2000"`
+	IsPublic    bool   `json:"is_public"`
+}
+
+// CollectionResponse коллекция и роль в ней текущего пользователя
+type CollectionResponse struct {
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Role        string    `json:"role"`
+	ID          uint      `json:"id"`
+	IsPublic    bool      `json:"is_public"`
+	IsTemplate  bool      `json:"is_template"`
+	StarterPack bool      `json:"starter_pack"`
+}
+
+// NewCollectionResponse формирует ответ с данными коллекции
+func NewCollectionResponse(collection *Collection, role string) CollectionResponse {
+	return CollectionResponse{
+		ID:          collection.ID,
+		Name:        collection.Name,
+		Description: collection.Description,
+		Role:        role,
+		IsPublic:    collection.IsPublic,
+		IsTemplate:  collection.IsTemplate,
+		StarterPack: collection.StarterPack,
+		CreatedAt:   collection.CreatedAt,
+		UpdatedAt:   collection.UpdatedAt,
+	}
+}
+
+// CollectionMemberResponse участник коллекции. Другим участникам виден только username, без email
+type CollectionMemberResponse struct {
+	JoinedAt time.Time `json:"joined_at"`
+	Username string    `json:"username"`
+	Role     string    `json:"role"`
+	UserID   uint      `json:"user_id"`
+}
+
+// CollectionInviteRequest запрос на приглашение пользователя в коллекцию по email
+type CollectionInviteRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required,oneof=viewer editor owner"`
+}
+
+// CollectionRoleRequest запрос на смену роли участника коллекции
+type CollectionRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=viewer editor owner"`
+}
+
+// PublicProfileResponse публичный профиль пользователя. Содержит только поля,
+// которые пользователь сам опубликовал; email сюда не попадает
+type PublicProfileResponse struct {
+	Username    string                     `json:"username"`
+	DisplayName string                     `json:"display_name,omitempty"`
+	Bio         string                     `json:"bio,omitempty"`
+	AvatarURL   string                     `json:"avatar_url,omitempty"`
+	Collections []PublicCollectionResponse `json:"collections"`
+}
+
+// PublicCollectionResponse публичная коллекция; закладки заполняются только при просмотре самой коллекции
+type PublicCollectionResponse struct {
+	CreatedAt   time.Time                `json:"created_at"`
+	UpdatedAt   time.Time                `json:"updated_at"`
+	Name        string                   `json:"name"`
+	Description string                   `json:"description"`
+	Bookmarks   []SharedBookmarkResponse `json:"bookmarks,omitempty"`
+	ID          uint                     `json:"id"`
+}
+
+// NewPublicCollectionResponse формирует публичные данные коллекции
+func NewPublicCollectionResponse(collection *Collection) PublicCollectionResponse {
+	return PublicCollectionResponse{
+		ID:          collection.ID,
+		Name:        collection.Name,
+		Description: collection.Description,
+		CreatedAt:   collection.CreatedAt,
+		UpdatedAt:   collection.UpdatedAt,
+	}
+}
+
+// TemplateRequest запрос владельца коллекции на публикацию её как шаблона или снятие с публикации
+type TemplateRequest struct {
+	IsTemplate bool `json:"is_template"`
+}
+
+// AdminTemplateRequest запрос администратора сервиса на публикацию любой коллекции как шаблона.
+// Стартовые наборы показываются первыми в списке шаблонов
+type AdminTemplateRequest struct {
+	IsTemplate  bool `json:"is_template"`
+	StarterPack bool `json:"starter_pack"`
+}
+
+// TemplateResponse шаблон коллекции; закладки заполняются только при просмотре самого шаблона
+type TemplateResponse struct {
+	UpdatedAt     time.Time                `json:"updated_at"`
+	Name          string                   `json:"name"`
+	Description   string                   `json:"description"`
+	Bookmarks     []SharedBookmarkResponse `json:"bookmarks,omitempty"`
+	BookmarkCount int64                    `json:"bookmark_count"`
+	ID            uint                     `json:"id"`
+	StarterPack   bool                     `json:"starter_pack"`
+}
+
+// SkippedBookmarkResponse закладка шаблона, которая уже есть у пользователя. BookmarkID — его закладка с тем же адресом
+type SkippedBookmarkResponse struct {
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	BookmarkID uint   `json:"bookmark_id"`
+}
+
+// TemplateCloneResponse отчёт о копировании шаблона: добавленные и пропущенные закладки.
+// FolderID — папка верхнего уровня, в которую скопирован шаблон
+type TemplateCloneResponse struct {
+	Added          []Bookmark                `json:"added"`
+	Skipped        []SkippedBookmarkResponse `json:"skipped"`
+	FolderID       uint                      `json:"folder_id"`
+	FoldersCreated int                       `json:"folders_created"`
+}
+
+// WorkspaceRequest запрос на создание или переименование рабочего пространства
+type WorkspaceRequest struct {
+	Name string `json:"name" binding:"required,max=255"`
+}
+
+// WorkspacePlanRequest запрос администратора сервиса на смену тарифа пространства
+type WorkspacePlanRequest struct {
+	Plan string `json:"plan" binding:"required,oneof=free team business"`
+}
+
+// WorkspaceResponse рабочее пространство, роль в нём текущего пользователя и использование лимитов тарифа
+type WorkspaceResponse struct {
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	Name      string          `json:"name"`
+	Plan      string          `json:"plan"`
+	Role      string          `json:"role"`
+	Limits    WorkspaceLimits `json:"limits"`
+	Bookmarks int64           `json:"bookmarks"`
+	Members   int64           `json:"members"`
+	ID        uint            `json:"id"`
+}
+
+// WorkspaceMemberRequest запрос администратора на добавление участника по username
+type WorkspaceMemberRequest struct {
+	Username string `json:"username" binding:"required"`
+	Role     string `json:"role" binding:"required,oneof=member admin"`
+}
+
+// WorkspaceRoleRequest запрос на смену роли участника пространства
+type WorkspaceRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=member admin"`
+}
+
+// WorkspaceMemberResponse участник пространства. Email другим участникам не показывается
+type WorkspaceMemberResponse struct {
+	JoinedAt time.Time `json:"joined_at"`
+	Username string    `json:"username"`
+	Role     string    `json:"role"`
+	UserID   uint      `json:"user_id"`
+}
+
+// CommentRequest запрос на создание комментария. ParentID — комментарий той же закладки, на который дан ответ.
+// Упоминания вида @username присылают упомянутым участникам письмо
+type CommentRequest struct {
+	ParentID *uint  `json:"parent_id"`
+	Body     string `json:"body" binding:"required,max=5000"`
+}
+
+// UpdateCommentRequest запрос на изменение текста комментария
+type UpdateCommentRequest struct {
+	Body string `json:"body" binding:"required,max=5000"`
+}
+
+// CommentResponse комментарий с автором и ответами на него. У удалённого комментария нет текста и автора
+type CommentResponse struct {
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	ParentID  *uint             `json:"parent_id"`
+	Username  string            `json:"username"`
+	Body      string            `json:"body"`
+	Replies   []CommentResponse `json:"replies"`
+	ID        uint              `json:"id"`
+	UserID    uint              `json:"user_id"`
+	Edited    bool              `json:"edited"`
+	Deleted   bool              `json:"deleted"`
+}
+
+// ExportedComment комментарий в экспорте закладок. Удалённые комментарии остаются без текста, чтобы сохранить ветки
+type ExportedComment struct {
+	CreatedAt time.Time `json:"created_at"`
+	ParentID  *uint     `json:"parent_id"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	ID        uint      `json:"id"`
+	Deleted   bool      `json:"deleted,omitempty"`
+}
+
+// ExportedBookmark закладка в экспорте вместе с комментариями к ней
+type ExportedBookmark struct {
+	Bookmark
+	Comments []ExportedComment `json:"comments"`
+}
+
+// ActivityFilter параметры журнала активности. Before — ID записи, после которой продолжить выдачу
+type ActivityFilter struct {
+	CollectionID uint `form:"collection_id"`
+	WorkspaceID  uint `form:"workspace_id"`
+	ActorID      uint `form:"actor_id"`
+	Before       uint `form:"before"`
+	Limit        int  `form:"limit"`
+}
+
+// ActivityResponse запись журнала активности с username того, кто совершил действие.
+// Fields перечисляет изменённые поля закладки для действия edited
+type ActivityResponse struct {
+	CreatedAt        time.Time `json:"created_at"`
+	CollectionID     *uint     `json:"collection_id"`
+	FromCollectionID *uint     `json:"from_collection_id"`
+	WorkspaceID      *uint     `json:"workspace_id"`
+	Action           string    `json:"action"`
+	Actor            string    `json:"actor"`
+	BookmarkTitle    string    `json:"bookmark_title"`
+	BookmarkURL      string    `json:"bookmark_url"`
+	Fields           []string  `json:"fields"`
+	ID               uint      `json:"id"`
+	ActorID          uint      `json:"actor_id"`
+	BookmarkID       uint      `json:"bookmark_id"`
+}
+
+// ActivityPageResponse страница журнала активности. NextBefore передаётся в before для следующей страницы;
+// null — записей больше нет
+type ActivityPageResponse struct {
+	NextBefore *uint              `json:"next_before"`
+	Items      []ActivityResponse `json:"items"`
+}
+
+// SendBookmarkRequest запрос на отправку закладки во входящие другого пользователя
+type SendBookmarkRequest struct {
+	Username string `json:"username" binding:"required"`
+	Note     string `json:"note" binding:"max=500"`
+}
+
+// InboxItemResponse закладка во входящих с username отправителя
+type InboxItemResponse struct {
+	CreatedAt   time.Time `json:"created_at"`
+	Sender      string    `json:"sender"`
+	Title       string    `json:"title"`
+	URL         string    `json:"url"`
+	Description string    `json:"description"`
+	Favicon     string    `json:"favicon"`
+	ImageURL    string    `json:"image_url"`
+	SiteName    string    `json:"site_name"`
+	Kind        string    `json:"kind"`
+	Note        string    `json:"note"`
+	ID          uint      `json:"id"`
+	SenderID    uint      `json:"sender_id"`
+}
+
+// BlockUserRequest запрос на запрет пользователю отправлять закладки во входящие
+type BlockUserRequest struct {
+	Username string `json:"username" binding:"required"`
+}
+
+// BlockedUserResponse пользователь, от которого не принимаются закладки
+type BlockedUserResponse struct {
+	BlockedAt time.Time `json:"blocked_at"`
+	Username  string    `json:"username"`
+	UserID    uint      `json:"user_id"`
+}
+
+// WidgetRequest настройки виджета коллекции. AllowedOrigins — сайты вида https://example.com,
+// на которых разрешено встраивать виджет. Limit 0 означает значение по умолчанию
+type WidgetRequest struct {
+	ShowFavicons   *bool    `json:"show_favicons"`
+	Layout         string   `json:"layout" binding:"omitempty,oneof=list tiles"`
+	AllowedOrigins []string `json:"allowed_origins" binding:"required,min=1,max=20"`
+	Limit          int      `json:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// WidgetResponse виджет коллекции с адресами для встраивания и готовым кодом iframe
+type WidgetResponse struct {
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	Layout         string    `json:"layout"`
+	URL            string    `json:"url"`
+	APIURL         string    `json:"api_url"`
+	Snippet        string    `json:"snippet"`
+	AllowedOrigins []string  `json:"allowed_origins"`
+	ID             uint      `json:"id"`
+	CollectionID   uint      `json:"collection_id"`
+	Limit          int       `json:"limit"`
+	ShowFavicons   bool      `json:"show_favicons"`
+}
+
+// NewWidgetResponse формирует ответ с данными виджета; baseURL — публичный адрес сервиса
+func NewWidgetResponse(widget *Widget, baseURL string) WidgetResponse {
+	embedURL := baseURL + WidgetPath(widget.Token)
+	return WidgetResponse{
+		ID:             widget.ID,
+		CollectionID:   widget.CollectionID,
+		Layout:         widget.Layout,
+		Limit:          widget.Limit,
+		ShowFavicons:   widget.ShowFavicons,
+		AllowedOrigins: widget.AllowedOrigins,
+		URL:            embedURL,
+		APIURL:         baseURL + WidgetAPIPath(widget.Token),
+		Snippet:        `<iframe src="` + embedURL + `" width="100%" height="480" style="border:0" loading="lazy" title="Theca bookmarks"></iframe>`,
+		CreatedAt:      widget.CreatedAt,
+		UpdatedAt:      widget.UpdatedAt,
+	}
+}
+
+// WidgetQuery параметры оформления, которые сайт может переопределить при встраивании виджета.
+// Limit не может превышать лимит, заданный владельцем виджета
+type WidgetQuery struct {
+	Favicons *bool  `form:"favicons"`
+	Layout   string `form:"layout" binding:"omitempty,oneof=list tiles"`
+	Limit    int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// EmbedResponse содержимое виджета публичной коллекции. AllowedOrigins нужны только для заголовков ответа
+type EmbedResponse struct {
+	Name           string                   `json:"name"`
+	Description    string                   `json:"description"`
+	Layout         string                   `json:"layout"`
+	Bookmarks      []SharedBookmarkResponse `json:"bookmarks"`
+	AllowedOrigins []string                 `json:"-"`
+	ShowFavicons   bool                     `json:"show_favicons"`
+}
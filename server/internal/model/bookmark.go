@@ -4,19 +4,249 @@ import "time"
 
 // Bookmark представляет собой модель закладки
 type Bookmark struct {
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	LastCheckedAt *time.Time `json:"last_checked_at" gorm:"index"`
+	SnapshotAt    *time.Time `json:"snapshot_at"`
+	ReadAt        *time.Time `json:"read_at"`
+	ArchivedAt    *time.Time `json:"archived_at"`
+	FavoritedAt   *time.Time `json:"favorited_at"`
+	ProgressAt    *time.Time `json:"progress_at"`
+	RemindAt      *time.Time `json:"remind_at" gorm:"index"`
+	SnoozedUntil  *time.Time `json:"snoozed_until" gorm:"index"`
+	ResurfacedAt  *time.Time `json:"resurfaced_at"`
+	LastVisitedAt *time.Time `json:"last_visited_at"`
+	PublishedAt   *time.Time `json:"published_at"`
+	FolderID      *uint      `json:"folder_id" gorm:"index"`
+	CollectionID  *uint      `json:"collection_id" gorm:"index"`
+	WorkspaceID   *uint      `json:"workspace_id" gorm:"index"`
+	Title         string     `json:"title"`
+	URL           string     `json:"url"`
+	Favicon       string     `json:"favicon"`
+	Description   string     `json:"description"`
+	ImageURL      string     `json:"image_url"`
+	SiteName      string     `json:"site_name"`
+	Language      string     `json:"language" gorm:"size:35"`
+	CanonicalURL  string     `json:"canonical_url"`
+	PreviewToken  string     `json:"-" gorm:"size:64"`
+	FinalURL      string     `json:"final_url"`
+	SuggestedURL  string     `json:"suggested_url"`
+	HealthStatus  string     `json:"health_status" gorm:"size:16;index"`
+	Author        string     `json:"author"`
+	State         string     `json:"state" gorm:"size:16;index;not null;default:read"`
+	Kind          string     `json:"kind" gorm:"size:16;index"`
+	Icons         []Icon     `json:"icons" gorm:"serializer:json"`
+	Tags          []string   `json:"tags" gorm:"serializer:json"`
+	Progress      float64    `json:"reading_progress" gorm:"default:0"`
+	VisitCount    int64      `json:"visit_count" gorm:"default:0"`
+	Frecency      float64    `json:"-" gorm:"default:0"`
+	ID            uint       `json:"id"`
+	UserID        uint       `json:"user_id"`
+	HTTPStatus    int        `json:"http_status"`
+	CheckFailures int        `json:"check_failures" gorm:"default:0"`
+	WordCount     int        `json:"word_count" gorm:"default:0"`
+	ShowText      bool       `json:"show_text"`
+	Favorite      bool       `json:"favorite" gorm:"index;default:false"`
+}
+
+// WordsPerMinute средняя скорость чтения, по которой оценивается время чтения
+const WordsPerMinute = 230
+
+// ReadingTime возвращает примерное время чтения в минутах, 0 если текст статьи не извлечён
+func (b *Bookmark) ReadingTime() int {
+	if b.WordCount <= 0 {
+		return 0
+	}
+	return (b.WordCount + WordsPerMinute - 1) / WordsPerMinute
+}
+
+// Виды содержимого закладки (поле Kind), определяются при сохранении ссылки
+const (
+	KindArticle       = "article"
+	KindVideo         = "video"
+	KindRepository    = "repository"
+	KindDocumentation = "documentation"
+	KindPDF           = "pdf"
+	KindImage         = "image"
+	KindSocial        = "social"
+	KindShop          = "shop"
+	KindOther         = "other"
+)
+
+// IsValidKind проверяет, что вид содержимого известен
+func IsValidKind(kind string) bool {
+	switch kind {
+	case KindArticle, KindVideo, KindRepository, KindDocumentation, KindPDF, KindImage, KindSocial, KindShop, KindOther:
+		return true
+	}
+	return false
+}
+
+// Состояния закладки в списке «прочитать позже» (поле State).
+// Новые закладки попадают во входящие как непрочитанные.
+// Progress хранит прочитанную долю статьи от 0 до 1, чтобы продолжить чтение с того же места
+const (
+	StateUnread   = "unread"
+	StateRead     = "read"
+	StateArchived = "archived"
+)
+
+// IsValidState проверяет, что состояние закладки известно
+func IsValidState(state string) bool {
+	return state == StateUnread || state == StateRead || state == StateArchived
+}
+
+// SetState переводит закладку в новое состояние и запоминает время перехода
+func (b *Bookmark) SetState(state string, now time.Time) {
+	switch state {
+	case StateUnread:
+		b.ReadAt = nil
+		b.ArchivedAt = nil
+	case StateRead:
+		if b.ReadAt == nil {
+			b.ReadAt = &now
+		}
+		b.ArchivedAt = nil
+	case StateArchived:
+		if b.ArchivedAt == nil {
+			b.ArchivedAt = &now
+		}
+	}
+	b.State = state
+}
+
+// IsSnoozed сообщает, что закладка отложена и пока скрыта из входящих
+func (b *Bookmark) IsSnoozed(now time.Time) bool {
+	return b.SnoozedUntil != nil && b.SnoozedUntil.After(now)
+}
+
+// SetFavorite добавляет закладку в избранное или убирает из него
+func (b *Bookmark) SetFavorite(favorite bool, now time.Time) {
+	switch {
+	case !favorite:
+		b.FavoritedAt = nil
+	case b.FavoritedAt == nil:
+		b.FavoritedAt = &now
+	}
+	b.Favorite = favorite
+}
+
+// Состояния доступности ссылки закладки
+const (
+	// HealthStatusUnchecked ссылка ещё не проверялась
+	HealthStatusUnchecked = ""
+	HealthStatusOK        = "ok"
+	// HealthStatusRedirected страница открывается, но по другому адресу
+	HealthStatusRedirected = "redirected"
+	// HealthStatusBroken страница не найдена, сервер отвечает ошибкой или домен недоступен
+	HealthStatusBroken = "broken"
+	// HealthStatusUnknown сайт не пускает проверку (401, 403), состояние не определить
+	HealthStatusUnknown = "unknown"
+)
+
+// ResetHealth сбрасывает результаты проверки ссылки, например после смены URL
+func (b *Bookmark) ResetHealth() {
+	b.LastCheckedAt = nil
+	b.FinalURL = ""
+	b.SuggestedURL = ""
+	b.HealthStatus = HealthStatusUnchecked
+	b.HTTPStatus = 0
+	b.CheckFailures = 0
+}
+
+// Icon иконка сайта одного из размеров в виде data URI.
+// Size — меньшая сторона в пикселях, 0 если размер неизвестен
+type Icon struct {
+	Data string `json:"data"`
+	Size int    `json:"size"`
+}
+
+// IconOverride задаёт иконку для доменов, с которых её не удаётся получить автоматически.
+// Pattern — домен ("gmail.com") или маска поддоменов ("*.google.com")
+type IconOverride struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
-	Title     string    `json:"title"`
-	URL       string    `json:"url"`
-	Favicon   string    `json:"favicon"`
+	Pattern   string    `json:"pattern" gorm:"size:255;uniqueIndex;not null"`
+	IconURL   string    `json:"icon_url" gorm:"not null"`
 	ID        uint      `json:"id"`
-	UserID    uint      `json:"user_id"`
-	ShowText  bool      `json:"show_text"`
 }
 
-// ImportBookmarksRequest представляет запрос на импорт закладок
+// SeedIconOverrides имя начального заполнения таблицы переопределений иконок
+const SeedIconOverrides = "icon_overrides"
+
+// AppliedSeed отметка о том, что начальные данные уже записаны в базу.
+// Удалённые администратором записи не возвращаются при следующем запуске
+type AppliedSeed struct {
+	CreatedAt time.Time `json:"created_at"`
+	Name      string    `json:"name" gorm:"primaryKey;size:64"`
+}
+
+// URLRewrite запись о замене URL закладки на адрес, куда ведёт постоянный редирект.
+// Хранится, чтобы замену можно было отменить
+type URLRewrite struct {
+	CreatedAt  time.Time  `json:"created_at" gorm:"index"`
+	UndoneAt   *time.Time `json:"undone_at"`
+	OldURL     string     `json:"old_url"`
+	NewURL     string     `json:"new_url"`
+	ID         uint       `json:"id"`
+	BookmarkID uint       `json:"bookmark_id" gorm:"index;not null"`
+	UserID     uint       `json:"user_id" gorm:"index;not null"`
+	Automatic  bool       `json:"automatic"`
+}
+
+// BookmarkPreview миниатюра превью страницы закладки, построенная по og:image.
+// Отдаётся публично по непредсказуемому токену
+type BookmarkPreview struct {
+	CreatedAt   time.Time `json:"created_at"`
+	Token       string    `json:"token" gorm:"size:64;uniqueIndex;not null"`
+	ContentType string    `json:"content_type" gorm:"size:64"`
+	SourceURL   string    `json:"source_url"`
+	Data        []byte    `json:"-"`
+	Size        int64     `json:"size"`
+	ID          uint      `json:"id"`
+	BookmarkID  uint      `json:"bookmark_id" gorm:"uniqueIndex;not null"`
+	UserID      uint      `json:"user_id" gorm:"index;not null"`
+	Width       int       `json:"width"`
+	Height      int       `json:"height"`
+}
+
+// BookmarkContent текст статьи, извлечённый со страницы закладки для режима чтения и поиска
+type BookmarkContent struct {
+	UpdatedAt  time.Time `json:"updated_at"`
+	Text       string    `json:"text" gorm:"type:text"`
+	ID         uint      `json:"id"`
+	BookmarkID uint      `json:"bookmark_id" gorm:"uniqueIndex;not null"`
+	UserID     uint      `json:"user_id" gorm:"index;not null"`
+}
+
+// BookmarkArchive офлайн-снимок страницы закладки. Сам HTML хранится в blob-хранилище по StorageKey
+type BookmarkArchive struct {
+	CreatedAt   time.Time `json:"created_at"`
+	StorageKey  string    `json:"-"`
+	ContentType string    `json:"content_type" gorm:"size:64"`
+	SourceURL   string    `json:"source_url"`
+	Size        int64     `json:"size"`
+	ID          uint      `json:"id"`
+	BookmarkID  uint      `json:"bookmark_id" gorm:"uniqueIndex;not null"`
+	UserID      uint      `json:"user_id" gorm:"index;not null"`
+	Resources   int       `json:"resources"`
+	// Truncated часть ресурсов страницы не поместилась в лимит снимка
+	Truncated bool `json:"truncated"`
+}
+
+// PreviewPath возвращает путь, по которому отдаётся превью с указанным токеном
+func PreviewPath(token string) string {
+	if token == "" {
+		return ""
+	}
+	return "/v1/previews/" + token
+}
+
+// ImportBookmarksRequest представляет запрос на импорт закладок.
+// WorkspaceID импортирует закладки в рабочее пространство вместо личных
 type ImportBookmarksRequest struct {
-	File string `json:"file" binding:"required"`
+	File        string `json:"file" binding:"required"`
+	WorkspaceID uint   `json:"workspace_id"`
 }
 
 // ExportBookmarksResponse представляет ответ на экспорт закладок
@@ -24,19 +254,22 @@ type ExportBookmarksResponse struct {
 	File string `json:"file"`
 }
 
-
+// BookmarkV2Request закладка в формате импорта/экспорта v2.
+// State — unread, read или archived; пустое значение означает read
 type BookmarkV2Request struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 	Title     string    `json:"title"`
 	URL       string    `json:"url"`
 	Favicon   string    `json:"favicon"`
+	State     string    `json:"state"`
 	ID        uint      `json:"-"`
 	UserID    uint      `json:"user_id"`
 	ShowText  bool      `json:"show_text"`
+	Favorite  bool      `json:"favorite"`
 }
 
 type ImportBookmarksV2Request struct {
-	Bookmarks []BookmarkV2Request `json:"bookmarks"`
-
+	Bookmarks   []BookmarkV2Request `json:"bookmarks"`
+	WorkspaceID uint                `json:"workspace_id"`
 }
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"strconv"
+	"time"
+)
+
+// linkSigningKey ключ подписи ссылок перехода, задаётся при запуске приложения
+var linkSigningKey []byte
+
+// SetLinkSigningKey задаёт ключ подписи ссылок перехода
+func SetLinkSigningKey(key []byte) {
+	linkSigningKey = key
+}
+
+// ClickSignature подпись ссылки перехода по закладке. Подписанная ссылка открывается без авторизации,
+// например из плиток новой вкладки
+func ClickSignature(bookmarkID uint) string {
+	mac := hmac.New(sha256.New, linkSigningKey)
+	mac.Write([]byte("go:" + strconv.FormatUint(uint64(bookmarkID), 10)))
+	return hex.EncodeToString(mac.Sum(nil))[:32]
+}
+
+// ValidClickSignature проверяет подпись ссылки перехода
+func ValidClickSignature(bookmarkID uint, signature string) bool {
+	if len(linkSigningKey) == 0 || signature == "" {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(ClickSignature(bookmarkID)))
+}
+
+// ClickPath возвращает подписанную ссылку перехода по закладке с учётом посещения
+func ClickPath(bookmarkID uint) string {
+	path := "/v1/go/" + strconv.FormatUint(uint64(bookmarkID), 10)
+	if len(linkSigningKey) == 0 {
+		return path
+	}
+	return path + "?sig=" + ClickSignature(bookmarkID)
+}
+
+// FrecencyAt возвращает вес закладки для «топ сайтов» на момент now. Каждое посещение добавляет
+// единицу, которая убывает вдвое за halfLife. Закладки, посещённые до появления веса, считаются
+// посещёнными VisitCount раз в момент последнего посещения
+func (b *Bookmark) FrecencyAt(now time.Time, halfLife time.Duration) float64 {
+	if b.LastVisitedAt == nil {
+		return 0
+	}
+
+	score := b.Frecency
+	if score == 0 {
+		score = float64(b.VisitCount)
+	}
+
+	age := now.Sub(*b.LastVisitedAt)
+	if age <= 0 || halfLife <= 0 {
+		return score
+	}
+	return score * math.Exp2(-float64(age)/float64(halfLife))
+}
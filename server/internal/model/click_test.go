@@ -0,0 +1,62 @@
+package model
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFrecencyAt(t *testing.T) {
+	now := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+	halfLife := 7 * 24 * time.Hour
+	at := func(d time.Duration) *time.Time {
+		visited := now.Add(-d)
+		return &visited
+	}
+
+	tests := []struct {
+		name     string
+		bookmark Bookmark
+		halfLife time.Duration
+		want     float64
+	}{
+		{"never visited", Bookmark{Frecency: 5, VisitCount: 3}, halfLife, 0},
+		{"visited just now", Bookmark{Frecency: 4, LastVisitedAt: at(0)}, halfLife, 4},
+		{"one half-life ago", Bookmark{Frecency: 4, LastVisitedAt: at(halfLife)}, halfLife, 2},
+		{"two half-lives ago", Bookmark{Frecency: 4, LastVisitedAt: at(2 * halfLife)}, halfLife, 1},
+		{"half a half-life ago", Bookmark{Frecency: 1, LastVisitedAt: at(halfLife / 2)}, halfLife, math.Sqrt2 / 2},
+		{"visit count before frecency", Bookmark{VisitCount: 6, LastVisitedAt: at(halfLife)}, halfLife, 3},
+		{"frecency wins over visit count", Bookmark{Frecency: 2, VisitCount: 6, LastVisitedAt: at(halfLife)}, halfLife, 1},
+		{"visit in the future", Bookmark{Frecency: 3, LastVisitedAt: at(-time.Hour)}, halfLife, 3},
+		{"no half-life", Bookmark{Frecency: 3, LastVisitedAt: at(halfLife)}, 0, 3},
+		{"negative half-life", Bookmark{Frecency: 3, LastVisitedAt: at(halfLife)}, -time.Hour, 3},
+		{"visited but nothing counted", Bookmark{LastVisitedAt: at(time.Hour)}, halfLife, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.bookmark.FrecencyAt(now, tt.halfLife)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Fatalf("FrecencyAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFrecencyAtVisitsAccumulate(t *testing.T) {
+	halfLife := 24 * time.Hour
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Так вес обновляется при каждом переходе: текущий вес на момент перехода плюс единица
+	var bookmark Bookmark
+	for day := range 3 {
+		visitedAt := start.Add(time.Duration(day) * halfLife)
+		bookmark.Frecency = bookmark.FrecencyAt(visitedAt, halfLife) + 1
+		bookmark.LastVisitedAt = &visitedAt
+	}
+
+	// 1/4 + 1/2 + 1 на момент третьего перехода
+	if got := bookmark.FrecencyAt(*bookmark.LastVisitedAt, halfLife); math.Abs(got-1.75) > 1e-9 {
+		t.Fatalf("frecency after three daily visits = %v, want 1.75", got)
+	}
+}
@@ -0,0 +1,58 @@
+package model
+
+import "time"
+
+// Роли участников общей коллекции: viewer только читает, editor добавляет и изменяет закладки,
+// owner дополнительно управляет участниками и самой коллекцией
+const (
+	RoleViewer = "viewer"
+	RoleEditor = "editor"
+	RoleOwner  = "owner"
+)
+
+// IsValidRole проверяет, что роль участника коллекции известна
+func IsValidRole(role string) bool {
+	return role == RoleViewer || role == RoleEditor || role == RoleOwner
+}
+
+// CanEdit проверяет, может ли участник с этой ролью добавлять и изменять закладки
+func CanEdit(role string) bool {
+	return role == RoleEditor || role == RoleOwner
+}
+
+// Collection общая коллекция закладок нескольких пользователей.
+// Публичную коллекцию может просмотреть кто угодно, она показывается в профилях её владельцев.
+// Коллекцию-шаблон любой пользователь может скопировать к себе; стартовые наборы отмечает администратор
+type Collection struct {
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Name        string    `json:"name" gorm:"size:255;not null"`
+	Description string    `json:"description"`
+	ID          uint      `json:"id"`
+	IsPublic    bool      `json:"is_public" gorm:"default:false;index"`
+	IsTemplate  bool      `json:"is_template" gorm:"default:false;index"`
+	StarterPack bool      `json:"starter_pack" gorm:"default:false"`
+}
+
+// CollectionMember участник коллекции и его роль
+type CollectionMember struct {
+	CreatedAt    time.Time `json:"created_at"`
+	Role         string    `json:"role" gorm:"size:16;not null"`
+	CollectionID uint      `json:"collection_id" gorm:"primaryKey;autoIncrement:false"`
+	UserID       uint      `json:"user_id" gorm:"primaryKey;autoIncrement:false;index"`
+}
+
+// CollectionInviteTTL время действия приглашения в коллекцию
+const CollectionInviteTTL = 7 * 24 * time.Hour
+
+// CollectionInvite приглашение в коллекцию по email. Принять его может только пользователь с этим адресом
+type CollectionInvite struct {
+	CreatedAt    time.Time `json:"created_at"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	Email        string    `json:"email" gorm:"size:255;index;not null"`
+	Role         string    `json:"role" gorm:"size:16;not null"`
+	Token        string    `json:"-" gorm:"size:64;uniqueIndex;not null"`
+	ID           uint      `json:"id"`
+	CollectionID uint      `json:"collection_id" gorm:"index;not null"`
+	InvitedBy    uint      `json:"invited_by"`
+}
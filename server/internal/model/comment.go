@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// MaxCommentLength максимальная длина текста комментария в символах
+const MaxCommentLength = 5000
+
+// Comment комментарий к закладке. ParentID указывает на комментарий, на который дан ответ.
+// Удалённый комментарий с ответами остаётся в ветке без текста, чтобы ответы не потеряли контекст
+type Comment struct {
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	ParentID   *uint     `json:"parent_id" gorm:"index"`
+	Body       string    `json:"body" gorm:"type:text"`
+	ID         uint      `json:"id"`
+	BookmarkID uint      `json:"bookmark_id" gorm:"index;not null"`
+	UserID     uint      `json:"user_id" gorm:"index;not null"`
+	Deleted    bool      `json:"deleted" gorm:"default:false"`
+}
+
+// Edited проверяет, менялся ли текст комментария после публикации
+func (c *Comment) Edited() bool {
+	return c.UpdatedAt.After(c.CreatedAt)
+}
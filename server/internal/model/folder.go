@@ -0,0 +1,72 @@
+package model
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Folder папка закладок пользователя или рабочего пространства. ParentID задаёт вложенность,
+// nil — папка верхнего уровня. У папки пространства UserID — её создатель
+type Folder struct {
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	ParentID    *uint     `json:"parent_id" gorm:"index"`
+	WorkspaceID *uint     `json:"workspace_id" gorm:"index"`
+	Name        string    `json:"name" gorm:"size:255;not null"`
+	ID          uint      `json:"id"`
+	UserID      uint      `json:"user_id" gorm:"index;not null"`
+}
+
+// Ограничения на теги закладки
+const (
+	MaxTagLength       = 64
+	MaxTagsPerBookmark = 32
+)
+
+// NormalizeTag приводит тег к нижнему регистру и схлопывает пробелы.
+// Возвращает false для пустого или слишком длинного тега и тегов со служебными символами
+func NormalizeTag(tag string) (string, bool) {
+	tag = strings.ToLower(strings.Join(strings.Fields(tag), " "))
+	if tag == "" || len([]rune(tag)) > MaxTagLength {
+		return "", false
+	}
+	for _, r := range tag {
+		if unicode.IsControl(r) || strings.ContainsRune(`"\%,`, r) {
+			return "", false
+		}
+	}
+	return tag, true
+}
+
+// NormalizeTags нормализует список тегов и убирает повторы, сохраняя порядок
+func NormalizeTags(tags []string) ([]string, bool) {
+	if len(tags) > MaxTagsPerBookmark {
+		return nil, false
+	}
+
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag, ok := NormalizeTag(tag)
+		if !ok {
+			return nil, false
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized, true
+}
+
+// HasTag проверяет, отмечена ли закладка тегом
+func (b *Bookmark) HasTag(tag string) bool {
+	for _, t := range b.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
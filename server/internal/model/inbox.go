@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+// Состояния закладки во входящих получателя
+const (
+	InboxPending   = "pending"
+	InboxAccepted  = "accepted"
+	InboxDismissed = "dismissed"
+)
+
+// MaxInboxNoteLength максимальная длина заметки к отправленной закладке в символах
+const MaxInboxNoteLength = 500
+
+// InboxItem закладка, отправленная одним пользователем другому. Хранит копию закладки на момент отправки,
+// поэтому не зависит от последующих изменений оригинала. BookmarkID — закладка, созданная у получателя при принятии
+type InboxItem struct {
+	CreatedAt   time.Time  `json:"created_at"`
+	RespondedAt *time.Time `json:"responded_at"`
+	BookmarkID  *uint      `json:"bookmark_id"`
+	Title       string     `json:"title"`
+	URL         string     `json:"url"`
+	Description string     `json:"description"`
+	Favicon     string     `json:"favicon"`
+	ImageURL    string     `json:"image_url"`
+	SiteName    string     `json:"site_name"`
+	Kind        string     `json:"kind" gorm:"size:16"`
+	Note        string     `json:"note" gorm:"size:500"`
+	Status      string     `json:"status" gorm:"size:16;index;not null;default:pending"`
+	Icons       []Icon     `json:"icons" gorm:"serializer:json"`
+	ID          uint       `json:"id"`
+	SenderID    uint       `json:"sender_id" gorm:"index;not null"`
+	RecipientID uint       `json:"recipient_id" gorm:"index;not null"`
+}
+
+// UserBlock запрещает пользователю BlockedUserID отправлять закладки во входящие пользователя UserID
+type UserBlock struct {
+	CreatedAt     time.Time `json:"created_at"`
+	UserID        uint      `json:"user_id" gorm:"primaryKey;autoIncrement:false"`
+	BlockedUserID uint      `json:"blocked_user_id" gorm:"primaryKey;autoIncrement:false"`
+}
@@ -1,12 +1,35 @@
 package model
 
+import "time"
+
+// User учётная запись пользователя. Digest* — настройки еженедельной рассылки: день недели (0 — воскресенье),
+// час и часовой пояс отправки, язык письма
 type User struct {
-	Email               string `json:"email" gorm:"size:255;unique;not null;index:idx_users_email"`
-	Username            string `json:"username" gorm:"size:255;unique;not null;index:idx_users_username"`
-	PassHash            string `json:"-" gorm:"size:255;not null"`
-	ID                  uint   `json:"id" gorm:"primary_key;unique;not null"`
-	RefreshTokenVersion uint   `json:"-" gorm:"default:0"`
-	AmountOfBookmarks   uint   `json:"amount_of_bookmarks" gorm:"default:0"`
-	IsVerified          bool   `json:"-" gorm:"default:false;index:idx_users_is_verified"`
-	IsPremium           bool   `json:"is_premium" gorm:"default:false"`
+	DigestSentAt        *time.Time `json:"-"`
+	Email               string     `json:"email" gorm:"size:255;unique;not null;index:idx_users_email"`
+	Username            string     `json:"username" gorm:"size:255;unique;not null;index:idx_users_username"`
+	PassHash            string     `json:"-" gorm:"size:255;not null"`
+	FeedToken           string     `json:"-" gorm:"size:64;index"`
+	UnsubscribeToken    string     `json:"-" gorm:"size:64;index"`
+	DigestTimezone      string     `json:"digest_timezone" gorm:"size:64;default:UTC"`
+	DigestLanguage      string     `json:"digest_language" gorm:"size:8;default:en"`
+	DisplayName         string     `json:"display_name" gorm:"size:64"`
+	Bio                 string     `json:"bio" gorm:"size:500"`
+	AvatarURL           string     `json:"avatar_url" gorm:"size:2048"`
+	ID                  uint       `json:"id" gorm:"primary_key;unique;not null"`
+	RefreshTokenVersion uint       `json:"-" gorm:"default:0"`
+	AmountOfBookmarks   uint       `json:"amount_of_bookmarks" gorm:"default:0"`
+	StorageUsed         int64      `json:"storage_used" gorm:"default:0"`
+	DigestWeekday       int        `json:"digest_weekday" gorm:"default:1"`
+	DigestHour          int        `json:"digest_hour" gorm:"default:9"`
+	IsVerified          bool       `json:"-" gorm:"default:false;index:idx_users_is_verified"`
+	IsPremium           bool       `json:"is_premium" gorm:"default:false"`
+	DigestEnabled       bool       `json:"digest_enabled" gorm:"default:false;index"`
+	// AutoRewriteRedirects заменять URL закладок при постоянном редиректе без подтверждения
+	AutoRewriteRedirects bool `json:"auto_rewrite_redirects" gorm:"default:false"`
+	IsAdmin              bool `json:"-" gorm:"default:false"` // выдаётся вручную в базе данных
+	// TrackVisits учитывать переходы по закладкам в статистике
+	TrackVisits bool `json:"track_visits" gorm:"default:true"`
+	// ProfilePublic показывать публичный профиль по username; email в нём не раскрывается никогда
+	ProfilePublic bool `json:"profile_public" gorm:"default:false"`
 }
@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+// Виды общих ссылок (поле ShareLink.Kind)
+const (
+	ShareFolder    = "folder"
+	ShareTag       = "tag"
+	ShareSelection = "selection"
+)
+
+// MaxShareSelection максимальное количество закладок в общей ссылке на выбранные закладки
+const MaxShareSelection = 500
+
+// ShareLink публичная ссылка только для чтения на папку, тег или выбранные закладки.
+// Token нельзя угадать; PasswordHash пуст, если пароль не задан
+type ShareLink struct {
+	CreatedAt    time.Time  `json:"created_at"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+	LastViewedAt *time.Time `json:"last_viewed_at"`
+	FolderID     *uint      `json:"folder_id" gorm:"index"`
+	Token        string     `json:"-" gorm:"size:64;uniqueIndex;not null"`
+	Kind         string     `json:"kind" gorm:"size:16;not null"`
+	Title        string     `json:"title"`
+	Tag          string     `json:"tag" gorm:"size:64"`
+	PasswordHash string     `json:"-"`
+	BookmarkIDs  []uint     `json:"bookmark_ids" gorm:"serializer:json"`
+	ViewCount    int64      `json:"view_count" gorm:"default:0"`
+	ID           uint       `json:"id"`
+	UserID       uint       `json:"user_id" gorm:"index;not null"`
+}
+
+// IsExpired проверяет, истёк ли срок действия ссылки
+func (l *ShareLink) IsExpired(now time.Time) bool {
+	return l.ExpiresAt != nil && !l.ExpiresAt.After(now)
+}
+
+// SharePath возвращает путь к HTML-странице общей ссылки
+func SharePath(token string) string {
+	return "/v1/s/" + token
+}
+
+// ShareAPIPath возвращает путь к JSON-представлению общей ссылки
+func ShareAPIPath(token string) string {
+	return "/v1/shares/" + token
+}
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Виды оформления виджета коллекции
+const (
+	WidgetList  = "list"
+	WidgetTiles = "tiles"
+)
+
+// Ограничения виджета: число закладок по умолчанию и максимум, число разрешённых источников
+const (
+	DefaultWidgetLimit = 20
+	MaxWidgetLimit     = 100
+	MaxWidgetOrigins   = 20
+)
+
+// Widget встраиваемый виджет публичной коллекции. У каждого виджета свой токен;
+// встроить его можно только на сайты из AllowedOrigins
+type Widget struct {
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	Token          string    `json:"-" gorm:"size:64;uniqueIndex;not null"`
+	Layout         string    `json:"layout" gorm:"size:16;not null;default:list"`
+	AllowedOrigins []string  `json:"allowed_origins" gorm:"serializer:json"`
+	ID             uint      `json:"id"`
+	CollectionID   uint      `json:"collection_id" gorm:"index;not null"`
+	UserID         uint      `json:"user_id" gorm:"index;not null"`
+	Limit          int       `json:"limit" gorm:"not null;default:20"`
+	ShowFavicons   bool      `json:"show_favicons"`
+}
+
+// IsOriginAllowed проверяет, что источник есть в списке разрешённых источников виджета
+func IsOriginAllowed(allowed []string, origin string) bool {
+	origin, ok := NormalizeOrigin(origin)
+	return ok && slices.Contains(allowed, origin)
+}
+
+// WidgetPath возвращает путь к HTML-виджету для встраивания через iframe
+func WidgetPath(token string) string {
+	return "/v1/embed/" + token
+}
+
+// WidgetAPIPath возвращает путь к JSON-представлению виджета
+func WidgetAPIPath(token string) string {
+	return WidgetPath(token) + "/json"
+}
+
+// NormalizeOrigin приводит источник к виду scheme://host[:port] в нижнем регистре.
+// Возвращает false для адресов не по http(s), с путём, запросом или учётными данными
+func NormalizeOrigin(origin string) (string, bool) {
+	parsed, err := url.Parse(strings.TrimSpace(origin))
+	if err != nil || parsed.Host == "" || parsed.User != nil || parsed.RawQuery != "" || parsed.Fragment != "" {
+		return "", false
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", false
+	}
+	if parsed.Path != "" && parsed.Path != "/" {
+		return "", false
+	}
+	// Источник попадает в заголовок Content-Security-Policy, поэтому в хосте допустимы только безопасные символы
+	for _, r := range parsed.Host {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune(".-:[]", r)) {
+			return "", false
+		}
+	}
+	return strings.ToLower(parsed.Scheme + "://" + parsed.Host), true
+}
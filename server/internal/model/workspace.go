@@ -0,0 +1,59 @@
+package model
+
+import "time"
+
+// Роли участников рабочего пространства: admin управляет составом и настройками пространства
+const (
+	WorkspaceMember = "member"
+	WorkspaceAdmin  = "admin"
+)
+
+// Тарифы рабочих пространств. Тариф меняет администратор сервиса
+const (
+	WorkspacePlanFree     = "free"
+	WorkspacePlanTeam     = "team"
+	WorkspacePlanBusiness = "business"
+)
+
+// WorkspaceLimits ограничения тарифа рабочего пространства
+type WorkspaceLimits struct {
+	MaxBookmarks int64 `json:"max_bookmarks"`
+	MaxMembers   int   `json:"max_members"`
+}
+
+var workspacePlanLimits = map[string]WorkspaceLimits{
+	WorkspacePlanFree:     {MaxMembers: 5, MaxBookmarks: 1000},
+	WorkspacePlanTeam:     {MaxMembers: 50, MaxBookmarks: 50000},
+	WorkspacePlanBusiness: {MaxMembers: 500, MaxBookmarks: 1000000},
+}
+
+// IsValidWorkspacePlan проверяет, что тариф известен
+func IsValidWorkspacePlan(plan string) bool {
+	_, ok := workspacePlanLimits[plan]
+	return ok
+}
+
+// Workspace рабочее пространство команды: общие закладки, папки и теги её участников
+type Workspace struct {
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Name      string    `json:"name" gorm:"size:255;not null"`
+	Plan      string    `json:"plan" gorm:"size:16;not null;default:free"`
+	ID        uint      `json:"id"`
+}
+
+// Limits возвращает ограничения тарифа пространства; неизвестный тариф считается бесплатным
+func (w *Workspace) Limits() WorkspaceLimits {
+	if limits, ok := workspacePlanLimits[w.Plan]; ok {
+		return limits
+	}
+	return workspacePlanLimits[WorkspacePlanFree]
+}
+
+// WorkspaceMembership участник рабочего пространства и его роль
+type WorkspaceMembership struct {
+	CreatedAt   time.Time `json:"created_at"`
+	Role        string    `json:"role" gorm:"size:16;not null"`
+	WorkspaceID uint      `json:"workspace_id" gorm:"primaryKey;autoIncrement:false"`
+	UserID      uint      `json:"user_id" gorm:"primaryKey;autoIncrement:false;index"`
+}
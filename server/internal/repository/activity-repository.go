@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"github.com/aerscs/theca-public/internal/model"
+	customerrors "github.com/aerscs/theca-public/internal/utils/errors"
+)
+
+// activityBatchSize размер пачки при записи активности импорта
+const activityBatchSize = 500
+
+func (r *repository) CreateActivities(activities []model.Activity) error {
+	const op = "repository.CreateActivities"
+	log := r.log.With("op", op)
+
+	if len(activities) == 0 {
+		return nil
+	}
+	err := r.db.CreateInBatches(activities, activityBatchSize).Error
+	if err != nil {
+		log.Error("failed to create activities", "error", err, "count", len(activities))
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
+
+// GetActivities returns the newest activity entries visible to the user: entries about their own bookmarks,
+// their own actions and entries of the given collections and workspaces
+func (r *repository) GetActivities(userID uint, collectionIDs, workspaceIDs []uint, filter *model.ActivityFilter, limit int) ([]model.Activity, error) {
+	const op = "repository.GetActivities"
+	log := r.log.With("op", op)
+
+	visible := r.db.Where("owner_id = ? OR actor_id = ?", userID, userID)
+	if len(collectionIDs) > 0 {
+		visible = visible.Or("collection_id IN ? OR from_collection_id IN ?", collectionIDs, collectionIDs)
+	}
+	if len(workspaceIDs) > 0 {
+		visible = visible.Or("workspace_id IN ?", workspaceIDs)
+	}
+
+	query := r.db.Where(visible)
+	if filter.CollectionID != 0 {
+		query = query.Where("collection_id = ? OR from_collection_id = ?", filter.CollectionID, filter.CollectionID)
+	}
+	if filter.WorkspaceID != 0 {
+		query = query.Where("workspace_id = ?", filter.WorkspaceID)
+	}
+	if filter.ActorID != 0 {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Before != 0 {
+		query = query.Where("id < ?", filter.Before)
+	}
+
+	var activities []model.Activity
+	err := query.Order("id DESC").Limit(limit).Find(&activities).Error
+	if err != nil {
+		log.Error("failed to get activities", "error", err, "user_id", userID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return activities, nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/aerscs/theca-public/internal/model"
+	customerrors "github.com/aerscs/theca-public/internal/utils/errors"
+	"gorm.io/gorm"
+)
+
+// SaveBookmarkArchive replaces the bookmark snapshot record and updates the owner's storage usage.
+// The replaced record is returned so that its blob can be removed by the caller
+func (r *repository) SaveBookmarkArchive(archive *model.BookmarkArchive) (*model.BookmarkArchive, error) {
+	const op = "repository.SaveBookmarkArchive"
+	log := r.log.With("op", op)
+
+	var replaced *model.BookmarkArchive
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Закладку могли удалить, пока снимок загружался
+		result := tx.Model(&model.Bookmark{}).Where("id = ?", archive.BookmarkID).
+			Update("snapshot_at", archive.CreatedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		var oldSize int64
+		var existing model.BookmarkArchive
+		err := tx.Where("bookmark_id = ?", archive.BookmarkID).First(&existing).Error
+		switch {
+		case err == nil:
+			oldSize = existing.Size
+			replaced = &existing
+			if err := tx.Delete(&existing).Error; err != nil {
+				return err
+			}
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+
+		if err := tx.Create(archive).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&model.User{}).Where("id = ?", archive.UserID).
+			Update("storage_used", gorm.Expr("storage_used + ?", archive.Size-oldSize)).Error
+	})
+	if err != nil {
+		log.Error("failed to save bookmark archive", "error", err, "bookmark_id", archive.BookmarkID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customerrors.New(customerrors.CodeNotFound, "Bookmark not found")
+		}
+		return nil, customerrors.FromGormError(err)
+	}
+
+	log.Debug("bookmark archive saved", "bookmark_id", archive.BookmarkID, "size", archive.Size)
+	return replaced, nil
+}
+
+func (r *repository) GetBookmarkArchive(bookmarkID uint) (*model.BookmarkArchive, error) {
+	const op = "repository.GetBookmarkArchive"
+	log := r.log.With("op", op)
+
+	var archive model.BookmarkArchive
+	err := r.db.Where("bookmark_id = ?", bookmarkID).First(&archive).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customerrors.New(customerrors.CodeNotFound, "Archive not found")
+		}
+		log.Error("failed to get bookmark archive", "error", err, "bookmark_id", bookmarkID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return &archive, nil
+}
+
+// DeleteBookmarkArchive removes the bookmark snapshot record and releases the owner's storage.
+// The removed record is returned so that its blob can be removed by the caller, nil if there was none
+func (r *repository) DeleteBookmarkArchive(bookmarkID uint) (*model.BookmarkArchive, error) {
+	const op = "repository.DeleteBookmarkArchive"
+	log := r.log.With("op", op)
+
+	var deleted *model.BookmarkArchive
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var existing model.BookmarkArchive
+		err := tx.Where("bookmark_id = ?", bookmarkID).First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&existing).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&model.Bookmark{}).Where("id = ?", bookmarkID).
+			Update("snapshot_at", nil).Error; err != nil {
+			return err
+		}
+
+		deleted = &existing
+		return tx.Model(&model.User{}).Where("id = ?", existing.UserID).
+			Update("storage_used", gorm.Expr("storage_used - ?", existing.Size)).Error
+	})
+	if err != nil {
+		log.Error("failed to delete bookmark archive", "error", err, "bookmark_id", bookmarkID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	log.Debug("bookmark archive deleted", "bookmark_id", bookmarkID)
+	return deleted, nil
+}
@@ -2,10 +2,14 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"log/slog"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/aerscs/theca-public/internal/model"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -22,6 +26,8 @@ type CacheRepository interface {
 	FaviconCacheRepository
 	// EmailVerificationCacheRepository defines interface for caching email verification code
 	EmailVerificationCacheRepository
+	// TopSitesCacheRepository defines interface for caching ranked top sites
+	TopSitesCacheRepository
 }
 
 type ResetTokenCacheRepository interface {
@@ -42,6 +48,12 @@ type FaviconCacheRepository interface {
 	StoreFaviconBase64(ctx context.Context, resourceURL, faviconBase64 string) error
 	// GetFaviconBase64 returns favicon as base64 encoded string for the specified resource
 	GetFaviconBase64(ctx context.Context, resourceURL string) (string, error)
+	// StoreFaviconSet saves icons of several resolutions for the specified resource with TTL
+	StoreFaviconSet(ctx context.Context, resourceURL string, icons []model.Icon) error
+	// GetFaviconSet returns icons of several resolutions for the specified resource
+	GetFaviconSet(ctx context.Context, resourceURL string) ([]model.Icon, error)
+	// InvalidateFavicons deletes all cached favicons of resources whose host matches
+	InvalidateFavicons(ctx context.Context, match func(host string) bool) (int, error)
 }
 
 type EmailVerificationCacheRepository interface {
@@ -59,6 +71,15 @@ type EmailVerificationCacheRepository interface {
 	IsVerificationRateLimited(ctx context.Context, userID uint) (bool, error)
 }
 
+type TopSitesCacheRepository interface {
+	// StoreTopSites saves the user's ranked top sites with TTL
+	StoreTopSites(ctx context.Context, userID uint, sites []model.TopSiteResponse, ttl time.Duration) error
+	// GetTopSites returns the user's ranked top sites, nil if not cached
+	GetTopSites(ctx context.Context, userID uint) ([]model.TopSiteResponse, error)
+	// InvalidateTopSites deletes the user's cached top sites
+	InvalidateTopSites(ctx context.Context, userID uint) error
+}
+
 type redisRepository struct {
 	client *redis.Client
 	log    *slog.Logger
@@ -281,6 +302,86 @@ func (r *redisRepository) GetFaviconBase64(ctx context.Context, resourceURL stri
 	return faviconBase64, nil
 }
 
+// StoreFaviconSet saves icon set as JSON with TTL
+func (r *redisRepository) StoreFaviconSet(ctx context.Context, resourceURL string, icons []model.Icon) error {
+	const op = "redisRepository.StoreFaviconSet"
+	log := r.log.With("op", op)
+
+	data, err := json.Marshal(icons)
+	if err != nil {
+		log.Error("failed to marshal favicon set", "error", err, "resource_url", resourceURL)
+		return err
+	}
+
+	err = r.client.Set(ctx, getFaviconSetKey(resourceURL), data, FaviconCacheTTL).Err()
+	if err != nil {
+		log.Error("failed to store favicon set", "error", err, "resource_url", resourceURL)
+		return err
+	}
+
+	log.Debug("favicon set stored", "resource_url", resourceURL, "count", len(icons))
+	return nil
+}
+
+// GetFaviconSet returns icon set
+func (r *redisRepository) GetFaviconSet(ctx context.Context, resourceURL string) ([]model.Icon, error) {
+	const op = "redisRepository.GetFaviconSet"
+	log := r.log.With("op", op)
+
+	data, err := r.client.Get(ctx, getFaviconSetKey(resourceURL)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			log.Debug("favicon set not found in cache", "resource_url", resourceURL)
+			return nil, nil
+		}
+		log.Error("failed to get favicon set", "error", err, "resource_url", resourceURL)
+		return nil, err
+	}
+
+	var icons []model.Icon
+	if err := json.Unmarshal(data, &icons); err != nil {
+		log.Error("failed to unmarshal favicon set", "error", err, "resource_url", resourceURL)
+		return nil, err
+	}
+
+	log.Debug("favicon set retrieved from cache", "resource_url", resourceURL)
+	return icons, nil
+}
+
+// InvalidateFavicons scans favicon keys and deletes those whose resource host matches
+func (r *redisRepository) InvalidateFavicons(ctx context.Context, match func(host string) bool) (int, error) {
+	const op = "redisRepository.InvalidateFavicons"
+	log := r.log.With("op", op)
+
+	deleted := 0
+	iter := r.client.Scan(ctx, 0, "favicon*", 500).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		_, resourceURL, found := strings.Cut(key, ":")
+		if !found {
+			continue
+		}
+
+		u, err := url.Parse(resourceURL)
+		if err != nil || !match(u.Hostname()) {
+			continue
+		}
+
+		if err := r.client.Del(ctx, key).Err(); err != nil {
+			log.Error("failed to delete favicon key", "error", err, "key", key)
+			return deleted, err
+		}
+		deleted++
+	}
+	if err := iter.Err(); err != nil {
+		log.Error("failed to scan favicon keys", "error", err)
+		return deleted, err
+	}
+
+	log.Debug("favicons invalidated", "deleted", deleted)
+	return deleted, nil
+}
+
 func (r *redisRepository) TrackVerificationAttempt(ctx context.Context, userID uint) error {
 	const op = "redisRepository.TrackVerificationAttempt"
 	log := r.log.With("op", op)
@@ -326,6 +427,61 @@ func (r *redisRepository) IsVerificationRateLimited(ctx context.Context, userID
 }
 
 // getResetTokenKey returns key for reset token
+// StoreTopSites saves ranked top sites as JSON with TTL
+func (r *redisRepository) StoreTopSites(ctx context.Context, userID uint, sites []model.TopSiteResponse, ttl time.Duration) error {
+	const op = "redisRepository.StoreTopSites"
+	log := r.log.With("op", op)
+
+	data, err := json.Marshal(sites)
+	if err != nil {
+		log.Error("failed to marshal top sites", "error", err, "user_id", userID)
+		return err
+	}
+
+	if err := r.client.Set(ctx, getTopSitesKey(userID), data, ttl).Err(); err != nil {
+		log.Error("failed to store top sites", "error", err, "user_id", userID)
+		return err
+	}
+
+	return nil
+}
+
+// GetTopSites returns ranked top sites
+func (r *redisRepository) GetTopSites(ctx context.Context, userID uint) ([]model.TopSiteResponse, error) {
+	const op = "redisRepository.GetTopSites"
+	log := r.log.With("op", op)
+
+	data, err := r.client.Get(ctx, getTopSitesKey(userID)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		log.Error("failed to get top sites", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	var sites []model.TopSiteResponse
+	if err := json.Unmarshal(data, &sites); err != nil {
+		log.Error("failed to unmarshal top sites", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	return sites, nil
+}
+
+// InvalidateTopSites deletes cached top sites
+func (r *redisRepository) InvalidateTopSites(ctx context.Context, userID uint) error {
+	const op = "redisRepository.InvalidateTopSites"
+	log := r.log.With("op", op)
+
+	if err := r.client.Del(ctx, getTopSitesKey(userID)).Err(); err != nil {
+		log.Error("failed to invalidate top sites", "error", err, "user_id", userID)
+		return err
+	}
+
+	return nil
+}
+
 func getResetTokenKey(token string) string {
 	return "password_reset:" + token
 }
@@ -350,7 +506,16 @@ func getFaviconBase64Key(resourceURL string) string {
 	return "favicon_base64:" + resourceURL
 }
 
+// getFaviconSetKey returns key for storing icons of several resolutions
+func getFaviconSetKey(resourceURL string) string {
+	return "favicon_set:" + resourceURL
+}
+
 // getVerificationAttemptsKey returns key for storing verification attempts
 func getVerificationAttemptsKey(userID uint) string {
 	return "verification_attempts:user:" + strconv.FormatUint(uint64(userID), 10)
 }
+
+func getTopSitesKey(userID uint) string {
+	return "top_sites:user:" + strconv.FormatUint(uint64(userID), 10)
+}
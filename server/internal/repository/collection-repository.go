@@ -0,0 +1,395 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/aerscs/theca-public/internal/model"
+	customerrors "github.com/aerscs/theca-public/internal/utils/errors"
+	"gorm.io/gorm"
+)
+
+// CreateCollection creates the collection and makes the given user its owner
+func (r *repository) CreateCollection(collection *model.Collection, ownerID uint) error {
+	const op = "repository.CreateCollection"
+	log := r.log.With("op", op)
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(collection).Error; err != nil {
+			return err
+		}
+		return tx.Create(&model.CollectionMember{
+			CollectionID: collection.ID,
+			UserID:       ownerID,
+			Role:         model.RoleOwner,
+			CreatedAt:    collection.CreatedAt,
+		}).Error
+	})
+	if err != nil {
+		log.Error("failed to create collection", "error", err, "user_id", ownerID)
+		return customerrors.FromGormError(err)
+	}
+
+	log.Debug("collection created", "collection_id", collection.ID, "user_id", ownerID)
+	return nil
+}
+
+func (r *repository) GetCollectionByID(collectionID uint) (*model.Collection, error) {
+	const op = "repository.GetCollectionByID"
+	log := r.log.With("op", op)
+
+	var collection model.Collection
+	err := r.db.First(&collection, collectionID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customerrors.New(customerrors.CodeNotFound, "Collection not found")
+		}
+		log.Error("failed to get collection", "error", err, "collection_id", collectionID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return &collection, nil
+}
+
+func (r *repository) GetCollectionsByIDs(collectionIDs []uint) ([]model.Collection, error) {
+	const op = "repository.GetCollectionsByIDs"
+	log := r.log.With("op", op)
+
+	var collections []model.Collection
+	if len(collectionIDs) == 0 {
+		return collections, nil
+	}
+	err := r.db.Where("id IN ?", collectionIDs).Order("name").Find(&collections).Error
+	if err != nil {
+		log.Error("failed to get collections", "error", err)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return collections, nil
+}
+
+// GetPublicCollectionsByOwner returns public collections the user owns
+func (r *repository) GetPublicCollectionsByOwner(userID uint) ([]model.Collection, error) {
+	const op = "repository.GetPublicCollectionsByOwner"
+	log := r.log.With("op", op)
+
+	var collections []model.Collection
+	err := r.db.Model(&model.Collection{}).
+		Joins("JOIN collection_members ON collection_members.collection_id = collections.id").
+		Where("collection_members.user_id = ? AND collection_members.role = ? AND collections.is_public = ?", userID, model.RoleOwner, true).
+		Order("collections.name").
+		Find(&collections).Error
+	if err != nil {
+		log.Error("failed to get public collections", "error", err, "user_id", userID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return collections, nil
+}
+
+func (r *repository) SaveCollection(collection *model.Collection) error {
+	const op = "repository.SaveCollection"
+	log := r.log.With("op", op)
+
+	err := r.db.Save(collection).Error
+	if err != nil {
+		log.Error("failed to save collection", "error", err, "collection_id", collection.ID)
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
+
+// DeleteCollection removes the collection with its members, invites and widgets.
+// Bookmarks stay with the users who added them
+func (r *repository) DeleteCollection(collectionID uint) error {
+	const op = "repository.DeleteCollection"
+	log := r.log.With("op", op)
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Bookmark{}).Where("collection_id = ?", collectionID).
+			UpdateColumn("collection_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("collection_id = ?", collectionID).Delete(&model.CollectionMember{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("collection_id = ?", collectionID).Delete(&model.CollectionInvite{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("collection_id = ?", collectionID).Delete(&model.Widget{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Collection{}, collectionID).Error
+	})
+	if err != nil {
+		log.Error("failed to delete collection", "error", err, "collection_id", collectionID)
+		return customerrors.FromGormError(err)
+	}
+
+	log.Debug("collection deleted", "collection_id", collectionID)
+	return nil
+}
+
+// GetUserMemberships returns all collection memberships of the user
+func (r *repository) GetUserMemberships(userID uint) ([]model.CollectionMember, error) {
+	const op = "repository.GetUserMemberships"
+	log := r.log.With("op", op)
+
+	var members []model.CollectionMember
+	err := r.db.Where("user_id = ?", userID).Find(&members).Error
+	if err != nil {
+		log.Error("failed to get memberships", "error", err, "user_id", userID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return members, nil
+}
+
+func (r *repository) GetCollectionMember(collectionID, userID uint) (*model.CollectionMember, error) {
+	const op = "repository.GetCollectionMember"
+	log := r.log.With("op", op)
+
+	var member model.CollectionMember
+	err := r.db.Where("collection_id = ? AND user_id = ?", collectionID, userID).First(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customerrors.New(customerrors.CodeNotFound, "Collection member not found")
+		}
+		log.Error("failed to get collection member", "error", err, "collection_id", collectionID, "user_id", userID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return &member, nil
+}
+
+func (r *repository) GetCollectionMembers(collectionID uint) ([]model.CollectionMember, error) {
+	const op = "repository.GetCollectionMembers"
+	log := r.log.With("op", op)
+
+	var members []model.CollectionMember
+	err := r.db.Where("collection_id = ?", collectionID).Order("created_at").Find(&members).Error
+	if err != nil {
+		log.Error("failed to get collection members", "error", err, "collection_id", collectionID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return members, nil
+}
+
+func (r *repository) SaveCollectionMember(member *model.CollectionMember) error {
+	const op = "repository.SaveCollectionMember"
+	log := r.log.With("op", op)
+
+	err := r.db.Save(member).Error
+	if err != nil {
+		log.Error("failed to save collection member", "error", err, "collection_id", member.CollectionID, "user_id", member.UserID)
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
+
+func (r *repository) DeleteCollectionMember(collectionID, userID uint) error {
+	const op = "repository.DeleteCollectionMember"
+	log := r.log.With("op", op)
+
+	err := r.db.Where("collection_id = ? AND user_id = ?", collectionID, userID).Delete(&model.CollectionMember{}).Error
+	if err != nil {
+		log.Error("failed to delete collection member", "error", err, "collection_id", collectionID, "user_id", userID)
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
+
+func (r *repository) CountCollectionOwners(collectionID uint) (int64, error) {
+	const op = "repository.CountCollectionOwners"
+	log := r.log.With("op", op)
+
+	var count int64
+	err := r.db.Model(&model.CollectionMember{}).
+		Where("collection_id = ? AND role = ?", collectionID, model.RoleOwner).
+		Count(&count).Error
+	if err != nil {
+		log.Error("failed to count collection owners", "error", err, "collection_id", collectionID)
+		return 0, customerrors.FromGormError(err)
+	}
+
+	return count, nil
+}
+
+func (r *repository) GetCollectionBookmarks(collectionID uint) ([]model.Bookmark, error) {
+	const op = "repository.GetCollectionBookmarks"
+	log := r.log.With("op", op)
+
+	var bookmarks []model.Bookmark
+	err := r.db.Where("collection_id = ?", collectionID).Order("created_at DESC").Find(&bookmarks).Error
+	if err != nil {
+		log.Error("failed to get collection bookmarks", "error", err, "collection_id", collectionID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return bookmarks, nil
+}
+
+// GetTemplateCollections returns collections published as templates, starter packs first
+func (r *repository) GetTemplateCollections() ([]model.Collection, error) {
+	const op = "repository.GetTemplateCollections"
+	log := r.log.With("op", op)
+
+	var collections []model.Collection
+	err := r.db.Where("is_template = ?", true).Order("starter_pack DESC, name").Find(&collections).Error
+	if err != nil {
+		log.Error("failed to get template collections", "error", err)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return collections, nil
+}
+
+// CountCollectionBookmarks returns the number of bookmarks in each of the collections;
+// collections without bookmarks are missing from the result
+func (r *repository) CountCollectionBookmarks(collectionIDs []uint) (map[uint]int64, error) {
+	const op = "repository.CountCollectionBookmarks"
+	log := r.log.With("op", op)
+
+	var rows []struct {
+		CollectionID uint
+		Count        int64
+	}
+	err := r.db.Model(&model.Bookmark{}).
+		Select("collection_id, COUNT(*) AS count").
+		Where("collection_id IN ?", collectionIDs).
+		Group("collection_id").
+		Scan(&rows).Error
+	if err != nil {
+		log.Error("failed to count collection bookmarks", "error", err)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	counts := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		counts[row.CollectionID] = row.Count
+	}
+	return counts, nil
+}
+
+// GetUsersByIDs returns users with the given IDs in no particular order
+func (r *repository) GetUsersByIDs(userIDs []uint) ([]model.User, error) {
+	const op = "repository.GetUsersByIDs"
+	log := r.log.With("op", op)
+
+	var users []model.User
+	if len(userIDs) == 0 {
+		return users, nil
+	}
+	err := r.db.Where("id IN ?", userIDs).Find(&users).Error
+	if err != nil {
+		log.Error("failed to get users", "error", err)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return users, nil
+}
+
+func (r *repository) CreateCollectionInvite(invite *model.CollectionInvite) error {
+	const op = "repository.CreateCollectionInvite"
+	log := r.log.With("op", op)
+
+	err := r.db.Create(invite).Error
+	if err != nil {
+		log.Error("failed to create collection invite", "error", err, "collection_id", invite.CollectionID)
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
+
+func (r *repository) GetCollectionInvites(collectionID uint) ([]model.CollectionInvite, error) {
+	const op = "repository.GetCollectionInvites"
+	log := r.log.With("op", op)
+
+	var invites []model.CollectionInvite
+	err := r.db.Where("collection_id = ?", collectionID).Order("created_at DESC").Find(&invites).Error
+	if err != nil {
+		log.Error("failed to get collection invites", "error", err, "collection_id", collectionID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return invites, nil
+}
+
+func (r *repository) GetCollectionInviteByID(inviteID uint) (*model.CollectionInvite, error) {
+	const op = "repository.GetCollectionInviteByID"
+	log := r.log.With("op", op)
+
+	var invite model.CollectionInvite
+	err := r.db.First(&invite, inviteID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customerrors.New(customerrors.CodeNotFound, "Invitation not found")
+		}
+		log.Error("failed to get collection invite", "error", err, "invite_id", inviteID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return &invite, nil
+}
+
+func (r *repository) GetCollectionInviteByToken(token string) (*model.CollectionInvite, error) {
+	const op = "repository.GetCollectionInviteByToken"
+	log := r.log.With("op", op)
+
+	var invite model.CollectionInvite
+	err := r.db.Where("token = ?", token).First(&invite).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customerrors.New(customerrors.CodeNotFound, "Invitation not found")
+		}
+		log.Error("failed to get collection invite by token", "error", err)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return &invite, nil
+}
+
+func (r *repository) DeleteCollectionInvite(inviteID uint) error {
+	const op = "repository.DeleteCollectionInvite"
+	log := r.log.With("op", op)
+
+	err := r.db.Delete(&model.CollectionInvite{}, inviteID).Error
+	if err != nil {
+		log.Error("failed to delete collection invite", "error", err, "invite_id", inviteID)
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
+
+// AcceptCollectionInvite removes the invite and saves the resulting membership in one transaction
+func (r *repository) AcceptCollectionInvite(invite *model.CollectionInvite, member *model.CollectionMember) error {
+	const op = "repository.AcceptCollectionInvite"
+	log := r.log.With("op", op)
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ?", invite.ID).Delete(&model.CollectionInvite{})
+		if result.Error != nil {
+			return result.Error
+		}
+		// Приглашение уже принято в параллельном запросе
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Save(member).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return customerrors.New(customerrors.CodeNotFound, "Invitation not found")
+		}
+		log.Error("failed to accept collection invite", "error", err, "invite_id", invite.ID)
+		return customerrors.FromGormError(err)
+	}
+
+	log.Debug("collection invite accepted", "collection_id", member.CollectionID, "user_id", member.UserID)
+	return nil
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/aerscs/theca-public/internal/model"
+	customerrors "github.com/aerscs/theca-public/internal/utils/errors"
+	"gorm.io/gorm"
+)
+
+func (r *repository) CreateComment(comment *model.Comment) error {
+	const op = "repository.CreateComment"
+	log := r.log.With("op", op)
+
+	err := r.db.Create(comment).Error
+	if err != nil {
+		log.Error("failed to create comment", "error", err, "bookmark_id", comment.BookmarkID)
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
+
+func (r *repository) GetCommentByID(commentID uint) (*model.Comment, error) {
+	const op = "repository.GetCommentByID"
+	log := r.log.With("op", op)
+
+	var comment model.Comment
+	err := r.db.First(&comment, commentID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customerrors.New(customerrors.CodeNotFound, "Comment not found")
+		}
+		log.Error("failed to get comment", "error", err, "comment_id", commentID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return &comment, nil
+}
+
+func (r *repository) SaveComment(comment *model.Comment) error {
+	const op = "repository.SaveComment"
+	log := r.log.With("op", op)
+
+	err := r.db.Save(comment).Error
+	if err != nil {
+		log.Error("failed to save comment", "error", err, "comment_id", comment.ID)
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
+
+func (r *repository) DeleteComment(commentID uint) error {
+	const op = "repository.DeleteComment"
+	log := r.log.With("op", op)
+
+	err := r.db.Delete(&model.Comment{}, commentID).Error
+	if err != nil {
+		log.Error("failed to delete comment", "error", err, "comment_id", commentID)
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
+
+func (r *repository) CountCommentReplies(commentID uint) (int64, error) {
+	const op = "repository.CountCommentReplies"
+	log := r.log.With("op", op)
+
+	var count int64
+	err := r.db.Model(&model.Comment{}).Where("parent_id = ?", commentID).Count(&count).Error
+	if err != nil {
+		log.Error("failed to count comment replies", "error", err, "comment_id", commentID)
+		return 0, customerrors.FromGormError(err)
+	}
+
+	return count, nil
+}
+
+// GetBookmarkComments returns all comments of the bookmark, oldest first
+func (r *repository) GetBookmarkComments(bookmarkID uint) ([]model.Comment, error) {
+	const op = "repository.GetBookmarkComments"
+	log := r.log.With("op", op)
+
+	var comments []model.Comment
+	err := r.db.Where("bookmark_id = ?", bookmarkID).Order("created_at, id").Find(&comments).Error
+	if err != nil {
+		log.Error("failed to get bookmark comments", "error", err, "bookmark_id", bookmarkID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return comments, nil
+}
+
+// GetCommentsByBookmarkIDs returns comments of all given bookmarks, oldest first
+func (r *repository) GetCommentsByBookmarkIDs(bookmarkIDs []uint) ([]model.Comment, error) {
+	const op = "repository.GetCommentsByBookmarkIDs"
+	log := r.log.With("op", op)
+
+	var comments []model.Comment
+	if len(bookmarkIDs) == 0 {
+		return comments, nil
+	}
+	err := r.db.Where("bookmark_id IN ?", bookmarkIDs).Order("created_at, id").Find(&comments).Error
+	if err != nil {
+		log.Error("failed to get comments", "error", err)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return comments, nil
+}
+
+func (r *repository) DeleteBookmarkComments(bookmarkID uint) error {
+	const op = "repository.DeleteBookmarkComments"
+	log := r.log.With("op", op)
+
+	err := r.db.Where("bookmark_id = ?", bookmarkID).Delete(&model.Comment{}).Error
+	if err != nil {
+		log.Error("failed to delete bookmark comments", "error", err, "bookmark_id", bookmarkID)
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/aerscs/theca-public/internal/model"
+	customerrors "github.com/aerscs/theca-public/internal/utils/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// likeEscaper экранирует спецсимволы шаблона LIKE
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// SaveBookmarkContent stores the extracted article text, replacing the previous one
+func (r *repository) SaveBookmarkContent(content *model.BookmarkContent) error {
+	const op = "repository.SaveBookmarkContent"
+	log := r.log.With("op", op)
+
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "bookmark_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"text", "updated_at"}),
+	}).Create(content).Error
+	if err != nil {
+		log.Error("failed to save bookmark content", "error", err, "bookmark_id", content.BookmarkID)
+		return customerrors.FromGormError(err)
+	}
+
+	log.Debug("bookmark content saved", "bookmark_id", content.BookmarkID, "length", len(content.Text))
+	return nil
+}
+
+func (r *repository) GetBookmarkContent(bookmarkID uint) (*model.BookmarkContent, error) {
+	const op = "repository.GetBookmarkContent"
+	log := r.log.With("op", op)
+
+	var content model.BookmarkContent
+	err := r.db.Where("bookmark_id = ?", bookmarkID).First(&content).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customerrors.New(customerrors.CodeNotFound, "Reader view is not available for this bookmark")
+		}
+		log.Error("failed to get bookmark content", "error", err, "bookmark_id", bookmarkID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return &content, nil
+}
+
+func (r *repository) DeleteBookmarkContent(bookmarkID uint) error {
+	const op = "repository.DeleteBookmarkContent"
+	log := r.log.With("op", op)
+
+	err := r.db.Where("bookmark_id = ?", bookmarkID).Delete(&model.BookmarkContent{}).Error
+	if err != nil {
+		log.Error("failed to delete bookmark content", "error", err, "bookmark_id", bookmarkID)
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
+
+// SearchBookmarks ищет личные закладки пользователя по заголовку, адресу, описанию и тексту статьи,
+// а с ненулевым workspaceID — закладки рабочего пространства.
+// В PostgreSQL текст статьи ищется полнотекстовым поиском, в SQLite — подстрокой
+func (r *repository) SearchBookmarks(userID, workspaceID uint, query string, limit int) ([]model.Bookmark, error) {
+	const op = "repository.SearchBookmarks"
+	log := r.log.With("op", op)
+
+	pattern := "%" + likeEscaper.Replace(strings.ToLower(query)) + "%"
+	condition := `LOWER(bookmarks.title) LIKE ? ESCAPE '\' OR LOWER(bookmarks.url) LIKE ? ESCAPE '\' OR LOWER(bookmarks.description) LIKE ? ESCAPE '\'`
+	args := []any{pattern, pattern, pattern}
+	if r.db.Dialector.Name() == "postgres" {
+		// Выражение совпадает с индексом idx_bookmark_contents_text_fts
+		condition += ` OR to_tsvector('simple', COALESCE(bookmark_contents.text, '')) @@ plainto_tsquery('simple', ?)`
+		args = append(args, query)
+	} else {
+		condition += ` OR LOWER(bookmark_contents.text) LIKE ? ESCAPE '\'`
+		args = append(args, pattern)
+	}
+
+	scope := r.db.Where("bookmarks.user_id = ? AND bookmarks.workspace_id IS NULL", userID)
+	if workspaceID != 0 {
+		scope = r.db.Where("bookmarks.workspace_id = ?", workspaceID)
+	}
+
+	var bookmarks []model.Bookmark
+	err := r.db.Model(&model.Bookmark{}).
+		Select("bookmarks.*").
+		Joins("LEFT JOIN bookmark_contents ON bookmark_contents.bookmark_id = bookmarks.id").
+		Where(scope).
+		Where(condition, args...).
+		Order("bookmarks.created_at DESC").
+		Limit(limit).
+		Find(&bookmarks).Error
+	if err != nil {
+		log.Error("failed to search bookmarks", "error", err, "user_id", userID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	log.Debug("bookmarks found", "user_id", userID, "count", len(bookmarks))
+	return bookmarks, nil
+}
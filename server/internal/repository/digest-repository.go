@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+	customerrors "github.com/aerscs/theca-public/internal/utils/errors"
+	"gorm.io/gorm"
+)
+
+// notSnoozedCondition excludes bookmarks hidden from the inbox until later
+const notSnoozedCondition = "snoozed_until IS NULL OR snoozed_until <= ?"
+
+// GetDigestSubscribers returns verified users who opted in to the weekly digest
+func (r *repository) GetDigestSubscribers() ([]model.User, error) {
+	const op = "repository.GetDigestSubscribers"
+	log := r.log.With("op", op)
+
+	var users []model.User
+	err := r.db.Where("digest_enabled = ? AND is_verified = ?", true, true).Find(&users).Error
+	if err != nil {
+		log.Error("failed to get digest subscribers", "error", err)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return users, nil
+}
+
+// ClaimDigest marks the digest as sent if it has not been sent since the scheduled time.
+// Returns false when another instance has already sent it
+func (r *repository) ClaimDigest(userID uint, scheduled, now time.Time) (bool, error) {
+	const op = "repository.ClaimDigest"
+	log := r.log.With("op", op)
+
+	result := r.db.Model(&model.User{}).
+		Where("id = ? AND (digest_sent_at IS NULL OR digest_sent_at < ?)", userID, scheduled).
+		UpdateColumn("digest_sent_at", now)
+	if result.Error != nil {
+		log.Error("failed to claim digest", "error", result.Error, "user_id", userID)
+		return false, customerrors.FromGormError(result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// GetBookmarksCreatedSince returns the user's newest personal bookmarks saved after the given time
+func (r *repository) GetBookmarksCreatedSince(userID uint, since time.Time, limit int) ([]model.Bookmark, error) {
+	const op = "repository.GetBookmarksCreatedSince"
+	log := r.log.With("op", op)
+
+	var bookmarks []model.Bookmark
+	err := r.db.Where("user_id = ? AND workspace_id IS NULL AND created_at >= ?", userID, since).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&bookmarks).Error
+	if err != nil {
+		log.Error("failed to get new bookmarks", "error", err, "user_id", userID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return bookmarks, nil
+}
+
+// GetResurfacedBookmarks returns unread personal bookmarks that returned to the inbox by a reminder or snooze after the given time
+func (r *repository) GetResurfacedBookmarks(userID uint, since time.Time, limit int) ([]model.Bookmark, error) {
+	const op = "repository.GetResurfacedBookmarks"
+	log := r.log.With("op", op)
+
+	var bookmarks []model.Bookmark
+	err := r.db.Where("user_id = ? AND workspace_id IS NULL AND state = ? AND resurfaced_at >= ?", userID, model.StateUnread, since).
+		Where(notSnoozedCondition, time.Now()).
+		Order("resurfaced_at DESC").
+		Limit(limit).
+		Find(&bookmarks).Error
+	if err != nil {
+		log.Error("failed to get resurfaced bookmarks", "error", err, "user_id", userID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return bookmarks, nil
+}
+
+// GetOldestUnreadBookmarks returns the user's longest waiting unread personal bookmarks saved before the given time
+// and the total number of unread bookmarks in the inbox
+func (r *repository) GetOldestUnreadBookmarks(userID uint, createdBefore time.Time, limit int) ([]model.Bookmark, int64, error) {
+	const op = "repository.GetOldestUnreadBookmarks"
+	log := r.log.With("op", op)
+
+	inbox := r.db.Model(&model.Bookmark{}).
+		Where("user_id = ? AND workspace_id IS NULL AND state = ?", userID, model.StateUnread).
+		Where(notSnoozedCondition, time.Now()).
+		Session(&gorm.Session{})
+
+	var total int64
+	if err := inbox.Count(&total).Error; err != nil {
+		log.Error("failed to count unread bookmarks", "error", err, "user_id", userID)
+		return nil, 0, customerrors.FromGormError(err)
+	}
+
+	var bookmarks []model.Bookmark
+	err := inbox.Where("created_at < ?", createdBefore).
+		Order("created_at").
+		Limit(limit).
+		Find(&bookmarks).Error
+	if err != nil {
+		log.Error("failed to get unread bookmarks", "error", err, "user_id", userID)
+		return nil, 0, customerrors.FromGormError(err)
+	}
+
+	return bookmarks, total, nil
+}
+
+func (r *repository) GetUserByUnsubscribeToken(token string) (*model.User, error) {
+	const op = "repository.GetUserByUnsubscribeToken"
+	log := r.log.With("op", op)
+
+	var user model.User
+	err := r.db.Where("unsubscribe_token = ?", token).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customerrors.New(customerrors.CodeNotFound, "Subscription not found")
+		}
+		log.Error("failed to get user by unsubscribe token", "error", err)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return &user, nil
+}
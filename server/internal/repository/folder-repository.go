@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/aerscs/theca-public/internal/model"
+	customerrors "github.com/aerscs/theca-public/internal/utils/errors"
+	"gorm.io/gorm"
+)
+
+// GetFolders returns the user's personal folders; workspace folders are not included
+func (r *repository) GetFolders(userID uint) ([]model.Folder, error) {
+	const op = "repository.GetFolders"
+	log := r.log.With("op", op)
+
+	var folders []model.Folder
+	err := r.db.Where("user_id = ? AND workspace_id IS NULL", userID).Order("name").Find(&folders).Error
+	if err != nil {
+		log.Error("failed to get folders", "error", err, "user_id", userID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return folders, nil
+}
+
+func (r *repository) GetFolderByID(folderID uint) (*model.Folder, error) {
+	const op = "repository.GetFolderByID"
+	log := r.log.With("op", op)
+
+	var folder model.Folder
+	err := r.db.First(&folder, folderID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customerrors.New(customerrors.CodeNotFound, "Folder not found")
+		}
+		log.Error("failed to get folder", "error", err, "folder_id", folderID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return &folder, nil
+}
+
+func (r *repository) SaveFolder(folder *model.Folder) error {
+	const op = "repository.SaveFolder"
+	log := r.log.With("op", op)
+
+	err := r.db.Save(folder).Error
+	if err != nil {
+		log.Error("failed to save folder", "error", err, "user_id", folder.UserID)
+		return customerrors.FromGormError(err)
+	}
+
+	log.Debug("folder saved", "folder_id", folder.ID, "user_id", folder.UserID)
+	return nil
+}
+
+// DeleteFolder removes the folder and moves its bookmarks and subfolders to the parent folder.
+// Share links to the folder are revoked
+func (r *repository) DeleteFolder(folder *model.Folder) error {
+	const op = "repository.DeleteFolder"
+	log := r.log.With("op", op)
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Bookmark{}).Where("folder_id = ?", folder.ID).
+			UpdateColumn("folder_id", folder.ParentID).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.Folder{}).Where("parent_id = ?", folder.ID).
+			UpdateColumn("parent_id", folder.ParentID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("folder_id = ?", folder.ID).Delete(&model.ShareLink{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Folder{}, folder.ID).Error
+	})
+	if err != nil {
+		log.Error("failed to delete folder", "error", err, "folder_id", folder.ID)
+		return customerrors.FromGormError(err)
+	}
+
+	log.Debug("folder deleted", "folder_id", folder.ID, "user_id", folder.UserID)
+	return nil
+}
+
+// GetBookmarksInFolders returns the user's bookmarks stored in any of the given folders
+func (r *repository) GetBookmarksInFolders(userID uint, folderIDs []uint) ([]model.Bookmark, error) {
+	const op = "repository.GetBookmarksInFolders"
+	log := r.log.With("op", op)
+
+	var bookmarks []model.Bookmark
+	err := r.db.Where("user_id = ? AND folder_id IN ?", userID, folderIDs).
+		Order("created_at DESC").
+		Find(&bookmarks).Error
+	if err != nil {
+		log.Error("failed to get bookmarks in folders", "error", err, "user_id", userID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return bookmarks, nil
+}
+
+// GetBookmarksByIDs returns the user's personal bookmarks with the given IDs; bookmarks of other users
+// and of workspaces are skipped
+func (r *repository) GetBookmarksByIDs(userID uint, bookmarkIDs []uint) ([]model.Bookmark, error) {
+	const op = "repository.GetBookmarksByIDs"
+	log := r.log.With("op", op)
+
+	var bookmarks []model.Bookmark
+	err := r.db.Where("user_id = ? AND workspace_id IS NULL AND id IN ?", userID, bookmarkIDs).
+		Order("created_at DESC").
+		Find(&bookmarks).Error
+	if err != nil {
+		log.Error("failed to get bookmarks by IDs", "error", err, "user_id", userID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return bookmarks, nil
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+	customerrors "github.com/aerscs/theca-public/internal/utils/errors"
+)
+
+// GetBookmarksDueForCheck returns bookmarks that were never checked or were checked before the given time.
+// Never checked bookmarks go first
+func (r *repository) GetBookmarksDueForCheck(checkedBefore time.Time, limit int) ([]model.Bookmark, error) {
+	const op = "repository.GetBookmarksDueForCheck"
+	log := r.log.With("op", op)
+
+	var bookmarks []model.Bookmark
+	err := r.db.Select("id", "user_id", "url", "final_url", "suggested_url", "health_status", "http_status", "check_failures", "last_checked_at").
+		Where("last_checked_at IS NULL OR last_checked_at < ?", checkedBefore).
+		Order("last_checked_at IS NOT NULL, last_checked_at").
+		Limit(limit).
+		Find(&bookmarks).Error
+	if err != nil {
+		log.Error("failed to get bookmarks due for check", "error", err)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return bookmarks, nil
+}
+
+// UpdateBookmarkHealth saves only the link check results, without touching updated_at
+func (r *repository) UpdateBookmarkHealth(bookmark *model.Bookmark) error {
+	const op = "repository.UpdateBookmarkHealth"
+	log := r.log.With("op", op)
+
+	err := r.db.Model(&model.Bookmark{}).Where("id = ?", bookmark.ID).UpdateColumns(map[string]any{
+		"last_checked_at": bookmark.LastCheckedAt,
+		"final_url":       bookmark.FinalURL,
+		"suggested_url":   bookmark.SuggestedURL,
+		"health_status":   bookmark.HealthStatus,
+		"http_status":     bookmark.HTTPStatus,
+		"check_failures":  bookmark.CheckFailures,
+	}).Error
+	if err != nil {
+		log.Error("failed to update bookmark health", "error", err, "bookmark_id", bookmark.ID)
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
+
+// PostponeBookmarkCheck moves the bookmark in the check queue by setting last_checked_at
+// without touching the check results
+func (r *repository) PostponeBookmarkCheck(bookmarkID uint, checkedAt time.Time) error {
+	const op = "repository.PostponeBookmarkCheck"
+	log := r.log.With("op", op)
+
+	err := r.db.Model(&model.Bookmark{}).Where("id = ?", bookmarkID).UpdateColumn("last_checked_at", checkedAt).Error
+	if err != nil {
+		log.Error("failed to postpone bookmark check", "error", err, "bookmark_id", bookmarkID)
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
+
+// GetBookmarksHealth returns checked personal bookmarks of the user with the given statuses.
+// A non-zero workspaceID returns bookmarks of that workspace instead
+func (r *repository) GetBookmarksHealth(userID, workspaceID uint, statuses []string, minFailures int) ([]model.Bookmark, error) {
+	const op = "repository.GetBookmarksHealth"
+	log := r.log.With("op", op)
+
+	query := r.db.Where("user_id = ? AND workspace_id IS NULL", userID)
+	if workspaceID != 0 {
+		query = r.db.Where("workspace_id = ?", workspaceID)
+	}
+	query = query.Where("health_status <> ?", model.HealthStatusUnchecked)
+	if len(statuses) > 0 {
+		query = query.Where("health_status IN ?", statuses)
+	}
+	if minFailures > 0 {
+		query = query.Where("check_failures >= ?", minFailures)
+	}
+
+	var bookmarks []model.Bookmark
+	err := query.Order("check_failures DESC, last_checked_at DESC").Find(&bookmarks).Error
+	if err != nil {
+		log.Error("failed to get bookmarks health", "error", err, "user_id", userID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	log.Debug("bookmarks health retrieved", "user_id", userID, "count", len(bookmarks))
+	return bookmarks, nil
+}
+
+// ScheduleBookmarksCheck marks all personal bookmarks of the user as due for check
+func (r *repository) ScheduleBookmarksCheck(userID uint) (int64, error) {
+	const op = "repository.ScheduleBookmarksCheck"
+	log := r.log.With("op", op)
+
+	result := r.db.Model(&model.Bookmark{}).Where("user_id = ? AND workspace_id IS NULL", userID).
+		UpdateColumn("last_checked_at", nil)
+	if result.Error != nil {
+		log.Error("failed to schedule bookmarks check", "error", result.Error, "user_id", userID)
+		return 0, customerrors.FromGormError(result.Error)
+	}
+
+	log.Debug("bookmarks check scheduled", "user_id", userID, "count", result.RowsAffected)
+	return result.RowsAffected, nil
+}
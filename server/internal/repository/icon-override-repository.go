@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+	customerrors "github.com/aerscs/theca-public/internal/utils/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *repository) GetIconOverrides() ([]model.IconOverride, error) {
+	const op = "repository.GetIconOverrides"
+	log := r.log.With("op", op)
+
+	var overrides []model.IconOverride
+	err := r.db.Order("pattern").Find(&overrides).Error
+	if err != nil {
+		log.Error("failed to get icon overrides", "error", err)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return overrides, nil
+}
+
+func (r *repository) GetIconOverrideByID(id uint) (*model.IconOverride, error) {
+	const op = "repository.GetIconOverrideByID"
+	log := r.log.With("op", op)
+
+	var override model.IconOverride
+	err := r.db.First(&override, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customerrors.New(customerrors.CodeNotFound, "Icon override not found")
+		}
+		log.Error("failed to get icon override", "error", err, "id", id)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return &override, nil
+}
+
+func (r *repository) SaveIconOverride(override *model.IconOverride) error {
+	const op = "repository.SaveIconOverride"
+	log := r.log.With("op", op)
+
+	err := r.db.Save(override).Error
+	if err != nil {
+		log.Error("failed to save icon override", "error", err, "pattern", override.Pattern)
+		return customerrors.FromGormError(err)
+	}
+
+	log.Debug("icon override saved", "id", override.ID, "pattern", override.Pattern)
+	return nil
+}
+
+func (r *repository) DeleteIconOverride(id uint) error {
+	const op = "repository.DeleteIconOverride"
+	log := r.log.With("op", op)
+
+	err := r.db.Delete(&model.IconOverride{}, id).Error
+	if err != nil {
+		log.Error("failed to delete icon override", "error", err, "id", id)
+		return customerrors.FromGormError(err)
+	}
+
+	log.Debug("icon override deleted", "id", id)
+	return nil
+}
+
+// SeedIconOverrides fills the overrides table once per database. The marker is written in the same transaction,
+// so overrides deleted by an admin are not seeded again; a table that already has rows is only marked
+func (r *repository) SeedIconOverrides(overrides []model.IconOverride) error {
+	const op = "repository.SeedIconOverrides"
+	log := r.log.With("op", op)
+
+	seeded := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		marker := &model.AppliedSeed{Name: model.SeedIconOverrides, CreatedAt: time.Now()}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(marker)
+		if result.Error != nil {
+			return result.Error
+		}
+		// Заполнение уже выполнено, в том числе другим инстансом
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		var count int64
+		if err := tx.Model(&model.IconOverride{}).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 || len(overrides) == 0 {
+			return nil
+		}
+		seeded = len(overrides)
+		return tx.Create(&overrides).Error
+	})
+	if err != nil {
+		log.Error("failed to seed icon overrides", "error", err)
+		return customerrors.FromGormError(err)
+	}
+
+	if seeded > 0 {
+		log.Info("icon overrides seeded", "count", seeded)
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+	customerrors "github.com/aerscs/theca-public/internal/utils/errors"
+	"gorm.io/gorm"
+)
+
+func (r *repository) CreateInboxItem(item *model.InboxItem) error {
+	const op = "repository.CreateInboxItem"
+	log := r.log.With("op", op)
+
+	err := r.db.Create(item).Error
+	if err != nil {
+		log.Error("failed to create inbox item", "error", err, "sender_id", item.SenderID, "recipient_id", item.RecipientID)
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
+
+func (r *repository) GetInboxItemByID(itemID uint) (*model.InboxItem, error) {
+	const op = "repository.GetInboxItemByID"
+	log := r.log.With("op", op)
+
+	var item model.InboxItem
+	err := r.db.First(&item, itemID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customerrors.New(customerrors.CodeNotFound, "Inbox item not found")
+		}
+		log.Error("failed to get inbox item", "error", err, "item_id", itemID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return &item, nil
+}
+
+// GetPendingInboxItems returns the recipient's unanswered inbox items, newest first
+func (r *repository) GetPendingInboxItems(recipientID uint) ([]model.InboxItem, error) {
+	const op = "repository.GetPendingInboxItems"
+	log := r.log.With("op", op)
+
+	var items []model.InboxItem
+	err := r.db.Where("recipient_id = ? AND status = ?", recipientID, model.InboxPending).
+		Order("created_at DESC").Find(&items).Error
+	if err != nil {
+		log.Error("failed to get inbox items", "error", err, "recipient_id", recipientID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return items, nil
+}
+
+// CountPendingInboxItems counts unanswered items the sender has in the recipient's inbox;
+// a non-empty url counts only items with that URL
+func (r *repository) CountPendingInboxItems(senderID, recipientID uint, url string) (int64, error) {
+	const op = "repository.CountPendingInboxItems"
+	log := r.log.With("op", op)
+
+	query := r.db.Model(&model.InboxItem{}).
+		Where("sender_id = ? AND recipient_id = ? AND status = ?", senderID, recipientID, model.InboxPending)
+	if url != "" {
+		query = query.Where("url = ?", url)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		log.Error("failed to count inbox items", "error", err, "sender_id", senderID, "recipient_id", recipientID)
+		return 0, customerrors.FromGormError(err)
+	}
+
+	return count, nil
+}
+
+// AcceptInboxItem marks a pending item as accepted and creates the recipient's bookmark in one transaction
+func (r *repository) AcceptInboxItem(item *model.InboxItem, bookmark *model.Bookmark, now time.Time) error {
+	const op = "repository.AcceptInboxItem"
+	log := r.log.With("op", op)
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(bookmark).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&model.InboxItem{}).
+			Where("id = ? AND status = ?", item.ID, model.InboxPending).
+			Updates(map[string]any{"status": model.InboxAccepted, "responded_at": now, "bookmark_id": bookmark.ID})
+		if result.Error != nil {
+			return result.Error
+		}
+		// Закладка уже принята или отклонена в параллельном запросе
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return customerrors.New(customerrors.CodeNotFound, "Inbox item not found")
+		}
+		log.Error("failed to accept inbox item", "error", err, "item_id", item.ID)
+		return customerrors.FromGormError(err)
+	}
+
+	item.Status = model.InboxAccepted
+	item.RespondedAt = &now
+	item.BookmarkID = &bookmark.ID
+	return nil
+}
+
+// DismissInboxItem marks a pending item as dismissed
+func (r *repository) DismissInboxItem(itemID uint, now time.Time) error {
+	const op = "repository.DismissInboxItem"
+	log := r.log.With("op", op)
+
+	result := r.db.Model(&model.InboxItem{}).
+		Where("id = ? AND status = ?", itemID, model.InboxPending).
+		Updates(map[string]any{"status": model.InboxDismissed, "responded_at": now})
+	if result.Error != nil {
+		log.Error("failed to dismiss inbox item", "error", result.Error, "item_id", itemID)
+		return customerrors.FromGormError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return customerrors.New(customerrors.CodeNotFound, "Inbox item not found")
+	}
+
+	return nil
+}
+
+// DismissInboxItemsFrom dismisses every pending item the sender has in the recipient's inbox
+func (r *repository) DismissInboxItemsFrom(recipientID, senderID uint, now time.Time) error {
+	const op = "repository.DismissInboxItemsFrom"
+	log := r.log.With("op", op)
+
+	err := r.db.Model(&model.InboxItem{}).
+		Where("recipient_id = ? AND sender_id = ? AND status = ?", recipientID, senderID, model.InboxPending).
+		Updates(map[string]any{"status": model.InboxDismissed, "responded_at": now}).Error
+	if err != nil {
+		log.Error("failed to dismiss inbox items", "error", err, "recipient_id", recipientID, "sender_id", senderID)
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
+
+// IsUserBlocked reports whether the user has blocked bookmarks from blockedUserID
+func (r *repository) IsUserBlocked(userID, blockedUserID uint) (bool, error) {
+	const op = "repository.IsUserBlocked"
+	log := r.log.With("op", op)
+
+	var count int64
+	err := r.db.Model(&model.UserBlock{}).
+		Where("user_id = ? AND blocked_user_id = ?", userID, blockedUserID).Count(&count).Error
+	if err != nil {
+		log.Error("failed to check user block", "error", err, "user_id", userID, "blocked_user_id", blockedUserID)
+		return false, customerrors.FromGormError(err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *repository) GetUserBlocks(userID uint) ([]model.UserBlock, error) {
+	const op = "repository.GetUserBlocks"
+	log := r.log.With("op", op)
+
+	var blocks []model.UserBlock
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&blocks).Error
+	if err != nil {
+		log.Error("failed to get user blocks", "error", err, "user_id", userID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return blocks, nil
+}
+
+func (r *repository) SaveUserBlock(block *model.UserBlock) error {
+	const op = "repository.SaveUserBlock"
+	log := r.log.With("op", op)
+
+	err := r.db.Save(block).Error
+	if err != nil {
+		log.Error("failed to save user block", "error", err, "user_id", block.UserID, "blocked_user_id", block.BlockedUserID)
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
+
+func (r *repository) DeleteUserBlock(userID, blockedUserID uint) error {
+	const op = "repository.DeleteUserBlock"
+	log := r.log.With("op", op)
+
+	result := r.db.Where("user_id = ? AND blocked_user_id = ?", userID, blockedUserID).Delete(&model.UserBlock{})
+	if result.Error != nil {
+		log.Error("failed to delete user block", "error", result.Error, "user_id", userID, "blocked_user_id", blockedUserID)
+		return customerrors.FromGormError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return customerrors.New(customerrors.CodeNotFound, "User is not blocked")
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"github.com/aerscs/theca-public/internal/model"
+	customerrors "github.com/aerscs/theca-public/internal/utils/errors"
+)
+
+// GetUnclassifiedBookmarks returns bookmarks saved before content kinds were introduced
+func (r *repository) GetUnclassifiedBookmarks(limit int) ([]model.Bookmark, error) {
+	const op = "repository.GetUnclassifiedBookmarks"
+	log := r.log.With("op", op)
+
+	var bookmarks []model.Bookmark
+	err := r.db.Where("kind IS NULL OR kind = ''").
+		Order("id").
+		Limit(limit).
+		Find(&bookmarks).Error
+	if err != nil {
+		log.Error("failed to get unclassified bookmarks", "error", err)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return bookmarks, nil
+}
+
+// UpdateBookmarkKind saves only the content kind of the bookmark
+func (r *repository) UpdateBookmarkKind(bookmarkID uint, kind string) error {
+	const op = "repository.UpdateBookmarkKind"
+	log := r.log.With("op", op)
+
+	err := r.db.Model(&model.Bookmark{}).Where("id = ?", bookmarkID).UpdateColumn("kind", kind).Error
+	if err != nil {
+		log.Error("failed to update bookmark kind", "error", err, "bookmark_id", bookmarkID)
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
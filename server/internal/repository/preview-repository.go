@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/aerscs/theca-public/internal/model"
+	customerrors "github.com/aerscs/theca-public/internal/utils/errors"
+	"gorm.io/gorm"
+)
+
+// SaveBookmarkPreview replaces the bookmark preview and updates the owner's storage usage
+func (r *repository) SaveBookmarkPreview(preview *model.BookmarkPreview) error {
+	const op = "repository.SaveBookmarkPreview"
+	log := r.log.With("op", op)
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var oldSize int64
+		var existing model.BookmarkPreview
+		err := tx.Where("bookmark_id = ?", preview.BookmarkID).First(&existing).Error
+		switch {
+		case err == nil:
+			oldSize = existing.Size
+			if err := tx.Delete(&existing).Error; err != nil {
+				return err
+			}
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+
+		if err := tx.Create(preview).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&model.Bookmark{}).Where("id = ?", preview.BookmarkID).
+			Update("preview_token", preview.Token).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&model.User{}).Where("id = ?", preview.UserID).
+			Update("storage_used", gorm.Expr("storage_used + ?", preview.Size-oldSize)).Error
+	})
+	if err != nil {
+		log.Error("failed to save bookmark preview", "error", err, "bookmark_id", preview.BookmarkID)
+		return customerrors.FromGormError(err)
+	}
+
+	log.Debug("bookmark preview saved", "bookmark_id", preview.BookmarkID, "size", preview.Size)
+	return nil
+}
+
+func (r *repository) GetBookmarkPreviewByToken(token string) (*model.BookmarkPreview, error) {
+	const op = "repository.GetBookmarkPreviewByToken"
+	log := r.log.With("op", op)
+
+	var preview model.BookmarkPreview
+	err := r.db.Where("token = ?", token).First(&preview).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customerrors.New(customerrors.CodeNotFound, "Preview not found")
+		}
+		log.Error("failed to get bookmark preview", "error", err)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return &preview, nil
+}
+
+// DeleteBookmarkPreview removes the bookmark preview and releases the owner's storage
+func (r *repository) DeleteBookmarkPreview(bookmarkID uint) error {
+	const op = "repository.DeleteBookmarkPreview"
+	log := r.log.With("op", op)
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var existing model.BookmarkPreview
+		err := tx.Where("bookmark_id = ?", bookmarkID).First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&existing).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&model.Bookmark{}).Where("id = ?", bookmarkID).
+			Update("preview_token", "").Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&model.User{}).Where("id = ?", existing.UserID).
+			Update("storage_used", gorm.Expr("storage_used - ?", existing.Size)).Error
+	})
+	if err != nil {
+		log.Error("failed to delete bookmark preview", "error", err, "bookmark_id", bookmarkID)
+		return customerrors.FromGormError(err)
+	}
+
+	log.Debug("bookmark preview deleted", "bookmark_id", bookmarkID)
+	return nil
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+	customerrors "github.com/aerscs/theca-public/internal/utils/errors"
+	"gorm.io/gorm"
+)
+
+// dueReminderCondition matches bookmarks whose reminder or snooze has expired
+const dueReminderCondition = "(remind_at IS NOT NULL AND remind_at <= ?) OR (snoozed_until IS NOT NULL AND snoozed_until <= ?)"
+
+// GetDueReminders returns bookmarks whose reminder or snooze time has come, oldest first
+func (r *repository) GetDueReminders(now time.Time, limit int) ([]model.Bookmark, error) {
+	const op = "repository.GetDueReminders"
+	log := r.log.With("op", op)
+
+	var bookmarks []model.Bookmark
+	err := r.db.Where(dueReminderCondition, now, now).
+		Order("COALESCE(remind_at, snoozed_until)").
+		Limit(limit).
+		Find(&bookmarks).Error
+	if err != nil {
+		log.Error("failed to get due reminders", "error", err)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return bookmarks, nil
+}
+
+// ClaimDueReminder saves the resurfaced bookmark only if its reminder is still due.
+// Returns false when another instance has already handled it, so that the email is sent once
+func (r *repository) ClaimDueReminder(bookmark *model.Bookmark, now time.Time) (bool, error) {
+	const op = "repository.ClaimDueReminder"
+	log := r.log.With("op", op)
+
+	result := r.db.Model(&model.Bookmark{}).
+		Where("id = ?", bookmark.ID).
+		Where(dueReminderCondition, now, now).
+		UpdateColumns(map[string]any{
+			"state":         bookmark.State,
+			"read_at":       bookmark.ReadAt,
+			"archived_at":   bookmark.ArchivedAt,
+			"remind_at":     bookmark.RemindAt,
+			"snoozed_until": bookmark.SnoozedUntil,
+			"resurfaced_at": bookmark.ResurfacedAt,
+		})
+	if result.Error != nil {
+		log.Error("failed to claim due reminder", "error", result.Error, "bookmark_id", bookmark.ID)
+		return false, customerrors.FromGormError(result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// GetUpcomingReminders returns the user's bookmarks with a reminder or snooze after the given time
+func (r *repository) GetUpcomingReminders(userID uint, after time.Time) ([]model.Bookmark, error) {
+	const op = "repository.GetUpcomingReminders"
+	log := r.log.With("op", op)
+
+	var bookmarks []model.Bookmark
+	err := r.db.Where("user_id = ?", userID).
+		Where("remind_at > ? OR snoozed_until > ?", after, after).
+		Order("COALESCE(remind_at, snoozed_until)").
+		Find(&bookmarks).Error
+	if err != nil {
+		log.Error("failed to get upcoming reminders", "error", err, "user_id", userID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return bookmarks, nil
+}
+
+func (r *repository) GetUserByFeedToken(token string) (*model.User, error) {
+	const op = "repository.GetUserByFeedToken"
+	log := r.log.With("op", op)
+
+	var user model.User
+	err := r.db.Where("feed_token = ?", token).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customerrors.New(customerrors.CodeNotFound, "Feed not found")
+		}
+		log.Error("failed to get user by feed token", "error", err)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return &user, nil
+}
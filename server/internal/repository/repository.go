@@ -3,6 +3,7 @@ package repository
 import (
 	"errors"
 	"log/slog"
+	"time"
 
 	"github.com/aerscs/theca-public/internal/model"
 	customerrors "github.com/aerscs/theca-public/internal/utils/errors"
@@ -23,6 +24,161 @@ type Repository interface {
 	GetBookmarkByID(bookmarkID uint) (*model.Bookmark, error)
 	UpdateBookmark(bookmark *model.Bookmark) error
 	DeleteBookmark(bookmarkID uint) error
+
+	// Методы для списка «прочитать позже»
+	GetFilteredBookmarks(userID uint, filter *model.BookmarkListFilter) ([]model.Bookmark, error)
+	UpdateBookmarkState(bookmark *model.Bookmark) error
+
+	// Методы для работы с папками
+	GetFolders(userID uint) ([]model.Folder, error)
+	GetFolderByID(folderID uint) (*model.Folder, error)
+	SaveFolder(folder *model.Folder) error
+	DeleteFolder(folder *model.Folder) error
+	GetBookmarksInFolders(userID uint, folderIDs []uint) ([]model.Bookmark, error)
+	GetBookmarksByIDs(userID uint, bookmarkIDs []uint) ([]model.Bookmark, error)
+
+	// Методы для общих ссылок
+	CreateShareLink(link *model.ShareLink) error
+	GetShareLinks(userID uint) ([]model.ShareLink, error)
+	GetShareLinkByID(shareID uint) (*model.ShareLink, error)
+	GetShareLinkByToken(token string) (*model.ShareLink, error)
+	DeleteShareLink(shareID uint) error
+	RecordShareView(shareID uint, viewedAt time.Time) error
+
+	// Методы для общих коллекций
+	CreateCollection(collection *model.Collection, ownerID uint) error
+	GetCollectionByID(collectionID uint) (*model.Collection, error)
+	GetCollectionsByIDs(collectionIDs []uint) ([]model.Collection, error)
+	GetPublicCollectionsByOwner(userID uint) ([]model.Collection, error)
+	SaveCollection(collection *model.Collection) error
+	DeleteCollection(collectionID uint) error
+	GetUserMemberships(userID uint) ([]model.CollectionMember, error)
+	GetCollectionMember(collectionID, userID uint) (*model.CollectionMember, error)
+	GetCollectionMembers(collectionID uint) ([]model.CollectionMember, error)
+	SaveCollectionMember(member *model.CollectionMember) error
+	DeleteCollectionMember(collectionID, userID uint) error
+	CountCollectionOwners(collectionID uint) (int64, error)
+	GetCollectionBookmarks(collectionID uint) ([]model.Bookmark, error)
+	GetTemplateCollections() ([]model.Collection, error)
+	CountCollectionBookmarks(collectionIDs []uint) (map[uint]int64, error)
+	GetUsersByIDs(userIDs []uint) ([]model.User, error)
+	CreateCollectionInvite(invite *model.CollectionInvite) error
+	GetCollectionInvites(collectionID uint) ([]model.CollectionInvite, error)
+	GetCollectionInviteByID(inviteID uint) (*model.CollectionInvite, error)
+	GetCollectionInviteByToken(token string) (*model.CollectionInvite, error)
+	DeleteCollectionInvite(inviteID uint) error
+	AcceptCollectionInvite(invite *model.CollectionInvite, member *model.CollectionMember) error
+
+	// Методы для встраиваемых виджетов коллекций
+	CreateWidget(widget *model.Widget) error
+	GetWidgetByID(widgetID uint) (*model.Widget, error)
+	GetWidgetByToken(token string) (*model.Widget, error)
+	GetCollectionWidgets(collectionID uint) ([]model.Widget, error)
+	SaveWidget(widget *model.Widget) error
+	DeleteWidget(widgetID uint) error
+
+	// Методы для входящих закладок от других пользователей
+	CreateInboxItem(item *model.InboxItem) error
+	GetInboxItemByID(itemID uint) (*model.InboxItem, error)
+	GetPendingInboxItems(recipientID uint) ([]model.InboxItem, error)
+	CountPendingInboxItems(senderID, recipientID uint, url string) (int64, error)
+	AcceptInboxItem(item *model.InboxItem, bookmark *model.Bookmark, now time.Time) error
+	DismissInboxItem(itemID uint, now time.Time) error
+	DismissInboxItemsFrom(recipientID, senderID uint, now time.Time) error
+	IsUserBlocked(userID, blockedUserID uint) (bool, error)
+	GetUserBlocks(userID uint) ([]model.UserBlock, error)
+	SaveUserBlock(block *model.UserBlock) error
+	DeleteUserBlock(userID, blockedUserID uint) error
+
+	// Методы для рабочих пространств
+	CreateWorkspace(workspace *model.Workspace, adminID uint) error
+	GetWorkspaceByID(workspaceID uint) (*model.Workspace, error)
+	SaveWorkspace(workspace *model.Workspace) error
+	DeleteWorkspace(workspaceID uint) error
+	GetUserWorkspaces(userID uint) ([]model.Workspace, []model.WorkspaceMembership, error)
+	GetWorkspaceMembership(workspaceID, userID uint) (*model.WorkspaceMembership, error)
+	GetWorkspaceMembers(workspaceID uint) ([]model.WorkspaceMembership, error)
+	SaveWorkspaceMember(membership *model.WorkspaceMembership) error
+	DeleteWorkspaceMember(workspaceID, userID uint) error
+	CountWorkspaceMembers(workspaceID uint, adminsOnly bool) (int64, error)
+	CountWorkspaceBookmarks(workspaceID uint) (int64, error)
+	GetWorkspaceBookmarks(workspaceID uint) ([]model.Bookmark, error)
+	GetWorkspaceFolders(workspaceID uint) ([]model.Folder, error)
+
+	// Методы для комментариев к закладкам
+	CreateComment(comment *model.Comment) error
+	GetCommentByID(commentID uint) (*model.Comment, error)
+	SaveComment(comment *model.Comment) error
+	DeleteComment(commentID uint) error
+	CountCommentReplies(commentID uint) (int64, error)
+	GetBookmarkComments(bookmarkID uint) ([]model.Comment, error)
+	GetCommentsByBookmarkIDs(bookmarkIDs []uint) ([]model.Comment, error)
+	DeleteBookmarkComments(bookmarkID uint) error
+
+	// Методы для журнала активности
+	CreateActivities(activities []model.Activity) error
+	GetActivities(userID uint, collectionIDs, workspaceIDs []uint, filter *model.ActivityFilter, limit int) ([]model.Activity, error)
+
+	// Методы для классификации закладок по виду содержимого
+	GetUnclassifiedBookmarks(limit int) ([]model.Bookmark, error)
+	UpdateBookmarkKind(bookmarkID uint, kind string) error
+
+	// Методы для напоминаний и откладывания закладок
+	GetDueReminders(now time.Time, limit int) ([]model.Bookmark, error)
+	ClaimDueReminder(bookmark *model.Bookmark, now time.Time) (bool, error)
+	GetUpcomingReminders(userID uint, after time.Time) ([]model.Bookmark, error)
+	GetUserByFeedToken(token string) (*model.User, error)
+
+	// Методы для статистики переходов по закладкам
+	RecordBookmarkVisit(bookmarkID uint, visitedAt time.Time, frecency float64) error
+	GetVisitedBookmarks(userID uint, limit int) ([]model.Bookmark, error)
+	ResetBookmarkVisits(userID uint) error
+
+	// Методы для еженедельной рассылки
+	GetDigestSubscribers() ([]model.User, error)
+	ClaimDigest(userID uint, scheduled, now time.Time) (bool, error)
+	GetBookmarksCreatedSince(userID uint, since time.Time, limit int) ([]model.Bookmark, error)
+	GetResurfacedBookmarks(userID uint, since time.Time, limit int) ([]model.Bookmark, error)
+	GetOldestUnreadBookmarks(userID uint, createdBefore time.Time, limit int) ([]model.Bookmark, int64, error)
+	GetUserByUnsubscribeToken(token string) (*model.User, error)
+
+	// Методы для работы с превью закладок
+	SaveBookmarkPreview(preview *model.BookmarkPreview) error
+	GetBookmarkPreviewByToken(token string) (*model.BookmarkPreview, error)
+	DeleteBookmarkPreview(bookmarkID uint) error
+
+	// Методы для работы с текстом статей
+	SaveBookmarkContent(content *model.BookmarkContent) error
+	GetBookmarkContent(bookmarkID uint) (*model.BookmarkContent, error)
+	DeleteBookmarkContent(bookmarkID uint) error
+	SearchBookmarks(userID, workspaceID uint, query string, limit int) ([]model.Bookmark, error)
+
+	// Методы для работы с офлайн-снимками страниц
+	SaveBookmarkArchive(archive *model.BookmarkArchive) (*model.BookmarkArchive, error)
+	GetBookmarkArchive(bookmarkID uint) (*model.BookmarkArchive, error)
+	DeleteBookmarkArchive(bookmarkID uint) (*model.BookmarkArchive, error)
+
+	// Методы для проверки доступности ссылок
+	GetBookmarksDueForCheck(checkedBefore time.Time, limit int) ([]model.Bookmark, error)
+	UpdateBookmarkHealth(bookmark *model.Bookmark) error
+	PostponeBookmarkCheck(bookmarkID uint, checkedAt time.Time) error
+	GetBookmarksHealth(userID, workspaceID uint, statuses []string, minFailures int) ([]model.Bookmark, error)
+	ScheduleBookmarksCheck(userID uint) (int64, error)
+
+	// Методы для замены URL при постоянных редиректах
+	RewriteBookmarkURL(bookmark *model.Bookmark, rewrite *model.URLRewrite) error
+	UndoURLRewrite(rewrite *model.URLRewrite) error
+	GetURLRewriteByID(id uint) (*model.URLRewrite, error)
+	IsURLRewriteUndone(bookmarkID uint, oldURL, newURL string) (bool, error)
+	GetURLRewrites(userID uint, since time.Time) ([]model.URLRewrite, error)
+	GetBookmarksWithSuggestedURL(userID uint) ([]model.Bookmark, error)
+
+	// Методы для работы с переопределениями иконок
+	GetIconOverrides() ([]model.IconOverride, error)
+	GetIconOverrideByID(id uint) (*model.IconOverride, error)
+	SaveIconOverride(override *model.IconOverride) error
+	DeleteIconOverride(id uint) error
+	SeedIconOverrides(overrides []model.IconOverride) error
 }
 
 type repository struct {
@@ -129,7 +285,8 @@ func (r *repository) SaveUser(user *model.User) error {
 	const op = "repository.SaveUser"
 	log := r.log.With("op", op)
 
-	err := r.db.Model(&model.User{}).Where("id = ?", user.ID).Save(user).Error
+	// storage_used меняется только атомарными обновлениями вместе с хранимыми данными
+	err := r.db.Model(&model.User{}).Where("id = ?", user.ID).Omit("StorageUsed").Save(user).Error
 	if err != nil {
 		log.Error("failed to save user", "error", err)
 		return customerrors.FromUserError(err, "users")
@@ -191,12 +348,13 @@ func (r *repository) AddBookmark(bookmark *model.Bookmark) error {
 	return nil
 }
 
+// GetBookmarks returns the user's personal bookmarks; workspace bookmarks are not included
 func (r *repository) GetBookmarks(userID uint) ([]model.Bookmark, error) {
 	const op = "repository.GetBookmarks"
 	log := r.log.With("op", op)
 
 	var bookmarks []model.Bookmark
-	err := r.db.Where("user_id = ?", userID).Find(&bookmarks).Error
+	err := r.db.Where("user_id = ? AND workspace_id IS NULL", userID).Find(&bookmarks).Error
 	if err != nil {
 		log.Error("failed to get bookmarks", "error", err, "user_id", userID)
 		return nil, customerrors.FromGormError(err)
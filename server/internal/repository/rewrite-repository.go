@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+	customerrors "github.com/aerscs/theca-public/internal/utils/errors"
+	"gorm.io/gorm"
+)
+
+// RewriteBookmarkURL replaces the bookmark URL and records the rewrite in one transaction
+func (r *repository) RewriteBookmarkURL(bookmark *model.Bookmark, rewrite *model.URLRewrite) error {
+	const op = "repository.RewriteBookmarkURL"
+	log := r.log.With("op", op)
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Bookmark{}).Where("id = ?", bookmark.ID).Updates(map[string]any{
+			"url":            bookmark.URL,
+			"final_url":      bookmark.FinalURL,
+			"suggested_url":  bookmark.SuggestedURL,
+			"health_status":  bookmark.HealthStatus,
+			"check_failures": bookmark.CheckFailures,
+			"updated_at":     bookmark.UpdatedAt,
+		}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(rewrite).Error
+	})
+	if err != nil {
+		log.Error("failed to rewrite bookmark URL", "error", err, "bookmark_id", bookmark.ID)
+		return customerrors.FromGormError(err)
+	}
+
+	log.Debug("bookmark URL rewritten", "bookmark_id", bookmark.ID, "old_url", rewrite.OldURL, "new_url", rewrite.NewURL)
+	return nil
+}
+
+// UndoURLRewrite restores the old bookmark URL and marks the rewrite as undone
+func (r *repository) UndoURLRewrite(rewrite *model.URLRewrite) error {
+	const op = "repository.UndoURLRewrite"
+	log := r.log.With("op", op)
+
+	now := time.Now()
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Возвращаем старый URL, только если закладку не меняли после замены
+		result := tx.Model(&model.Bookmark{}).
+			Where("id = ? AND url = ?", rewrite.BookmarkID, rewrite.NewURL).
+			Updates(map[string]any{
+				"url":            rewrite.OldURL,
+				"final_url":      rewrite.OldURL,
+				"suggested_url":  "",
+				"check_failures": 0,
+				"updated_at":     now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return customerrors.New(customerrors.CodeDataConflict, "Bookmark URL was changed after the rewrite")
+		}
+
+		rewrite.UndoneAt = &now
+		return tx.Model(rewrite).Update("undone_at", now).Error
+	})
+	if err != nil {
+		var customErr *customerrors.Error
+		if errors.As(err, &customErr) {
+			return err
+		}
+		log.Error("failed to undo URL rewrite", "error", err, "rewrite_id", rewrite.ID)
+		return customerrors.FromGormError(err)
+	}
+
+	log.Debug("URL rewrite undone", "rewrite_id", rewrite.ID, "bookmark_id", rewrite.BookmarkID)
+	return nil
+}
+
+// IsURLRewriteUndone reports whether the user undid a rewrite of the bookmark from oldURL to newURL
+func (r *repository) IsURLRewriteUndone(bookmarkID uint, oldURL, newURL string) (bool, error) {
+	const op = "repository.IsURLRewriteUndone"
+	log := r.log.With("op", op)
+
+	var count int64
+	err := r.db.Model(&model.URLRewrite{}).
+		Where("bookmark_id = ? AND old_url = ? AND new_url = ? AND undone_at IS NOT NULL", bookmarkID, oldURL, newURL).
+		Count(&count).Error
+	if err != nil {
+		log.Error("failed to check undone URL rewrite", "error", err, "bookmark_id", bookmarkID)
+		return false, customerrors.FromGormError(err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *repository) GetURLRewriteByID(id uint) (*model.URLRewrite, error) {
+	const op = "repository.GetURLRewriteByID"
+	log := r.log.With("op", op)
+
+	var rewrite model.URLRewrite
+	err := r.db.First(&rewrite, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customerrors.New(customerrors.CodeNotFound, "Rewrite not found")
+		}
+		log.Error("failed to get URL rewrite", "error", err, "rewrite_id", id)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return &rewrite, nil
+}
+
+// GetURLRewrites returns rewrites of the user's personal bookmarks created after since, newest first
+func (r *repository) GetURLRewrites(userID uint, since time.Time) ([]model.URLRewrite, error) {
+	const op = "repository.GetURLRewrites"
+	log := r.log.With("op", op)
+
+	var rewrites []model.URLRewrite
+	personal := r.db.Model(&model.Bookmark{}).Select("id").Where("workspace_id IS NULL")
+	err := r.db.Where("user_id = ? AND created_at >= ? AND bookmark_id IN (?)", userID, since, personal).
+		Order("created_at DESC").
+		Find(&rewrites).Error
+	if err != nil {
+		log.Error("failed to get URL rewrites", "error", err, "user_id", userID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return rewrites, nil
+}
+
+// GetBookmarksWithSuggestedURL returns personal bookmarks of the user that have a pending redirect suggestion
+func (r *repository) GetBookmarksWithSuggestedURL(userID uint) ([]model.Bookmark, error) {
+	const op = "repository.GetBookmarksWithSuggestedURL"
+	log := r.log.With("op", op)
+
+	var bookmarks []model.Bookmark
+	err := r.db.Where("user_id = ? AND workspace_id IS NULL AND suggested_url <> ''", userID).Find(&bookmarks).Error
+	if err != nil {
+		log.Error("failed to get bookmarks with suggested URL", "error", err, "user_id", userID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return bookmarks, nil
+}
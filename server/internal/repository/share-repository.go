@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+	customerrors "github.com/aerscs/theca-public/internal/utils/errors"
+	"gorm.io/gorm"
+)
+
+func (r *repository) CreateShareLink(link *model.ShareLink) error {
+	const op = "repository.CreateShareLink"
+	log := r.log.With("op", op)
+
+	err := r.db.Create(link).Error
+	if err != nil {
+		log.Error("failed to create share link", "error", err, "user_id", link.UserID)
+		return customerrors.FromGormError(err)
+	}
+
+	log.Debug("share link created", "share_id", link.ID, "user_id", link.UserID, "kind", link.Kind)
+	return nil
+}
+
+func (r *repository) GetShareLinks(userID uint) ([]model.ShareLink, error) {
+	const op = "repository.GetShareLinks"
+	log := r.log.With("op", op)
+
+	var links []model.ShareLink
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&links).Error
+	if err != nil {
+		log.Error("failed to get share links", "error", err, "user_id", userID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return links, nil
+}
+
+func (r *repository) GetShareLinkByID(shareID uint) (*model.ShareLink, error) {
+	const op = "repository.GetShareLinkByID"
+	log := r.log.With("op", op)
+
+	var link model.ShareLink
+	err := r.db.First(&link, shareID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customerrors.New(customerrors.CodeNotFound, "Share link not found")
+		}
+		log.Error("failed to get share link", "error", err, "share_id", shareID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return &link, nil
+}
+
+func (r *repository) GetShareLinkByToken(token string) (*model.ShareLink, error) {
+	const op = "repository.GetShareLinkByToken"
+	log := r.log.With("op", op)
+
+	var link model.ShareLink
+	err := r.db.Where("token = ?", token).First(&link).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customerrors.New(customerrors.CodeNotFound, "Share link not found")
+		}
+		log.Error("failed to get share link by token", "error", err)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return &link, nil
+}
+
+func (r *repository) DeleteShareLink(shareID uint) error {
+	const op = "repository.DeleteShareLink"
+	log := r.log.With("op", op)
+
+	err := r.db.Delete(&model.ShareLink{}, shareID).Error
+	if err != nil {
+		log.Error("failed to delete share link", "error", err, "share_id", shareID)
+		return customerrors.FromGormError(err)
+	}
+
+	log.Debug("share link deleted", "share_id", shareID)
+	return nil
+}
+
+// RecordShareView increments the view counter of the share link
+func (r *repository) RecordShareView(shareID uint, viewedAt time.Time) error {
+	const op = "repository.RecordShareView"
+	log := r.log.With("op", op)
+
+	err := r.db.Model(&model.ShareLink{}).Where("id = ?", shareID).UpdateColumns(map[string]any{
+		"view_count":     gorm.Expr("view_count + 1"),
+		"last_viewed_at": viewedAt,
+	}).Error
+	if err != nil {
+		log.Error("failed to record share view", "error", err, "share_id", shareID)
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
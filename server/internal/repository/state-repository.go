@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+	customerrors "github.com/aerscs/theca-public/internal/utils/errors"
+)
+
+// GetFilteredBookmarks returns the user's bookmarks in the given read-later state, favorites, content kind, folder and/or tag.
+// With filter.WorkspaceID set it returns bookmarks of that workspace instead of the user's personal ones
+func (r *repository) GetFilteredBookmarks(userID uint, filter *model.BookmarkListFilter) ([]model.Bookmark, error) {
+	const op = "repository.GetFilteredBookmarks"
+	log := r.log.With("op", op)
+
+	query := r.db.Where("user_id = ? AND workspace_id IS NULL", userID)
+	if filter.WorkspaceID != 0 {
+		query = r.db.Where("workspace_id = ?", filter.WorkspaceID)
+	}
+	if filter.State != "" {
+		query = query.Where("state = ?", filter.State)
+	}
+	if filter.Favorite != nil {
+		query = query.Where("favorite = ?", *filter.Favorite)
+	}
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+	if filter.FolderID != nil {
+		if *filter.FolderID == 0 {
+			query = query.Where("folder_id IS NULL")
+		} else {
+			query = query.Where("folder_id = ?", *filter.FolderID)
+		}
+	}
+	if filter.Tag != "" {
+		query = query.Where(`tags LIKE ? ESCAPE '\'`, tagPattern(filter.Tag))
+	}
+	switch {
+	case filter.Snoozed != nil && *filter.Snoozed:
+		query = query.Where("snoozed_until > ?", time.Now())
+	case filter.Snoozed != nil || filter.State == model.StateUnread:
+		// Отложенные закладки возвращаются во входящие, когда срок истечёт
+		query = query.Where(notSnoozedCondition, time.Now())
+	}
+
+	var bookmarks []model.Bookmark
+	err := query.Order("created_at DESC").Find(&bookmarks).Error
+	if err != nil {
+		log.Error("failed to get filtered bookmarks", "error", err, "user_id", userID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	log.Debug("filtered bookmarks retrieved", "user_id", userID, "state", filter.State, "count", len(bookmarks))
+	return bookmarks, nil
+}
+
+// UpdateBookmarkState saves only the read-later state, favorite flag, reading progress and reminders
+func (r *repository) UpdateBookmarkState(bookmark *model.Bookmark) error {
+	const op = "repository.UpdateBookmarkState"
+	log := r.log.With("op", op)
+
+	err := r.db.Model(&model.Bookmark{}).Where("id = ?", bookmark.ID).UpdateColumns(map[string]any{
+		"state":         bookmark.State,
+		"read_at":       bookmark.ReadAt,
+		"archived_at":   bookmark.ArchivedAt,
+		"favorite":      bookmark.Favorite,
+		"favorited_at":  bookmark.FavoritedAt,
+		"progress":      bookmark.Progress,
+		"progress_at":   bookmark.ProgressAt,
+		"remind_at":     bookmark.RemindAt,
+		"snoozed_until": bookmark.SnoozedUntil,
+	}).Error
+	if err != nil {
+		log.Error("failed to update bookmark state", "error", err, "bookmark_id", bookmark.ID)
+		return customerrors.FromGormError(err)
+	}
+
+	log.Debug("bookmark state updated", "bookmark_id", bookmark.ID, "state", bookmark.State)
+	return nil
+}
+
+// tagPattern builds a LIKE pattern matching the tag inside the JSON-serialized tags column
+func tagPattern(tag string) string {
+	encoded, _ := json.Marshal(tag)
+	escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(string(encoded))
+	return "%" + escaped + "%"
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+	customerrors "github.com/aerscs/theca-public/internal/utils/errors"
+	"gorm.io/gorm"
+)
+
+// RecordBookmarkVisit increments the visit counter and stores the new frecency without touching updated_at
+func (r *repository) RecordBookmarkVisit(bookmarkID uint, visitedAt time.Time, frecency float64) error {
+	const op = "repository.RecordBookmarkVisit"
+	log := r.log.With("op", op)
+
+	err := r.db.Model(&model.Bookmark{}).Where("id = ?", bookmarkID).UpdateColumns(map[string]any{
+		"visit_count":     gorm.Expr("visit_count + 1"),
+		"last_visited_at": visitedAt,
+		"frecency":        frecency,
+	}).Error
+	if err != nil {
+		log.Error("failed to record bookmark visit", "error", err, "bookmark_id", bookmarkID)
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
+
+// GetVisitedBookmarks returns the user's visited personal bookmarks, most visited first. limit <= 0 means no limit
+func (r *repository) GetVisitedBookmarks(userID uint, limit int) ([]model.Bookmark, error) {
+	const op = "repository.GetVisitedBookmarks"
+	log := r.log.With("op", op)
+
+	query := r.db.Select("id", "user_id", "title", "url", "favicon", "visit_count", "last_visited_at", "frecency").
+		Where("user_id = ? AND workspace_id IS NULL AND visit_count > 0", userID).
+		Order("visit_count DESC, last_visited_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var bookmarks []model.Bookmark
+	if err := query.Find(&bookmarks).Error; err != nil {
+		log.Error("failed to get visited bookmarks", "error", err, "user_id", userID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return bookmarks, nil
+}
+
+// ResetBookmarkVisits removes the collected visit statistics of the user's personal bookmarks
+func (r *repository) ResetBookmarkVisits(userID uint) error {
+	const op = "repository.ResetBookmarkVisits"
+	log := r.log.With("op", op)
+
+	err := r.db.Model(&model.Bookmark{}).Where("user_id = ? AND workspace_id IS NULL AND visit_count > 0", userID).UpdateColumns(map[string]any{
+		"visit_count":     0,
+		"last_visited_at": nil,
+		"frecency":        0,
+	}).Error
+	if err != nil {
+		log.Error("failed to reset bookmark visits", "error", err, "user_id", userID)
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
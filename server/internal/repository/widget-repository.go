@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/aerscs/theca-public/internal/model"
+	customerrors "github.com/aerscs/theca-public/internal/utils/errors"
+	"gorm.io/gorm"
+)
+
+func (r *repository) CreateWidget(widget *model.Widget) error {
+	const op = "repository.CreateWidget"
+	log := r.log.With("op", op)
+
+	err := r.db.Create(widget).Error
+	if err != nil {
+		log.Error("failed to create widget", "error", err, "collection_id", widget.CollectionID)
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
+
+func (r *repository) GetWidgetByID(widgetID uint) (*model.Widget, error) {
+	const op = "repository.GetWidgetByID"
+	log := r.log.With("op", op)
+
+	var widget model.Widget
+	err := r.db.First(&widget, widgetID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customerrors.New(customerrors.CodeNotFound, "Widget not found")
+		}
+		log.Error("failed to get widget", "error", err, "widget_id", widgetID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return &widget, nil
+}
+
+func (r *repository) GetWidgetByToken(token string) (*model.Widget, error) {
+	const op = "repository.GetWidgetByToken"
+	log := r.log.With("op", op)
+
+	var widget model.Widget
+	err := r.db.Where("token = ?", token).First(&widget).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customerrors.New(customerrors.CodeNotFound, "Widget not found")
+		}
+		log.Error("failed to get widget by token", "error", err)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return &widget, nil
+}
+
+func (r *repository) GetCollectionWidgets(collectionID uint) ([]model.Widget, error) {
+	const op = "repository.GetCollectionWidgets"
+	log := r.log.With("op", op)
+
+	var widgets []model.Widget
+	err := r.db.Where("collection_id = ?", collectionID).Order("created_at DESC").Find(&widgets).Error
+	if err != nil {
+		log.Error("failed to get collection widgets", "error", err, "collection_id", collectionID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return widgets, nil
+}
+
+func (r *repository) SaveWidget(widget *model.Widget) error {
+	const op = "repository.SaveWidget"
+	log := r.log.With("op", op)
+
+	err := r.db.Save(widget).Error
+	if err != nil {
+		log.Error("failed to save widget", "error", err, "widget_id", widget.ID)
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
+
+func (r *repository) DeleteWidget(widgetID uint) error {
+	const op = "repository.DeleteWidget"
+	log := r.log.With("op", op)
+
+	err := r.db.Delete(&model.Widget{}, widgetID).Error
+	if err != nil {
+		log.Error("failed to delete widget", "error", err, "widget_id", widgetID)
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/aerscs/theca-public/internal/model"
+	customerrors "github.com/aerscs/theca-public/internal/utils/errors"
+	"gorm.io/gorm"
+)
+
+// CreateWorkspace creates the workspace and makes the given user its admin
+func (r *repository) CreateWorkspace(workspace *model.Workspace, adminID uint) error {
+	const op = "repository.CreateWorkspace"
+	log := r.log.With("op", op)
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(workspace).Error; err != nil {
+			return err
+		}
+		return tx.Create(&model.WorkspaceMembership{
+			WorkspaceID: workspace.ID,
+			UserID:      adminID,
+			Role:        model.WorkspaceAdmin,
+			CreatedAt:   workspace.CreatedAt,
+		}).Error
+	})
+	if err != nil {
+		log.Error("failed to create workspace", "error", err, "user_id", adminID)
+		return customerrors.FromGormError(err)
+	}
+
+	log.Debug("workspace created", "workspace_id", workspace.ID, "user_id", adminID)
+	return nil
+}
+
+func (r *repository) GetWorkspaceByID(workspaceID uint) (*model.Workspace, error) {
+	const op = "repository.GetWorkspaceByID"
+	log := r.log.With("op", op)
+
+	var workspace model.Workspace
+	err := r.db.First(&workspace, workspaceID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customerrors.New(customerrors.CodeNotFound, "Workspace not found")
+		}
+		log.Error("failed to get workspace", "error", err, "workspace_id", workspaceID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return &workspace, nil
+}
+
+func (r *repository) SaveWorkspace(workspace *model.Workspace) error {
+	const op = "repository.SaveWorkspace"
+	log := r.log.With("op", op)
+
+	err := r.db.Save(workspace).Error
+	if err != nil {
+		log.Error("failed to save workspace", "error", err, "workspace_id", workspace.ID)
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
+
+// DeleteWorkspace removes the workspace with its folders and members.
+// Its bookmarks become personal bookmarks of the users who added them
+func (r *repository) DeleteWorkspace(workspaceID uint) error {
+	const op = "repository.DeleteWorkspace"
+	log := r.log.With("op", op)
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Bookmark{}).Where("workspace_id = ?", workspaceID).
+			UpdateColumns(map[string]any{"workspace_id": nil, "folder_id": nil}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("workspace_id = ?", workspaceID).Delete(&model.Folder{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("workspace_id = ?", workspaceID).Delete(&model.WorkspaceMembership{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Workspace{}, workspaceID).Error
+	})
+	if err != nil {
+		log.Error("failed to delete workspace", "error", err, "workspace_id", workspaceID)
+		return customerrors.FromGormError(err)
+	}
+
+	log.Debug("workspace deleted", "workspace_id", workspaceID)
+	return nil
+}
+
+// GetUserWorkspaces returns workspaces the user belongs to with the user's memberships
+func (r *repository) GetUserWorkspaces(userID uint) ([]model.Workspace, []model.WorkspaceMembership, error) {
+	const op = "repository.GetUserWorkspaces"
+	log := r.log.With("op", op)
+
+	var memberships []model.WorkspaceMembership
+	if err := r.db.Where("user_id = ?", userID).Find(&memberships).Error; err != nil {
+		log.Error("failed to get workspace memberships", "error", err, "user_id", userID)
+		return nil, nil, customerrors.FromGormError(err)
+	}
+
+	var workspaces []model.Workspace
+	if len(memberships) == 0 {
+		return workspaces, memberships, nil
+	}
+	ids := make([]uint, 0, len(memberships))
+	for _, membership := range memberships {
+		ids = append(ids, membership.WorkspaceID)
+	}
+	if err := r.db.Where("id IN ?", ids).Order("name").Find(&workspaces).Error; err != nil {
+		log.Error("failed to get workspaces", "error", err, "user_id", userID)
+		return nil, nil, customerrors.FromGormError(err)
+	}
+
+	return workspaces, memberships, nil
+}
+
+func (r *repository) GetWorkspaceMembership(workspaceID, userID uint) (*model.WorkspaceMembership, error) {
+	const op = "repository.GetWorkspaceMembership"
+	log := r.log.With("op", op)
+
+	var membership model.WorkspaceMembership
+	err := r.db.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).First(&membership).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customerrors.New(customerrors.CodeNotFound, "Workspace member not found")
+		}
+		log.Error("failed to get workspace member", "error", err, "workspace_id", workspaceID, "user_id", userID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return &membership, nil
+}
+
+func (r *repository) GetWorkspaceMembers(workspaceID uint) ([]model.WorkspaceMembership, error) {
+	const op = "repository.GetWorkspaceMembers"
+	log := r.log.With("op", op)
+
+	var members []model.WorkspaceMembership
+	err := r.db.Where("workspace_id = ?", workspaceID).Order("created_at").Find(&members).Error
+	if err != nil {
+		log.Error("failed to get workspace members", "error", err, "workspace_id", workspaceID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return members, nil
+}
+
+func (r *repository) SaveWorkspaceMember(membership *model.WorkspaceMembership) error {
+	const op = "repository.SaveWorkspaceMember"
+	log := r.log.With("op", op)
+
+	err := r.db.Save(membership).Error
+	if err != nil {
+		log.Error("failed to save workspace member", "error", err, "workspace_id", membership.WorkspaceID, "user_id", membership.UserID)
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
+
+func (r *repository) DeleteWorkspaceMember(workspaceID, userID uint) error {
+	const op = "repository.DeleteWorkspaceMember"
+	log := r.log.With("op", op)
+
+	err := r.db.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).Delete(&model.WorkspaceMembership{}).Error
+	if err != nil {
+		log.Error("failed to delete workspace member", "error", err, "workspace_id", workspaceID, "user_id", userID)
+		return customerrors.FromGormError(err)
+	}
+
+	return nil
+}
+
+// CountWorkspaceMembers returns the number of workspace members, only admins if adminsOnly is set
+func (r *repository) CountWorkspaceMembers(workspaceID uint, adminsOnly bool) (int64, error) {
+	const op = "repository.CountWorkspaceMembers"
+	log := r.log.With("op", op)
+
+	query := r.db.Model(&model.WorkspaceMembership{}).Where("workspace_id = ?", workspaceID)
+	if adminsOnly {
+		query = query.Where("role = ?", model.WorkspaceAdmin)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		log.Error("failed to count workspace members", "error", err, "workspace_id", workspaceID)
+		return 0, customerrors.FromGormError(err)
+	}
+
+	return count, nil
+}
+
+func (r *repository) CountWorkspaceBookmarks(workspaceID uint) (int64, error) {
+	const op = "repository.CountWorkspaceBookmarks"
+	log := r.log.With("op", op)
+
+	var count int64
+	err := r.db.Model(&model.Bookmark{}).Where("workspace_id = ?", workspaceID).Count(&count).Error
+	if err != nil {
+		log.Error("failed to count workspace bookmarks", "error", err, "workspace_id", workspaceID)
+		return 0, customerrors.FromGormError(err)
+	}
+
+	return count, nil
+}
+
+func (r *repository) GetWorkspaceBookmarks(workspaceID uint) ([]model.Bookmark, error) {
+	const op = "repository.GetWorkspaceBookmarks"
+	log := r.log.With("op", op)
+
+	var bookmarks []model.Bookmark
+	err := r.db.Where("workspace_id = ?", workspaceID).Find(&bookmarks).Error
+	if err != nil {
+		log.Error("failed to get workspace bookmarks", "error", err, "workspace_id", workspaceID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return bookmarks, nil
+}
+
+func (r *repository) GetWorkspaceFolders(workspaceID uint) ([]model.Folder, error) {
+	const op = "repository.GetWorkspaceFolders"
+	log := r.log.With("op", op)
+
+	var folders []model.Folder
+	err := r.db.Where("workspace_id = ?", workspaceID).Order("name").Find(&folders).Error
+	if err != nil {
+		log.Error("failed to get workspace folders", "error", err, "workspace_id", workspaceID)
+		return nil, customerrors.FromGormError(err)
+	}
+
+	return folders, nil
+}
@@ -0,0 +1,86 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Job периодическая фоновая задача
+type Job struct {
+	Run      func(ctx context.Context)
+	Name     string
+	Interval time.Duration
+}
+
+// Scheduler запускает фоновые задачи с заданным периодом.
+// Запуски одной задачи не пересекаются: если задача выполняется дольше периода, тики пропускаются
+type Scheduler struct {
+	log    *slog.Logger
+	cancel context.CancelFunc
+	jobs   []Job
+	wg     sync.WaitGroup
+}
+
+func New(log *slog.Logger) *Scheduler {
+	return &Scheduler{log: log}
+}
+
+// Every регистрирует задачу. Вызывать до Start
+func (s *Scheduler) Every(name string, interval time.Duration, run func(ctx context.Context)) {
+	s.jobs = append(s.jobs, Job{Name: name, Interval: interval, Run: run})
+}
+
+// Start запускает все зарегистрированные задачи
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	for _, job := range s.jobs {
+		if job.Interval <= 0 {
+			s.log.Warn("job disabled", "job", job.Name)
+			continue
+		}
+
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+	}
+}
+
+// Stop останавливает задачи и дожидается завершения текущих запусков
+func (s *Scheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.run(ctx, job)
+		}
+	}
+}
+
+// run выполняет задачу, не давая панике остановить планировщик
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	log := s.log.With("job", job.Name)
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("job panicked", "panic", r)
+		}
+	}()
+
+	start := time.Now()
+	job.Run(ctx)
+	log.Debug("job finished", "duration", time.Since(start).String())
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get Activity
+// @Description Get the activity feed, newest first: who added, edited, moved or deleted which bookmark and when. Covers the user's own bookmarks and actions and changes in collections and workspaces the user belongs to. Pass next_before as before to get the next page
+// @Tags activity
+// @Produce json
+// @Param collection_id query int false "Only activity of this collection"
+// @Param workspace_id query int false "Only activity of this workspace"
+// @Param actor_id query int false "Only actions of this user"
+// @Param before query int false "Return entries older than this entry ID"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Success 200 {object} model.ActivityPageResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/activity [get]
+func (h *Handler) GetActivity(c *gin.Context) {
+	const op = "handler.GetActivity"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	var filter model.ActivityFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		log.Debug("binding query", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid query parameters"))
+		return
+	}
+
+	page, err := h.service.GetActivity(userID, &filter)
+	if err != nil {
+		log.Error("failed to get activity", "error", err, "user_id", userID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, page)
+}
@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+	"github.com/gin-gonic/gin"
+)
+
+// RequireAdmin пропускает только пользователей с правами администратора.
+// Должен стоять после JWTMiddleware
+func (h *Handler) RequireAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handler.RequireAdmin"
+		log := h.log.With("op", op)
+
+		userID := c.GetUint("userID")
+		if userID == 0 {
+			errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+			c.Abort()
+			return
+		}
+
+		isAdmin, err := h.service.IsAdmin(userID)
+		if err != nil {
+			log.Error("failed to check admin rights", "error", err, "user_id", userID)
+			errors.RespondWithError(c, err)
+			c.Abort()
+			return
+		}
+		if !isAdmin {
+			log.Warn("non-admin user tried to access admin endpoint", "user_id", userID, "path", c.FullPath())
+			errors.RespondWithError(c, errors.New(errors.CodeForbidden, "Forbidden"))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// @Summary Get Icon Overrides
+// @Description Get icon overrides for known services (admin only)
+// @Tags admin
+// @Produce json
+// @Success 200 {array} model.IconOverride
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/admin/icon-overrides [get]
+func (h *Handler) GetIconOverrides(c *gin.Context) {
+	const op = "handler.GetIconOverrides"
+	log := h.log.With("op", op)
+
+	overrides, err := h.service.GetIconOverrides()
+	if err != nil {
+		log.Error("failed to get icon overrides", "error", err)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, overrides)
+}
+
+// @Summary Create Icon Override
+// @Description Create icon override for a domain or a wildcard pattern like *.example.com (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param overrideRequest body model.IconOverrideRequest true "Icon override"
+// @Success 200 {object} model.IconOverride
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 409
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/admin/icon-overrides [post]
+func (h *Handler) CreateIconOverride(c *gin.Context) {
+	const op = "handler.CreateIconOverride"
+	log := h.log.With("op", op)
+
+	var req model.IconOverrideRequest
+	if err := c.BindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid request format"))
+		return
+	}
+
+	override, err := h.service.CreateIconOverride(&req)
+	if err != nil {
+		log.Error("failed to create icon override", "error", err)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, override)
+}
+
+// @Summary Update Icon Override
+// @Description Update icon override (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Icon override ID"
+// @Param overrideRequest body model.IconOverrideRequest true "Icon override"
+// @Success 200 {object} model.IconOverride
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 409
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/admin/icon-overrides/{id} [put]
+func (h *Handler) UpdateIconOverride(c *gin.Context) {
+	const op = "handler.UpdateIconOverride"
+	log := h.log.With("op", op)
+
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid icon override ID", "error", err, "id", idStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid icon override ID"))
+		return
+	}
+
+	var req model.IconOverrideRequest
+	if err := c.BindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid request format"))
+		return
+	}
+
+	override, err := h.service.UpdateIconOverride(uint(id), &req)
+	if err != nil {
+		log.Error("failed to update icon override", "error", err, "id", id)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, override)
+}
+
+// @Summary Delete Icon Override
+// @Description Delete icon override (admin only)
+// @Tags admin
+// @Produce json
+// @Param id path int true "Icon override ID"
+// @Success 200 {object} errors.Response
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/admin/icon-overrides/{id} [delete]
+func (h *Handler) DeleteIconOverride(c *gin.Context) {
+	const op = "handler.DeleteIconOverride"
+	log := h.log.With("op", op)
+
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid icon override ID", "error", err, "id", idStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid icon override ID"))
+		return
+	}
+
+	if err := h.service.DeleteIconOverride(uint(id)); err != nil {
+		log.Error("failed to delete icon override", "error", err, "id", id)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, "Icon override deleted successfully")
+}
+
+// @Summary Set Workspace Plan
+// @Description Change the plan of a team workspace, which sets its member and bookmark limits (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param planRequest body model.WorkspacePlanRequest true "Plan"
+// @Success 200 {object} model.Workspace
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/admin/workspaces/{id}/plan [put]
+func (h *Handler) SetWorkspacePlan(c *gin.Context) {
+	const op = "handler.SetWorkspacePlan"
+	log := h.log.With("op", op)
+
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid workspace ID", "error", err, "id", idStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid workspace ID"))
+		return
+	}
+
+	var req model.WorkspacePlanRequest
+	if err := c.BindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid request format"))
+		return
+	}
+
+	workspace, err := h.service.SetWorkspacePlan(uint(id), &req)
+	if err != nil {
+		log.Error("failed to set workspace plan", "error", err, "id", id)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, workspace)
+}
+
+// @Summary Set Collection Template
+// @Description Publish any collection as a template or a starter pack shown first in the template list (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Param templateRequest body model.AdminTemplateRequest true "Template flags"
+// @Success 200 {object} model.Collection
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/admin/collections/{id}/template [put]
+func (h *Handler) SetCollectionTemplate(c *gin.Context) {
+	const op = "handler.SetCollectionTemplate"
+	log := h.log.With("op", op)
+
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid collection ID", "error", err, "id", idStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid collection ID"))
+		return
+	}
+
+	var req model.AdminTemplateRequest
+	if err := c.BindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid request format"))
+		return
+	}
+
+	collection, err := h.service.SetCollectionTemplate(uint(id), &req)
+	if err != nil {
+		log.Error("failed to set collection template", "error", err, "id", id)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, collection)
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"net/http"
 	"strconv"
 
 	"github.com/aerscs/theca-public/internal/model"
@@ -38,7 +39,7 @@ func (h *Handler) AddBookmark(c *gin.Context) {
 		return
 	}
 
-	bookmark, err := h.service.AddBookmark(userID, req.Title, req.URL, req.ShowText)
+	bookmark, err := h.service.AddBookmark(userID, &req)
 	if err != nil {
 		log.Error("failed to add bookmark", "error", err)
 		errors.RespondWithError(c, err)
@@ -46,23 +47,24 @@ func (h *Handler) AddBookmark(c *gin.Context) {
 	}
 
 	log.Debug("bookmark added successfully", "user_id", userID, "bookmark_id", bookmark.ID)
-	errors.RespondWithSuccess(c, model.BookmarkResponse{
-		ID:        bookmark.ID,
-		Title:     bookmark.Title,
-		URL:       bookmark.URL,
-		ShowText:  bookmark.ShowText,
-		CreatedAt: bookmark.CreatedAt,
-		UpdatedAt: bookmark.UpdatedAt,
-		Favicon:   bookmark.Favicon,
-	})
+	errors.RespondWithSuccess(c, model.NewBookmarkResponse(bookmark))
 }
 
 // @Summary Get All Bookmarks
-// @Description Get all bookmarks for the authenticated user
+// @Description Get bookmarks of the authenticated user, optionally filtered by read-later state
 // @Tags bookmarks
 // @Produce json
+// @Param state query string false "Read-later state: unread, read or archived"
+// @Param favorite query bool false "Only favorite (true) or non-favorite (false) bookmarks"
+// @Param snoozed query bool false "Only snoozed (true) or not snoozed (false) bookmarks. Unread bookmarks exclude snoozed ones by default"
+// @Param kind query string false "Content kind: article, video, repository, documentation, pdf, image, social, shop or other"
+// @Param folder_id query int false "Folder ID, 0 for bookmarks outside folders"
+// @Param tag query string false "Tag"
+// @Param workspace_id query int false "Workspace ID: list bookmarks of the workspace instead of personal ones"
 // @Success 200 {array} model.BookmarkResponse
+// @Failure 400
 // @Failure 401
+// @Failure 403
 // @Failure 500
 // @Security Bearer
 // @Router /v1/api/bookmarks [get]
@@ -77,7 +79,14 @@ func (h *Handler) GetBookmarks(c *gin.Context) {
 		return
 	}
 
-	bookmarks, err := h.service.GetBookmarks(userID)
+	var filter model.BookmarkListFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		log.Debug("binding query", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid bookmark filter"))
+		return
+	}
+
+	bookmarks, err := h.service.GetBookmarks(userID, &filter)
 	if err != nil {
 		log.Error("failed to get bookmarks", "error", err)
 		errors.RespondWithError(c, err)
@@ -85,16 +94,8 @@ func (h *Handler) GetBookmarks(c *gin.Context) {
 	}
 
 	bookmarkResponses := make([]model.BookmarkResponse, len(bookmarks))
-	for i, bookmark := range bookmarks {
-		bookmarkResponses[i] = model.BookmarkResponse{
-			ID:        bookmark.ID,
-			Title:     bookmark.Title,
-			URL:       bookmark.URL,
-			ShowText:  bookmark.ShowText,
-			CreatedAt: bookmark.CreatedAt,
-			UpdatedAt: bookmark.UpdatedAt,
-			Favicon:   bookmark.Favicon,
-		}
+	for i := range bookmarks {
+		bookmarkResponses[i] = model.NewBookmarkResponse(&bookmarks[i])
 	}
 
 	log.Debug("bookmarks retrieved successfully", "user_id", userID, "count", len(bookmarks))
@@ -141,15 +142,7 @@ func (h *Handler) GetBookmarkByID(c *gin.Context) {
 	}
 
 	log.Debug("bookmark retrieved successfully", "user_id", userID, "bookmark_id", bookmarkID)
-	errors.RespondWithSuccess(c, model.BookmarkResponse{
-		ID:        bookmark.ID,
-		Title:     bookmark.Title,
-		URL:       bookmark.URL,
-		ShowText:  bookmark.ShowText,
-		CreatedAt: bookmark.CreatedAt,
-		UpdatedAt: bookmark.UpdatedAt,
-		Favicon:   bookmark.Favicon,
-	})
+	errors.RespondWithSuccess(c, model.NewBookmarkResponse(bookmark))
 }
 
 // @Summary Update Bookmark
@@ -193,7 +186,7 @@ func (h *Handler) UpdateBookmark(c *gin.Context) {
 		return
 	}
 
-	if req.Title == nil && req.URL == nil && req.ShowText == nil {
+	if req.Title == nil && req.URL == nil && req.ShowText == nil && req.FolderID == nil && req.Tags == nil && req.CollectionID == nil {
 		log.Debug("empty patch request")
 		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "No fields to update"))
 		return
@@ -207,15 +200,7 @@ func (h *Handler) UpdateBookmark(c *gin.Context) {
 	}
 
 	log.Debug("bookmark updated successfully", "user_id", userID, "bookmark_id", bookmarkID)
-	errors.RespondWithSuccess(c, model.BookmarkResponse{
-		ID:        bookmark.ID,
-		Title:     bookmark.Title,
-		URL:       bookmark.URL,
-		ShowText:  bookmark.ShowText,
-		CreatedAt: bookmark.CreatedAt,
-		UpdatedAt: bookmark.UpdatedAt,
-		Favicon:   bookmark.Favicon,
-	})
+	errors.RespondWithSuccess(c, model.NewBookmarkResponse(bookmark))
 }
 
 // @Summary Delete Bookmark
@@ -262,7 +247,7 @@ func (h *Handler) DeleteBookmark(c *gin.Context) {
 }
 
 // @Summary Import Bookmarks
-// @Description Import bookmarks from HTML file encoded in base64
+// @Description Import bookmarks from HTML file encoded in base64, into personal bookmarks or a workspace
 // @Tags bookmarks
 // @Accept json
 // @Produce json
@@ -270,6 +255,7 @@ func (h *Handler) DeleteBookmark(c *gin.Context) {
 // @Success 200 {array} model.BookmarkResponse
 // @Failure 400
 // @Failure 401
+// @Failure 403
 // @Failure 500
 // @Security Bearer
 // @Router /v1/api/bookmarks/import [put]
@@ -297,7 +283,7 @@ func (h *Handler) ImportBookmarks(c *gin.Context) {
 		return
 	}
 
-	bookmarks, err := h.service.ImportBookmarks(userID, req.File)
+	bookmarks, err := h.service.ImportBookmarks(userID, req.WorkspaceID, req.File)
 	if err != nil {
 		log.Error("failed to import bookmarks", "error", err)
 		errors.RespondWithError(c, err)
@@ -305,16 +291,8 @@ func (h *Handler) ImportBookmarks(c *gin.Context) {
 	}
 
 	bookmarkResponses := make([]model.BookmarkResponse, len(bookmarks))
-	for i, bookmark := range bookmarks {
-		bookmarkResponses[i] = model.BookmarkResponse{
-			ID:        bookmark.ID,
-			Title:     bookmark.Title,
-			URL:       bookmark.URL,
-			ShowText:  bookmark.ShowText,
-			CreatedAt: bookmark.CreatedAt,
-			UpdatedAt: bookmark.UpdatedAt,
-			Favicon:   bookmark.Favicon,
-		}
+	for i := range bookmarks {
+		bookmarkResponses[i] = model.NewBookmarkResponse(&bookmarks[i])
 	}
 
 	log.Debug("bookmarks imported successfully", "user_id", userID, "count", len(bookmarks))
@@ -322,11 +300,14 @@ func (h *Handler) ImportBookmarks(c *gin.Context) {
 }
 
 // @Summary Export Bookmarks
-// @Description Export all user's bookmarks as HTML file in base64 encoding
+// @Description Export all user's personal bookmarks, or bookmarks of a workspace, as HTML file in base64 encoding. Comments are written into bookmark descriptions
 // @Tags bookmarks
 // @Produce json
+// @Param workspace_id query int false "Workspace ID"
 // @Success 200 {object} model.ExportBookmarksResponse
+// @Failure 400
 // @Failure 401
+// @Failure 403
 // @Failure 500
 // @Security Bearer
 // @Router /v1/api/bookmarks/export [get]
@@ -341,7 +322,14 @@ func (h *Handler) ExportBookmarks(c *gin.Context) {
 		return
 	}
 
-	base64Data, err := h.service.ExportBookmarks(userID)
+	var query model.WorkspaceQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Debug("binding query", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid query parameters"))
+		return
+	}
+
+	base64Data, err := h.service.ExportBookmarks(userID, query.WorkspaceID)
 	if err != nil {
 		log.Error("failed to export bookmarks", "error", err)
 		errors.RespondWithError(c, err)
@@ -359,10 +347,11 @@ func (h *Handler) ExportBookmarks(c *gin.Context) {
 // @Tags bookmarks
 // @Accept json
 // @Produce json
-// @Param importRequest body model.ImportBookmarksRequest true "Import data"
+// @Param importRequest body model.ImportBookmarksV2Request true "Import data"
 // @Success 200 {array} model.BookmarkResponse
 // @Failure 400
 // @Failure 401
+// @Failure 403
 // @Failure 500
 // @Security Bearer
 // @Router /v2/api/bookmarks/import [POST]
@@ -384,7 +373,7 @@ func (h *Handler) ImportBookmarksV2(c *gin.Context) {
 		return
 	}
 
-	bookmarks, err := h.service.ImportBookmarksV2(userID, req.Bookmarks)
+	bookmarks, err := h.service.ImportBookmarksV2(userID, req.WorkspaceID, req.Bookmarks)
 	if err != nil {
 		log.Error("failed to import bookmarks", "error", err)
 		errors.RespondWithError(c, err)
@@ -396,11 +385,14 @@ func (h *Handler) ImportBookmarksV2(c *gin.Context) {
 }
 
 // @Summary Export Bookmarks V2
-// @Description Export all user's bookmarks as JSON
+// @Description Export all user's personal bookmarks, or bookmarks of a workspace, as JSON together with their comments
 // @Tags bookmarks
 // @Produce json
-// @Success 200 {array} model.BookmarkResponse
+// @Param workspace_id query int false "Workspace ID"
+// @Success 200 {array} model.ExportedBookmark
+// @Failure 400
 // @Failure 401
+// @Failure 403
 // @Failure 500
 // @Security Bearer
 // @Router /v2/api/bookmarks/export [get]
@@ -415,7 +407,14 @@ func (h *Handler) ExportBookmarksV2(c *gin.Context) {
 		return
 	}
 
-	bookmarks, err := h.service.ExportBookmarksV2(userID)
+	var query model.WorkspaceQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Debug("binding query", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid query parameters"))
+		return
+	}
+
+	bookmarks, err := h.service.ExportBookmarksV2(userID, query.WorkspaceID)
 	if err != nil {
 		log.Error("failed to export bookmarks", "error", err)
 		errors.RespondWithError(c, err)
@@ -427,3 +426,1019 @@ func (h *Handler) ExportBookmarksV2(c *gin.Context) {
 	log.Debug("bookmarks exported successfully", "user_id", userID, "count", len(bookmarks))
 	errors.RespondWithSuccess(c, bookmarks)
 }
+
+// @Summary Get Bookmark Preview
+// @Description Get the preview thumbnail of a bookmark by its public token
+// @Tags bookmarks
+// @Produce jpeg
+// @Param token path string true "Preview token"
+// @Success 200 {file} binary
+// @Failure 404
+// @Failure 500
+// @Router /v1/previews/{token} [get]
+func (h *Handler) GetBookmarkPreview(c *gin.Context) {
+	const op = "handler.GetBookmarkPreview"
+	log := h.log.With("op", op)
+
+	token := c.Param("token")
+	if token == "" {
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Preview token is required"))
+		return
+	}
+
+	preview, err := h.service.GetBookmarkPreview(token)
+	if err != nil {
+		log.Debug("failed to get bookmark preview", "error", err)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	// Токен меняется при каждом обновлении превью, поэтому ответ можно кэшировать навсегда
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Data(http.StatusOK, preview.ContentType, preview.Data)
+}
+
+// @Summary Get Bookmarks Health
+// @Description Get checked bookmarks with broken or redirected links
+// @Tags bookmarks
+// @Produce json
+// @Param status query string false "Link status: broken, redirected, unknown, ok or all (default broken and redirected)"
+// @Param min_failures query int false "Minimum number of consecutive failed checks"
+// @Param workspace_id query int false "Workspace ID: list bookmarks of the workspace instead of personal ones"
+// @Success 200 {array} model.BookmarkHealthResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/health [get]
+func (h *Handler) GetBookmarksHealth(c *gin.Context) {
+	const op = "handler.GetBookmarksHealth"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	var filter model.BookmarkHealthFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		log.Debug("binding query", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid query parameters"))
+		return
+	}
+
+	bookmarks, err := h.service.GetBookmarksHealth(userID, &filter)
+	if err != nil {
+		log.Error("failed to get bookmarks health", "error", err)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	responses := make([]model.BookmarkHealthResponse, len(bookmarks))
+	for i := range bookmarks {
+		responses[i] = model.NewBookmarkHealthResponse(&bookmarks[i])
+	}
+
+	errors.RespondWithSuccess(c, responses)
+}
+
+// @Summary Check All Bookmarks
+// @Description Queue all bookmarks of the user for a link check
+// @Tags bookmarks
+// @Produce json
+// @Success 200 {object} errors.Response
+// @Failure 401
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/health/check [post]
+func (h *Handler) ScheduleBookmarksCheck(c *gin.Context) {
+	const op = "handler.ScheduleBookmarksCheck"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	count, err := h.service.ScheduleBookmarksCheck(userID)
+	if err != nil {
+		log.Error("failed to schedule bookmarks check", "error", err)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, gin.H{"queued": count})
+}
+
+// @Summary Check Bookmark
+// @Description Check the bookmark link right now. Only the bookmark author can run the check
+// @Tags bookmarks
+// @Produce json
+// @Param id path int true "Bookmark ID"
+// @Success 200 {object} model.BookmarkHealthResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/{id}/check [post]
+func (h *Handler) CheckBookmark(c *gin.Context) {
+	const op = "handler.CheckBookmark"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	bookmarkIDStr := c.Param("id")
+	bookmarkID, err := strconv.ParseUint(bookmarkIDStr, 10, 32)
+	if err != nil {
+		log.Error("invalid bookmark ID", "error", err, "bookmark_id", bookmarkIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid bookmark ID"))
+		return
+	}
+
+	bookmark, err := h.service.CheckBookmarkNow(userID, uint(bookmarkID))
+	if err != nil {
+		log.Error("failed to check bookmark", "error", err, "bookmark_id", bookmarkID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, model.NewBookmarkHealthResponse(bookmark))
+}
+
+// @Summary Get Redirect Suggestions
+// @Description Get bookmarks whose links permanently redirect to a new location
+// @Tags bookmarks
+// @Produce json
+// @Success 200 {array} model.BookmarkHealthResponse
+// @Failure 401
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/redirects [get]
+func (h *Handler) GetRedirectSuggestions(c *gin.Context) {
+	const op = "handler.GetRedirectSuggestions"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	bookmarks, err := h.service.GetRedirectSuggestions(userID)
+	if err != nil {
+		log.Error("failed to get redirect suggestions", "error", err)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	responses := make([]model.BookmarkHealthResponse, len(bookmarks))
+	for i := range bookmarks {
+		responses[i] = model.NewBookmarkHealthResponse(&bookmarks[i])
+	}
+
+	errors.RespondWithSuccess(c, responses)
+}
+
+// @Summary Apply All Redirect Suggestions
+// @Description Replace URLs of all bookmarks with their permanent redirect destinations
+// @Tags bookmarks
+// @Produce json
+// @Success 200 {object} model.URLRewriteReport
+// @Failure 401
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/redirects/apply [post]
+func (h *Handler) ApplyAllRedirectSuggestions(c *gin.Context) {
+	const op = "handler.ApplyAllRedirectSuggestions"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	report, err := h.service.ApplyAllRedirectSuggestions(userID)
+	if err != nil {
+		log.Error("failed to apply redirect suggestions", "error", err)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, report)
+}
+
+// @Summary Apply Redirect Suggestion
+// @Description Replace the bookmark URL with its permanent redirect destination. Only the bookmark author can apply it
+// @Tags bookmarks
+// @Produce json
+// @Param id path int true "Bookmark ID"
+// @Success 200 {object} model.URLRewrite
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/{id}/redirect [post]
+func (h *Handler) ApplyRedirectSuggestion(c *gin.Context) {
+	const op = "handler.ApplyRedirectSuggestion"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	bookmarkIDStr := c.Param("id")
+	bookmarkID, err := strconv.ParseUint(bookmarkIDStr, 10, 32)
+	if err != nil {
+		log.Error("invalid bookmark ID", "error", err, "bookmark_id", bookmarkIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid bookmark ID"))
+		return
+	}
+
+	rewrite, err := h.service.ApplyRedirectSuggestion(userID, uint(bookmarkID))
+	if err != nil {
+		log.Error("failed to apply redirect suggestion", "error", err, "bookmark_id", bookmarkID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, rewrite)
+}
+
+// @Summary Get URL Rewrites
+// @Description Get the report of bookmark URLs replaced because of permanent redirects
+// @Tags bookmarks
+// @Produce json
+// @Param days query int false "Report period in days (default 30)"
+// @Success 200 {object} model.URLRewriteReport
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/rewrites [get]
+func (h *Handler) GetURLRewrites(c *gin.Context) {
+	const op = "handler.GetURLRewrites"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	days := 0
+	if daysStr := c.Query("days"); daysStr != "" {
+		var err error
+		days, err = strconv.Atoi(daysStr)
+		if err != nil || days <= 0 {
+			errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid days parameter"))
+			return
+		}
+	}
+
+	report, err := h.service.GetURLRewriteReport(userID, days)
+	if err != nil {
+		log.Error("failed to get URL rewrites", "error", err)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, report)
+}
+
+// @Summary Undo URL Rewrite
+// @Description Restore the bookmark URL that was replaced because of a permanent redirect
+// @Tags bookmarks
+// @Produce json
+// @Param id path int true "Rewrite ID"
+// @Success 200 {object} model.URLRewrite
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 409
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/rewrites/{id}/undo [post]
+func (h *Handler) UndoURLRewrite(c *gin.Context) {
+	const op = "handler.UndoURLRewrite"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	rewriteIDStr := c.Param("id")
+	rewriteID, err := strconv.ParseUint(rewriteIDStr, 10, 32)
+	if err != nil {
+		log.Error("invalid rewrite ID", "error", err, "rewrite_id", rewriteIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid rewrite ID"))
+		return
+	}
+
+	rewrite, err := h.service.UndoURLRewrite(userID, uint(rewriteID))
+	if err != nil {
+		log.Error("failed to undo URL rewrite", "error", err, "rewrite_id", rewriteID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, rewrite)
+}
+
+// archiveCSP запрещает снимку выполнять скрипты и загружать что-либо, кроме встроенных ресурсов
+const archiveCSP = "sandbox; default-src 'none'; img-src data:; style-src 'unsafe-inline' data:; font-src data:"
+
+// @Summary Get Bookmark Archive
+// @Description Get the offline HTML snapshot of the bookmarked page
+// @Tags bookmarks
+// @Produce html
+// @Param id path int true "Bookmark ID"
+// @Success 200 {file} binary
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/{id}/archive [get]
+func (h *Handler) GetBookmarkArchive(c *gin.Context) {
+	const op = "handler.GetBookmarkArchive"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	bookmarkIDStr := c.Param("id")
+	bookmarkID, err := strconv.ParseUint(bookmarkIDStr, 10, 32)
+	if err != nil {
+		log.Error("invalid bookmark ID", "error", err, "bookmark_id", bookmarkIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid bookmark ID"))
+		return
+	}
+
+	archive, data, err := h.service.GetBookmarkArchive(userID, uint(bookmarkID))
+	if err != nil {
+		log.Debug("failed to get bookmark archive", "error", err, "bookmark_id", bookmarkID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	// Снимок — чужая страница, поэтому отдаём её в песочнице без доступа к нашему origin
+	c.Header("Content-Security-Policy", archiveCSP)
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Referrer-Policy", "no-referrer")
+	c.Header("Cache-Control", "private, no-cache")
+	c.Data(http.StatusOK, archive.ContentType, data)
+}
+
+// @Summary Archive Bookmark
+// @Description Take a new offline snapshot of the bookmarked page, replacing the previous one
+// @Tags bookmarks
+// @Produce json
+// @Param id path int true "Bookmark ID"
+// @Success 200 {object} model.BookmarkArchive
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/{id}/archive [post]
+func (h *Handler) ArchiveBookmark(c *gin.Context) {
+	const op = "handler.ArchiveBookmark"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	bookmarkIDStr := c.Param("id")
+	bookmarkID, err := strconv.ParseUint(bookmarkIDStr, 10, 32)
+	if err != nil {
+		log.Error("invalid bookmark ID", "error", err, "bookmark_id", bookmarkIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid bookmark ID"))
+		return
+	}
+
+	archive, err := h.service.ArchiveBookmark(userID, uint(bookmarkID))
+	if err != nil {
+		log.Debug("failed to archive bookmark", "error", err, "bookmark_id", bookmarkID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, archive)
+}
+
+// @Summary Delete Bookmark Archive
+// @Description Delete the offline snapshot of the bookmarked page and free the storage
+// @Tags bookmarks
+// @Produce json
+// @Param id path int true "Bookmark ID"
+// @Success 200 {object} errors.Response
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/{id}/archive [delete]
+func (h *Handler) DeleteBookmarkArchive(c *gin.Context) {
+	const op = "handler.DeleteBookmarkArchive"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	bookmarkIDStr := c.Param("id")
+	bookmarkID, err := strconv.ParseUint(bookmarkIDStr, 10, 32)
+	if err != nil {
+		log.Error("invalid bookmark ID", "error", err, "bookmark_id", bookmarkIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid bookmark ID"))
+		return
+	}
+
+	if err := h.service.DeleteBookmarkArchive(userID, uint(bookmarkID)); err != nil {
+		log.Error("failed to delete bookmark archive", "error", err, "bookmark_id", bookmarkID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, "Archive deleted successfully")
+}
+
+// @Summary Get Reader View
+// @Description Get the main article text extracted from the bookmarked page
+// @Tags bookmarks
+// @Produce json
+// @Param id path int true "Bookmark ID"
+// @Success 200 {object} model.ReaderViewResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/{id}/reader [get]
+func (h *Handler) GetReaderView(c *gin.Context) {
+	const op = "handler.GetReaderView"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	bookmarkIDStr := c.Param("id")
+	bookmarkID, err := strconv.ParseUint(bookmarkIDStr, 10, 32)
+	if err != nil {
+		log.Error("invalid bookmark ID", "error", err, "bookmark_id", bookmarkIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid bookmark ID"))
+		return
+	}
+
+	bookmark, content, err := h.service.GetReaderView(userID, uint(bookmarkID))
+	if err != nil {
+		log.Debug("failed to get reader view", "error", err, "bookmark_id", bookmarkID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, model.NewReaderViewResponse(bookmark, content))
+}
+
+// @Summary Search Bookmarks
+// @Description Search bookmarks by title, URL, description and extracted article text
+// @Tags bookmarks
+// @Produce json
+// @Param q query string true "Search query"
+// @Param limit query int false "Maximum number of results (default 50, max 200)"
+// @Param workspace_id query int false "Workspace ID: search bookmarks of the workspace instead of personal ones"
+// @Success 200 {array} model.BookmarkResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/search [get]
+func (h *Handler) SearchBookmarks(c *gin.Context) {
+	const op = "handler.SearchBookmarks"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	var req model.SearchBookmarksRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Debug("binding query", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Search query is required"))
+		return
+	}
+
+	bookmarks, err := h.service.SearchBookmarks(userID, &req)
+	if err != nil {
+		log.Error("failed to search bookmarks", "error", err)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	bookmarkResponses := make([]model.BookmarkResponse, len(bookmarks))
+	for i := range bookmarks {
+		bookmarkResponses[i] = model.NewBookmarkResponse(&bookmarks[i])
+	}
+
+	errors.RespondWithSuccess(c, bookmarkResponses)
+}
+
+// @Summary Update Bookmark State
+// @Description Move a bookmark between unread, read and archived and/or mark it as favorite. Only the bookmark author can change its state
+// @Tags bookmarks
+// @Accept json
+// @Produce json
+// @Param id path int true "Bookmark ID"
+// @Param stateRequest body model.UpdateBookmarkStateRequest true "New state and/or favorite flag"
+// @Success 200 {object} model.BookmarkResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/{id}/state [patch]
+func (h *Handler) UpdateBookmarkState(c *gin.Context) {
+	const op = "handler.UpdateBookmarkState"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	bookmarkIDStr := c.Param("id")
+	bookmarkID, err := strconv.ParseUint(bookmarkIDStr, 10, 32)
+	if err != nil {
+		log.Error("invalid bookmark ID", "error", err, "bookmark_id", bookmarkIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid bookmark ID"))
+		return
+	}
+
+	var req model.UpdateBookmarkStateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid request format"))
+		return
+	}
+
+	bookmark, err := h.service.UpdateBookmarkState(userID, uint(bookmarkID), &req)
+	if err != nil {
+		log.Debug("failed to update bookmark state", "error", err, "bookmark_id", bookmarkID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, model.NewBookmarkResponse(bookmark))
+}
+
+// @Summary Save Reading Progress
+// @Description Save how much of the article has been read (0 to 1) so that reading can be resumed. Finishing an unread bookmark marks it as read. Only the bookmark author can save progress
+// @Tags bookmarks
+// @Accept json
+// @Produce json
+// @Param id path int true "Bookmark ID"
+// @Param progressRequest body model.ReadingProgressRequest true "Reading progress"
+// @Success 200 {object} model.BookmarkResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/{id}/progress [put]
+func (h *Handler) UpdateReadingProgress(c *gin.Context) {
+	const op = "handler.UpdateReadingProgress"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	bookmarkIDStr := c.Param("id")
+	bookmarkID, err := strconv.ParseUint(bookmarkIDStr, 10, 32)
+	if err != nil {
+		log.Error("invalid bookmark ID", "error", err, "bookmark_id", bookmarkIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid bookmark ID"))
+		return
+	}
+
+	var req model.ReadingProgressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Progress must be a number between 0 and 1"))
+		return
+	}
+
+	bookmark, err := h.service.UpdateReadingProgress(userID, uint(bookmarkID), *req.Progress)
+	if err != nil {
+		log.Debug("failed to update reading progress", "error", err, "bookmark_id", bookmarkID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, model.NewBookmarkResponse(bookmark))
+}
+
+// @Summary Set Bookmark Reminder
+// @Description Schedule an email reminder about a bookmark. When it is due, the bookmark returns to the inbox
+// @Tags bookmarks
+// @Accept json
+// @Produce json
+// @Param id path int true "Bookmark ID"
+// @Param reminderRequest body model.ReminderRequest true "Reminder time"
+// @Success 200 {object} model.BookmarkResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/{id}/reminder [put]
+func (h *Handler) SetBookmarkReminder(c *gin.Context) {
+	const op = "handler.SetBookmarkReminder"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	bookmarkIDStr := c.Param("id")
+	bookmarkID, err := strconv.ParseUint(bookmarkIDStr, 10, 32)
+	if err != nil {
+		log.Error("invalid bookmark ID", "error", err, "bookmark_id", bookmarkIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid bookmark ID"))
+		return
+	}
+
+	var req model.ReminderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Time is required"))
+		return
+	}
+
+	bookmark, err := h.service.SetBookmarkReminder(userID, uint(bookmarkID), req.At)
+	if err != nil {
+		log.Debug("failed to set bookmark reminder", "error", err, "bookmark_id", bookmarkID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, model.NewBookmarkResponse(bookmark))
+}
+
+// @Summary Clear Bookmark Reminder
+// @Description Cancel the reminder of a bookmark
+// @Tags bookmarks
+// @Produce json
+// @Param id path int true "Bookmark ID"
+// @Success 200 {object} model.BookmarkResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/{id}/reminder [delete]
+func (h *Handler) ClearBookmarkReminder(c *gin.Context) {
+	const op = "handler.ClearBookmarkReminder"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	bookmarkIDStr := c.Param("id")
+	bookmarkID, err := strconv.ParseUint(bookmarkIDStr, 10, 32)
+	if err != nil {
+		log.Error("invalid bookmark ID", "error", err, "bookmark_id", bookmarkIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid bookmark ID"))
+		return
+	}
+
+	bookmark, err := h.service.ClearBookmarkReminder(userID, uint(bookmarkID))
+	if err != nil {
+		log.Debug("failed to clear bookmark reminder", "error", err, "bookmark_id", bookmarkID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, model.NewBookmarkResponse(bookmark))
+}
+
+// @Summary Snooze Bookmark
+// @Description Hide a bookmark from the inbox until the given time. When it is due, the bookmark returns to the inbox and an email is sent
+// @Tags bookmarks
+// @Accept json
+// @Produce json
+// @Param id path int true "Bookmark ID"
+// @Param reminderRequest body model.ReminderRequest true "Time when the bookmark returns to the inbox"
+// @Success 200 {object} model.BookmarkResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/{id}/snooze [put]
+func (h *Handler) SnoozeBookmark(c *gin.Context) {
+	const op = "handler.SnoozeBookmark"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	bookmarkIDStr := c.Param("id")
+	bookmarkID, err := strconv.ParseUint(bookmarkIDStr, 10, 32)
+	if err != nil {
+		log.Error("invalid bookmark ID", "error", err, "bookmark_id", bookmarkIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid bookmark ID"))
+		return
+	}
+
+	var req model.ReminderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Time is required"))
+		return
+	}
+
+	bookmark, err := h.service.SnoozeBookmark(userID, uint(bookmarkID), req.At)
+	if err != nil {
+		log.Debug("failed to snooze bookmark", "error", err, "bookmark_id", bookmarkID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, model.NewBookmarkResponse(bookmark))
+}
+
+// @Summary Unsnooze Bookmark
+// @Description Return a snoozed bookmark to the inbox right away
+// @Tags bookmarks
+// @Produce json
+// @Param id path int true "Bookmark ID"
+// @Success 200 {object} model.BookmarkResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/{id}/snooze [delete]
+func (h *Handler) UnsnoozeBookmark(c *gin.Context) {
+	const op = "handler.UnsnoozeBookmark"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	bookmarkIDStr := c.Param("id")
+	bookmarkID, err := strconv.ParseUint(bookmarkIDStr, 10, 32)
+	if err != nil {
+		log.Error("invalid bookmark ID", "error", err, "bookmark_id", bookmarkIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid bookmark ID"))
+		return
+	}
+
+	bookmark, err := h.service.UnsnoozeBookmark(userID, uint(bookmarkID))
+	if err != nil {
+		log.Debug("failed to unsnooze bookmark", "error", err, "bookmark_id", bookmarkID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, model.NewBookmarkResponse(bookmark))
+}
+
+// @Summary Go To Bookmark
+// @Description Redirect to the bookmark URL and count the visit when the author opens it and hasn't turned tracking off. Other members are only redirected. Requires authorization or the signed link from go_url
+// @Tags bookmarks
+// @Param id path int true "Bookmark ID"
+// @Param sig query string false "Link signature from go_url"
+// @Success 302
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Router /v1/go/{id} [get]
+func (h *Handler) GoToBookmark(c *gin.Context) {
+	const op = "handler.GoToBookmark"
+	log := h.log.With("op", op)
+
+	bookmarkIDStr := c.Param("id")
+	bookmarkID, err := strconv.ParseUint(bookmarkIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid bookmark ID", "error", err, "bookmark_id", bookmarkIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid bookmark ID"))
+		return
+	}
+
+	target, err := h.service.VisitBookmark(c.GetUint("userID"), uint(bookmarkID), c.Query("sig"))
+	if err != nil {
+		log.Debug("failed to open bookmark", "error", err, "bookmark_id", bookmarkID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	// Каждый переход должен доходить до сервера, иначе посещение не будет учтено
+	c.Header("Cache-Control", "no-store")
+	c.Header("Referrer-Policy", "no-referrer")
+	c.Redirect(http.StatusFound, target)
+}
+
+// @Summary Get Bookmark Usage
+// @Description Get the most visited bookmarks of the authenticated user
+// @Tags bookmarks
+// @Produce json
+// @Param limit query int false "Maximum number of results (default 50, max 200)"
+// @Success 200 {array} model.BookmarkUsageResponse
+// @Failure 401
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/stats [get]
+func (h *Handler) GetBookmarkUsage(c *gin.Context) {
+	const op = "handler.GetBookmarkUsage"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	var filter model.UsageStatsFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		log.Debug("binding query", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid limit"))
+		return
+	}
+
+	bookmarks, err := h.service.GetBookmarkUsage(userID, &filter)
+	if err != nil {
+		log.Error("failed to get bookmark usage", "error", err)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	usage := make([]model.BookmarkUsageResponse, len(bookmarks))
+	for i := range bookmarks {
+		usage[i] = model.BookmarkUsageResponse{
+			ID:            bookmarks[i].ID,
+			Title:         bookmarks[i].Title,
+			URL:           bookmarks[i].URL,
+			VisitCount:    bookmarks[i].VisitCount,
+			LastVisitedAt: bookmarks[i].LastVisitedAt,
+		}
+	}
+
+	errors.RespondWithSuccess(c, usage)
+}
+
+// @Summary Get Domain Usage
+// @Description Get visit statistics of the authenticated user grouped by site
+// @Tags bookmarks
+// @Produce json
+// @Param limit query int false "Maximum number of results (default 50, max 200)"
+// @Success 200 {array} model.DomainUsageResponse
+// @Failure 401
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/stats/domains [get]
+func (h *Handler) GetDomainUsage(c *gin.Context) {
+	const op = "handler.GetDomainUsage"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	var filter model.UsageStatsFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		log.Debug("binding query", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid limit"))
+		return
+	}
+
+	domains, err := h.service.GetDomainUsage(userID, &filter)
+	if err != nil {
+		log.Error("failed to get domain usage", "error", err)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, domains)
+}
+
+// @Summary Get Top Sites
+// @Description Get the most used bookmarks for new-tab tiles, ranked by frecency: visit frequency weighted by recency decay
+// @Tags bookmarks
+// @Produce json
+// @Param limit query int false "Maximum number of results (default 12, max 100)"
+// @Success 200 {array} model.TopSiteResponse
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/top [get]
+func (h *Handler) GetTopSites(c *gin.Context) {
+	const op = "handler.GetTopSites"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	var filter model.TopSitesFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		log.Debug("binding query", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid limit"))
+		return
+	}
+
+	sites, err := h.service.GetTopSites(userID, filter.Limit)
+	if err != nil {
+		log.Error("failed to get top sites", "error", err)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, sites)
+}
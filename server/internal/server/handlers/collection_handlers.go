@@ -0,0 +1,575 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get Collections
+// @Description Get shared collections the authenticated user belongs to, with the user's role
+// @Tags collections
+// @Produce json
+// @Success 200 {array} model.CollectionResponse
+// @Failure 401
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/collections [get]
+func (h *Handler) GetCollections(c *gin.Context) {
+	const op = "handler.GetCollections"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	collections, err := h.service.GetCollections(userID)
+	if err != nil {
+		log.Error("failed to get collections", "error", err, "user_id", userID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, collections)
+}
+
+// @Summary Create Collection
+// @Description Create a shared collection. The creator becomes its owner
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param collectionRequest body model.CollectionRequest true "Collection"
+// @Success 200 {object} model.CollectionResponse
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/collections [post]
+func (h *Handler) CreateCollection(c *gin.Context) {
+	const op = "handler.CreateCollection"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	var req model.CollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid request format"))
+		return
+	}
+
+	collection, err := h.service.CreateCollection(userID, &req)
+	if err != nil {
+		log.Error("failed to create collection", "error", err, "user_id", userID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, collection)
+}
+
+// @Summary Update Collection
+// @Description Rename a collection or change its description (owners only)
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Param collectionRequest body model.CollectionRequest true "Collection"
+// @Success 200 {object} model.CollectionResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/collections/{id} [put]
+func (h *Handler) UpdateCollection(c *gin.Context) {
+	const op = "handler.UpdateCollection"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	collectionIDStr := c.Param("id")
+	collectionID, err := strconv.ParseUint(collectionIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid collection ID", "error", err, "collection_id", collectionIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid collection ID"))
+		return
+	}
+
+	var req model.CollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid request format"))
+		return
+	}
+
+	collection, err := h.service.UpdateCollection(userID, uint(collectionID), &req)
+	if err != nil {
+		log.Error("failed to update collection", "error", err, "collection_id", collectionID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, collection)
+}
+
+// @Summary Delete Collection
+// @Description Delete a collection (owners only). Its bookmarks stay with the users who added them
+// @Tags collections
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Success 200 {object} errors.Response
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/collections/{id} [delete]
+func (h *Handler) DeleteCollection(c *gin.Context) {
+	const op = "handler.DeleteCollection"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	collectionIDStr := c.Param("id")
+	collectionID, err := strconv.ParseUint(collectionIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid collection ID", "error", err, "collection_id", collectionIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid collection ID"))
+		return
+	}
+
+	if err := h.service.DeleteCollection(userID, uint(collectionID)); err != nil {
+		log.Error("failed to delete collection", "error", err, "collection_id", collectionID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, "Collection deleted successfully")
+}
+
+// @Summary Get Collection Bookmarks
+// @Description Get bookmarks of a collection the authenticated user belongs to
+// @Tags collections
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Success 200 {array} model.BookmarkResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/collections/{id}/bookmarks [get]
+func (h *Handler) GetCollectionBookmarks(c *gin.Context) {
+	const op = "handler.GetCollectionBookmarks"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	collectionIDStr := c.Param("id")
+	collectionID, err := strconv.ParseUint(collectionIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid collection ID", "error", err, "collection_id", collectionIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid collection ID"))
+		return
+	}
+
+	bookmarks, err := h.service.GetCollectionBookmarks(userID, uint(collectionID))
+	if err != nil {
+		log.Error("failed to get collection bookmarks", "error", err, "collection_id", collectionID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	response := make([]model.BookmarkResponse, 0, len(bookmarks))
+	for i := range bookmarks {
+		response = append(response, model.NewBookmarkResponse(&bookmarks[i]))
+	}
+
+	errors.RespondWithSuccess(c, response)
+}
+
+// @Summary Get Collection Members
+// @Description Get members of a collection with their roles. Only usernames are shown, never emails
+// @Tags collections
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Success 200 {array} model.CollectionMemberResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/collections/{id}/members [get]
+func (h *Handler) GetCollectionMembers(c *gin.Context) {
+	const op = "handler.GetCollectionMembers"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	collectionIDStr := c.Param("id")
+	collectionID, err := strconv.ParseUint(collectionIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid collection ID", "error", err, "collection_id", collectionIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid collection ID"))
+		return
+	}
+
+	members, err := h.service.GetCollectionMembers(userID, uint(collectionID))
+	if err != nil {
+		log.Error("failed to get collection members", "error", err, "collection_id", collectionID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, members)
+}
+
+// @Summary Update Collection Member
+// @Description Change the role of a collection member (owners only). A collection always keeps at least one owner
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Param userId path int true "Member user ID"
+// @Param roleRequest body model.CollectionRoleRequest true "Role"
+// @Success 200 {object} model.CollectionMemberResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/collections/{id}/members/{userId} [put]
+func (h *Handler) UpdateCollectionMember(c *gin.Context) {
+	const op = "handler.UpdateCollectionMember"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	collectionIDStr := c.Param("id")
+	collectionID, err := strconv.ParseUint(collectionIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid collection ID", "error", err, "collection_id", collectionIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid collection ID"))
+		return
+	}
+
+	memberIDStr := c.Param("userId")
+	memberID, err := strconv.ParseUint(memberIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid member ID", "error", err, "member_id", memberIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid member ID"))
+		return
+	}
+
+	var req model.CollectionRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid request format"))
+		return
+	}
+
+	member, err := h.service.UpdateCollectionMember(userID, uint(collectionID), uint(memberID), &req)
+	if err != nil {
+		log.Error("failed to update collection member", "error", err, "collection_id", collectionID, "member_id", memberID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, member)
+}
+
+// @Summary Remove Collection Member
+// @Description Remove a member from a collection. Owners can remove anyone, other members can only leave
+// @Tags collections
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Param userId path int true "Member user ID"
+// @Success 200 {object} errors.Response
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/collections/{id}/members/{userId} [delete]
+func (h *Handler) RemoveCollectionMember(c *gin.Context) {
+	const op = "handler.RemoveCollectionMember"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	collectionIDStr := c.Param("id")
+	collectionID, err := strconv.ParseUint(collectionIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid collection ID", "error", err, "collection_id", collectionIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid collection ID"))
+		return
+	}
+
+	memberIDStr := c.Param("userId")
+	memberID, err := strconv.ParseUint(memberIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid member ID", "error", err, "member_id", memberIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid member ID"))
+		return
+	}
+
+	if err := h.service.RemoveCollectionMember(userID, uint(collectionID), uint(memberID)); err != nil {
+		log.Error("failed to remove collection member", "error", err, "collection_id", collectionID, "member_id", memberID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, "Member removed from the collection")
+}
+
+// @Summary Invite To Collection
+// @Description Invite a user to a collection by email (owners only). The invitation is valid for 7 days
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Param inviteRequest body model.CollectionInviteRequest true "Invitation"
+// @Success 200 {object} model.CollectionInvite
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 409
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/collections/{id}/invites [post]
+func (h *Handler) InviteToCollection(c *gin.Context) {
+	const op = "handler.InviteToCollection"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	collectionIDStr := c.Param("id")
+	collectionID, err := strconv.ParseUint(collectionIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid collection ID", "error", err, "collection_id", collectionIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid collection ID"))
+		return
+	}
+
+	var req model.CollectionInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid request format"))
+		return
+	}
+
+	invite, err := h.service.InviteToCollection(userID, uint(collectionID), &req)
+	if err != nil {
+		log.Error("failed to invite to collection", "error", err, "collection_id", collectionID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, invite)
+}
+
+// @Summary Get Collection Invites
+// @Description Get pending invitations of a collection (owners only)
+// @Tags collections
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Success 200 {array} model.CollectionInvite
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/collections/{id}/invites [get]
+func (h *Handler) GetCollectionInvites(c *gin.Context) {
+	const op = "handler.GetCollectionInvites"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	collectionIDStr := c.Param("id")
+	collectionID, err := strconv.ParseUint(collectionIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid collection ID", "error", err, "collection_id", collectionIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid collection ID"))
+		return
+	}
+
+	invites, err := h.service.GetCollectionInvites(userID, uint(collectionID))
+	if err != nil {
+		log.Error("failed to get collection invites", "error", err, "collection_id", collectionID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, invites)
+}
+
+// @Summary Revoke Collection Invite
+// @Description Revoke a pending invitation (owners only)
+// @Tags collections
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Param inviteId path int true "Invitation ID"
+// @Success 200 {object} errors.Response
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/collections/{id}/invites/{inviteId} [delete]
+func (h *Handler) RevokeCollectionInvite(c *gin.Context) {
+	const op = "handler.RevokeCollectionInvite"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	collectionIDStr := c.Param("id")
+	collectionID, err := strconv.ParseUint(collectionIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid collection ID", "error", err, "collection_id", collectionIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid collection ID"))
+		return
+	}
+
+	inviteIDStr := c.Param("inviteId")
+	inviteID, err := strconv.ParseUint(inviteIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid invitation ID", "error", err, "invite_id", inviteIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid invitation ID"))
+		return
+	}
+
+	if err := h.service.RevokeCollectionInvite(userID, uint(collectionID), uint(inviteID)); err != nil {
+		log.Error("failed to revoke collection invite", "error", err, "invite_id", inviteID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, "Invitation revoked")
+}
+
+// @Summary Accept Collection Invite
+// @Description Join a collection with the token from the invitation email. The account email must match the invited address
+// @Tags collections
+// @Produce json
+// @Param token path string true "Invitation token"
+// @Success 200 {object} model.CollectionResponse
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/collections/invites/{token}/accept [post]
+func (h *Handler) AcceptCollectionInvite(c *gin.Context) {
+	const op = "handler.AcceptCollectionInvite"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	collection, err := h.service.AcceptCollectionInvite(userID, c.Param("token"))
+	if err != nil {
+		log.Error("failed to accept collection invite", "error", err, "user_id", userID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, collection)
+}
+
+// @Summary Get Public Collection
+// @Description Get a public collection with its bookmarks. Works without authorization; private collections respond with 404
+// @Tags collections
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Success 200 {object} model.PublicCollectionResponse
+// @Failure 400
+// @Failure 404
+// @Failure 500
+// @Router /v1/collections/{id} [get]
+func (h *Handler) GetPublicCollection(c *gin.Context) {
+	const op = "handler.GetPublicCollection"
+	log := h.log.With("op", op)
+
+	collectionIDStr := c.Param("id")
+	collectionID, err := strconv.ParseUint(collectionIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid collection ID", "error", err, "collection_id", collectionIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid collection ID"))
+		return
+	}
+
+	collection, err := h.service.GetPublicCollection(uint(collectionID))
+	if err != nil {
+		log.Debug("failed to get public collection", "error", err, "collection_id", collectionID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=60")
+	errors.RespondWithSuccess(c, collection)
+}
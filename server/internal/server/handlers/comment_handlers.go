@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get Comments
+// @Description Get comment threads of a bookmark. Comments are visible to everyone who can view the bookmark
+// @Tags comments
+// @Produce json
+// @Param id path int true "Bookmark ID"
+// @Success 200 {array} model.CommentResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/{id}/comments [get]
+func (h *Handler) GetComments(c *gin.Context) {
+	const op = "handler.GetComments"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	bookmarkIDStr := c.Param("id")
+	bookmarkID, err := strconv.ParseUint(bookmarkIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid bookmark ID", "error", err, "bookmark_id", bookmarkIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid bookmark ID"))
+		return
+	}
+
+	comments, err := h.service.GetComments(userID, uint(bookmarkID))
+	if err != nil {
+		log.Error("failed to get comments", "error", err, "bookmark_id", bookmarkID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, comments)
+}
+
+// @Summary Create Comment
+// @Description Comment on a bookmark or reply to a comment with parent_id. Users mentioned as @username who can view the bookmark are notified by email
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param id path int true "Bookmark ID"
+// @Param commentRequest body model.CommentRequest true "Comment"
+// @Success 200 {object} model.CommentResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/{id}/comments [post]
+func (h *Handler) CreateComment(c *gin.Context) {
+	const op = "handler.CreateComment"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	bookmarkIDStr := c.Param("id")
+	bookmarkID, err := strconv.ParseUint(bookmarkIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid bookmark ID", "error", err, "bookmark_id", bookmarkIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid bookmark ID"))
+		return
+	}
+
+	var req model.CommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid request format"))
+		return
+	}
+
+	comment, err := h.service.CreateComment(userID, uint(bookmarkID), &req)
+	if err != nil {
+		log.Error("failed to create comment", "error", err, "bookmark_id", bookmarkID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, comment)
+}
+
+// @Summary Update Comment
+// @Description Edit own comment. Only users mentioned for the first time are notified
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param id path int true "Bookmark ID"
+// @Param commentId path int true "Comment ID"
+// @Param commentRequest body model.UpdateCommentRequest true "Comment"
+// @Success 200 {object} model.CommentResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/{id}/comments/{commentId} [put]
+func (h *Handler) UpdateComment(c *gin.Context) {
+	const op = "handler.UpdateComment"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	bookmarkIDStr := c.Param("id")
+	bookmarkID, err := strconv.ParseUint(bookmarkIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid bookmark ID", "error", err, "bookmark_id", bookmarkIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid bookmark ID"))
+		return
+	}
+
+	commentIDStr := c.Param("commentId")
+	commentID, err := strconv.ParseUint(commentIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid comment ID", "error", err, "comment_id", commentIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid comment ID"))
+		return
+	}
+
+	var req model.UpdateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid request format"))
+		return
+	}
+
+	comment, err := h.service.UpdateComment(userID, uint(bookmarkID), uint(commentID), &req)
+	if err != nil {
+		log.Error("failed to update comment", "error", err, "comment_id", commentID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, comment)
+}
+
+// @Summary Delete Comment
+// @Description Delete a comment. Besides its author, anyone allowed to delete the bookmark can delete it. A comment with replies stays in the thread without its text
+// @Tags comments
+// @Produce json
+// @Param id path int true "Bookmark ID"
+// @Param commentId path int true "Comment ID"
+// @Success 200 {object} errors.Response
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/{id}/comments/{commentId} [delete]
+func (h *Handler) DeleteComment(c *gin.Context) {
+	const op = "handler.DeleteComment"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	bookmarkIDStr := c.Param("id")
+	bookmarkID, err := strconv.ParseUint(bookmarkIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid bookmark ID", "error", err, "bookmark_id", bookmarkIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid bookmark ID"))
+		return
+	}
+
+	commentIDStr := c.Param("commentId")
+	commentID, err := strconv.ParseUint(commentIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid comment ID", "error", err, "comment_id", commentIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid comment ID"))
+		return
+	}
+
+	if err := h.service.DeleteComment(userID, uint(bookmarkID), uint(commentID)); err != nil {
+		log.Error("failed to delete comment", "error", err, "comment_id", commentID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, "Comment deleted successfully")
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+	"github.com/aerscs/theca-public/internal/utils/feed"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get bookmark feed URLs
+// @Description Get secret RSS and Atom subscription URLs for the whole account, a folder with its subfolders or a tag
+// @Tags feeds
+// @Produce json
+// @Param folder_id query int false "Folder ID"
+// @Param tag query string false "Tag"
+// @Success 200 {object} model.FeedURLsResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/user/me/feeds [get]
+func (h *Handler) GetFeedURLs(c *gin.Context) {
+	h.respondFeedURLs(c, "handler.GetFeedURLs", false)
+}
+
+// @Summary Rotate bookmark feed URLs
+// @Description Issue a new feed token. The token is shared with the calendar feed, so previous feed and calendar URLs stop working
+// @Tags feeds
+// @Produce json
+// @Param folder_id query int false "Folder ID"
+// @Param tag query string false "Tag"
+// @Success 200 {object} model.FeedURLsResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/user/me/feeds/rotate [post]
+func (h *Handler) RotateFeedURLs(c *gin.Context) {
+	h.respondFeedURLs(c, "handler.RotateFeedURLs", true)
+}
+
+func (h *Handler) respondFeedURLs(c *gin.Context, op string, rotate bool) {
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	var query model.FeedQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Debug("binding query", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid query parameters"))
+		return
+	}
+
+	urls, err := h.service.GetFeedURLs(userID, &query, rotate)
+	if err != nil {
+		log.Error("failed to get feed URLs", "error", err, "user_id", userID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, urls)
+}
+
+// @Summary Get bookmark feed
+// @Description RSS 2.0 or Atom feed of the latest bookmarks of an account, folder or tag, authenticated by the feed token. Supports If-Modified-Since
+// @Tags feeds
+// @Produce xml
+// @Param token path string true "Feed token"
+// @Param format path string true "Feed format" Enums(rss, atom)
+// @Param folder_id query int false "Folder ID"
+// @Param tag query string false "Tag"
+// @Success 200 {string} string
+// @Success 304
+// @Failure 404
+// @Failure 500
+// @Router /v1/feeds/{token}/{format} [get]
+func (h *Handler) GetBookmarkFeed(c *gin.Context) {
+	const op = "handler.GetBookmarkFeed"
+	log := h.log.With("op", op)
+
+	var query model.FeedQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Debug("binding query", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeNotFound, "Feed not found"))
+		return
+	}
+
+	format := c.Param("format")
+	out, err := h.service.GetBookmarkFeed(c.Param("token"), format, &query)
+	if err != nil {
+		log.Debug("failed to get bookmark feed", "error", err)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=300")
+	respondFeed(c, out, format)
+}
+
+// @Summary Get collection feed
+// @Description RSS 2.0 or Atom feed of the latest bookmarks of a public collection. Works without authorization. Supports If-Modified-Since
+// @Tags feeds
+// @Produce xml
+// @Param id path int true "Collection ID"
+// @Param format path string true "Feed format" Enums(rss, atom)
+// @Success 200 {string} string
+// @Success 304
+// @Failure 400
+// @Failure 404
+// @Failure 500
+// @Router /v1/collections/{id}/feed/{format} [get]
+func (h *Handler) GetCollectionFeed(c *gin.Context) {
+	const op = "handler.GetCollectionFeed"
+	log := h.log.With("op", op)
+
+	collectionIDStr := c.Param("id")
+	collectionID, err := strconv.ParseUint(collectionIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid collection ID", "error", err, "collection_id", collectionIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid collection ID"))
+		return
+	}
+
+	format := c.Param("format")
+	out, err := h.service.GetCollectionFeed(uint(collectionID), format)
+	if err != nil {
+		log.Debug("failed to get collection feed", "error", err, "collection_id", collectionID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	respondFeed(c, out, format)
+}
+
+// respondFeed отдаёт ленту в нужном формате или 304, если с If-Modified-Since она не менялась
+func respondFeed(c *gin.Context, out *feed.Feed, format string) {
+	lastModified := out.Updated.UTC().Truncate(time.Second)
+	c.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+	if since, err := http.ParseTime(c.GetHeader("If-Modified-Since")); err == nil && !lastModified.After(since) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	if format == model.FeedAtom {
+		c.Data(http.StatusOK, "application/atom+xml; charset=utf-8", feed.Atom(out))
+		return
+	}
+	c.Data(http.StatusOK, "application/rss+xml; charset=utf-8", feed.RSS(out))
+}
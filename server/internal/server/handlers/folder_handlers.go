@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get Folders
+// @Description Get personal folders of the authenticated user, or folders of a workspace the user belongs to
+// @Tags folders
+// @Produce json
+// @Param workspace_id query int false "Workspace ID"
+// @Success 200 {array} model.Folder
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/folders [get]
+func (h *Handler) GetFolders(c *gin.Context) {
+	const op = "handler.GetFolders"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	var query model.WorkspaceQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Debug("binding query", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid query parameters"))
+		return
+	}
+
+	folders, err := h.service.GetFolders(userID, query.WorkspaceID)
+	if err != nil {
+		log.Error("failed to get folders", "error", err, "user_id", userID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, folders)
+}
+
+// @Summary Create Folder
+// @Description Create a bookmark folder, optionally inside another folder
+// @Tags folders
+// @Accept json
+// @Produce json
+// @Param folderRequest body model.FolderRequest true "Folder"
+// @Success 200 {object} model.Folder
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/folders [post]
+func (h *Handler) CreateFolder(c *gin.Context) {
+	const op = "handler.CreateFolder"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	var req model.FolderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid request format"))
+		return
+	}
+
+	folder, err := h.service.CreateFolder(userID, &req)
+	if err != nil {
+		log.Error("failed to create folder", "error", err, "user_id", userID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, folder)
+}
+
+// @Summary Update Folder
+// @Description Rename a folder or move it into another folder
+// @Tags folders
+// @Accept json
+// @Produce json
+// @Param id path int true "Folder ID"
+// @Param folderRequest body model.FolderRequest true "Folder"
+// @Success 200 {object} model.Folder
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/folders/{id} [put]
+func (h *Handler) UpdateFolder(c *gin.Context) {
+	const op = "handler.UpdateFolder"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	folderIDStr := c.Param("id")
+	folderID, err := strconv.ParseUint(folderIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid folder ID", "error", err, "folder_id", folderIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid folder ID"))
+		return
+	}
+
+	var req model.FolderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid request format"))
+		return
+	}
+
+	folder, err := h.service.UpdateFolder(userID, uint(folderID), &req)
+	if err != nil {
+		log.Error("failed to update folder", "error", err, "folder_id", folderID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, folder)
+}
+
+// @Summary Delete Folder
+// @Description Delete a folder. Its bookmarks and subfolders move to the parent folder, share links to it are revoked
+// @Tags folders
+// @Produce json
+// @Param id path int true "Folder ID"
+// @Success 200 {object} errors.Response
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/folders/{id} [delete]
+func (h *Handler) DeleteFolder(c *gin.Context) {
+	const op = "handler.DeleteFolder"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	folderIDStr := c.Param("id")
+	folderID, err := strconv.ParseUint(folderIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid folder ID", "error", err, "folder_id", folderIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid folder ID"))
+		return
+	}
+
+	if err := h.service.DeleteFolder(userID, uint(folderID)); err != nil {
+		log.Error("failed to delete folder", "error", err, "folder_id", folderID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, "Folder deleted successfully")
+}
+
+// @Summary Get Tags
+// @Description Get tags of personal or workspace bookmarks with bookmark counts, most used first
+// @Tags folders
+// @Produce json
+// @Param workspace_id query int false "Workspace ID"
+// @Success 200 {array} model.TagResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/tags [get]
+func (h *Handler) GetTags(c *gin.Context) {
+	const op = "handler.GetTags"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	var query model.WorkspaceQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Debug("binding query", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid query parameters"))
+		return
+	}
+
+	tags, err := h.service.GetTags(userID, query.WorkspaceID)
+	if err != nil {
+		log.Error("failed to get tags", "error", err, "user_id", userID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, tags)
+}
@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"log/slog"
+	"net/http"
+	"strings"
 
 	"github.com/aerscs/theca-public/internal/model"
 	"github.com/aerscs/theca-public/internal/service"
@@ -341,33 +343,160 @@ func (h *Handler) GetSelfUser(c *gin.Context) {
 	errors.RespondWithSuccess(c, user)
 }
 
-// @Summary Get user by ID
-// @Description Get user information
+// @Summary Update user settings
+// @Description Update settings of the authenticated user. Omitted fields are not changed
 // @Tags user
 // @Accept json
 // @Produce json
+// @Param settingsRequest body model.UpdateUserSettingsRequest true "User settings"
 // @Success 200 {object} model.UserResponse
 // @Failure 400
+// @Failure 401
 // @Failure 500
-// @Router /v1/api/user/{id} [get]
-func (h *Handler) GetUser(c *gin.Context) {
-	const op = "handler.getUser"
+// @Security Bearer
+// @Router /v1/api/user/me [patch]
+func (h *Handler) UpdateUserSettings(c *gin.Context) {
+	const op = "handler.UpdateUserSettings"
 	log := h.log.With(slog.String("op", op))
 
-	userID, ok := c.Params.Get("id")
-	if !ok {
-		log.Debug("missing user id")
-		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "user id is required"))
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
 		return
 	}
 
-	user, err := h.service.GetUser(userID)
+	var req model.UpdateUserSettingsRequest
+	if err := c.BindJSON(&req); err != nil {
+		log.Debug("invalid request format", slog.String("error", err.Error()))
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid request format"))
+		return
+	}
+
+	user, err := h.service.UpdateUserSettings(userID, &req)
 	if err != nil {
-		log.Error("failed to get user", "error", err)
+		log.Error("failed to update user settings", "error", err)
 		errors.RespondWithError(c, err)
 		return
 	}
 
-	log.Debug("user retrieved successfully", "user", user)
 	errors.RespondWithSuccess(c, user)
 }
+
+// @Summary Get calendar feed URL
+// @Description Get the iCalendar subscription URL with upcoming reminders and snoozed bookmarks. The URL is secret and works without authorization
+// @Tags user
+// @Produce json
+// @Success 200 {object} model.CalendarFeedResponse
+// @Failure 401
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/user/me/calendar [get]
+func (h *Handler) GetCalendarFeedURL(c *gin.Context) {
+	h.respondCalendarFeedURL(c, "handler.GetCalendarFeedURL", false)
+}
+
+// @Summary Rotate calendar feed URL
+// @Description Issue a new calendar subscription URL. The token is shared with bookmark feeds, so previous calendar and feed URLs stop working
+// @Tags user
+// @Produce json
+// @Success 200 {object} model.CalendarFeedResponse
+// @Failure 401
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/user/me/calendar/rotate [post]
+func (h *Handler) RotateCalendarFeedURL(c *gin.Context) {
+	h.respondCalendarFeedURL(c, "handler.RotateCalendarFeedURL", true)
+}
+
+func (h *Handler) respondCalendarFeedURL(c *gin.Context, op string, rotate bool) {
+	log := h.log.With(slog.String("op", op))
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	url, err := h.service.GetCalendarFeedURL(userID, rotate)
+	if err != nil {
+		log.Error("failed to get calendar feed URL", "error", err)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, model.CalendarFeedResponse{URL: url})
+}
+
+// @Summary Get calendar feed
+// @Description iCalendar feed with upcoming reminders, for subscription from calendar apps
+// @Tags user
+// @Produce plain
+// @Param token path string true "Feed token with optional .ics suffix"
+// @Success 200 {string} string
+// @Failure 404
+// @Failure 500
+// @Router /v1/calendar/{token} [get]
+func (h *Handler) GetCalendarFeed(c *gin.Context) {
+	const op = "handler.GetCalendarFeed"
+	log := h.log.With(slog.String("op", op))
+
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+	feed, err := h.service.GetCalendarFeed(token)
+	if err != nil {
+		log.Debug("failed to get calendar feed", "error", err)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", feed)
+}
+
+// @Summary Unsubscribe from digest
+// @Description Turn off the weekly digest with the token from the email. Supports one-click unsubscribe (RFC 8058)
+// @Tags user
+// @Produce json
+// @Param token path string true "Unsubscribe token"
+// @Success 200 {object} errors.Response
+// @Failure 404
+// @Failure 500
+// @Router /v1/digest/unsubscribe/{token} [get]
+// @Router /v1/digest/unsubscribe/{token} [post]
+func (h *Handler) UnsubscribeDigest(c *gin.Context) {
+	const op = "handler.UnsubscribeDigest"
+	log := h.log.With(slog.String("op", op))
+
+	if err := h.service.UnsubscribeDigest(c.Param("token")); err != nil {
+		log.Debug("failed to unsubscribe from digest", "error", err)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, "You have been unsubscribed from the weekly digest")
+}
+
+// @Summary Get public profile
+// @Description Get the published profile of a user by username: display name, bio, avatar and public collections. Email is never included. Hidden profiles respond with 404
+// @Tags user
+// @Produce json
+// @Param username path string true "Username"
+// @Success 200 {object} model.PublicProfileResponse
+// @Failure 404
+// @Failure 500
+// @Router /v1/profiles/{username} [get]
+func (h *Handler) GetPublicProfile(c *gin.Context) {
+	const op = "handler.GetPublicProfile"
+	log := h.log.With(slog.String("op", op))
+
+	profile, err := h.service.GetPublicProfile(c.Param("username"))
+	if err != nil {
+		log.Debug("failed to get public profile", "error", err)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=60")
+	errors.RespondWithSuccess(c, profile)
+}
@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Send Bookmark
+// @Description Send a copy of a bookmark with an optional note to another user's inbox. The recipient is notified by email
+// @Tags inbox
+// @Accept json
+// @Produce json
+// @Param id path int true "Bookmark ID"
+// @Param sendBookmarkRequest body model.SendBookmarkRequest true "Recipient and note"
+// @Success 200 {object} errors.Response
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 409
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/bookmarks/{id}/send [post]
+func (h *Handler) SendBookmark(c *gin.Context) {
+	const op = "handler.SendBookmark"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	bookmarkIDStr := c.Param("id")
+	bookmarkID, err := strconv.ParseUint(bookmarkIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid bookmark ID", "error", err, "bookmark_id", bookmarkIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid bookmark ID"))
+		return
+	}
+
+	var req model.SendBookmarkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid request format"))
+		return
+	}
+
+	if err := h.service.SendBookmark(userID, uint(bookmarkID), &req); err != nil {
+		log.Error("failed to send bookmark", "error", err, "bookmark_id", bookmarkID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, "Bookmark sent successfully")
+}
+
+// @Summary Get Inbox
+// @Description Get bookmarks other users sent to the current user that are not accepted or dismissed yet
+// @Tags inbox
+// @Produce json
+// @Success 200 {array} model.InboxItemResponse
+// @Failure 401
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/inbox [get]
+func (h *Handler) GetInbox(c *gin.Context) {
+	const op = "handler.GetInbox"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	items, err := h.service.GetInbox(userID)
+	if err != nil {
+		log.Error("failed to get inbox", "error", err)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, items)
+}
+
+// @Summary Accept Inbox Item
+// @Description Save a bookmark from the inbox to personal bookmarks as unread
+// @Tags inbox
+// @Produce json
+// @Param id path int true "Inbox item ID"
+// @Success 200 {object} model.Bookmark
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/inbox/{id}/accept [post]
+func (h *Handler) AcceptInboxItem(c *gin.Context) {
+	const op = "handler.AcceptInboxItem"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	itemIDStr := c.Param("id")
+	itemID, err := strconv.ParseUint(itemIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid inbox item ID", "error", err, "item_id", itemIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid inbox item ID"))
+		return
+	}
+
+	bookmark, err := h.service.AcceptInboxItem(userID, uint(itemID))
+	if err != nil {
+		log.Error("failed to accept inbox item", "error", err, "item_id", itemID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, bookmark)
+}
+
+// @Summary Dismiss Inbox Item
+// @Description Remove a bookmark from the inbox without saving it
+// @Tags inbox
+// @Produce json
+// @Param id path int true "Inbox item ID"
+// @Success 200 {object} errors.Response
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/inbox/{id}/dismiss [post]
+func (h *Handler) DismissInboxItem(c *gin.Context) {
+	const op = "handler.DismissInboxItem"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	itemIDStr := c.Param("id")
+	itemID, err := strconv.ParseUint(itemIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid inbox item ID", "error", err, "item_id", itemIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid inbox item ID"))
+		return
+	}
+
+	if err := h.service.DismissInboxItem(userID, uint(itemID)); err != nil {
+		log.Error("failed to dismiss inbox item", "error", err, "item_id", itemID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, "Inbox item dismissed successfully")
+}
+
+// @Summary Get Blocked Users
+// @Description Get users who are not allowed to send bookmarks to the current user
+// @Tags inbox
+// @Produce json
+// @Success 200 {array} model.BlockedUserResponse
+// @Failure 401
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/user/me/blocked [get]
+func (h *Handler) GetBlockedUsers(c *gin.Context) {
+	const op = "handler.GetBlockedUsers"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	users, err := h.service.GetBlockedUsers(userID)
+	if err != nil {
+		log.Error("failed to get blocked users", "error", err)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, users)
+}
+
+// @Summary Block User
+// @Description Stop receiving bookmarks from a user. Bookmarks the user already sent are dismissed
+// @Tags inbox
+// @Accept json
+// @Produce json
+// @Param blockUserRequest body model.BlockUserRequest true "User to block"
+// @Success 200 {object} model.BlockedUserResponse
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/user/me/blocked [post]
+func (h *Handler) BlockUser(c *gin.Context) {
+	const op = "handler.BlockUser"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	var req model.BlockUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid request format"))
+		return
+	}
+
+	blocked, err := h.service.BlockUser(userID, &req)
+	if err != nil {
+		log.Error("failed to block user", "error", err)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, blocked)
+}
+
+// @Summary Unblock User
+// @Description Allow a blocked user to send bookmarks again
+// @Tags inbox
+// @Produce json
+// @Param userId path int true "Blocked user ID"
+// @Success 200 {object} errors.Response
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/user/me/blocked/{userId} [delete]
+func (h *Handler) UnblockUser(c *gin.Context) {
+	const op = "handler.UnblockUser"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	blockedIDStr := c.Param("userId")
+	blockedID, err := strconv.ParseUint(blockedIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid user ID", "error", err, "user_id", blockedIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid user ID"))
+		return
+	}
+
+	if err := h.service.UnblockUser(userID, uint(blockedID)); err != nil {
+		log.Error("failed to unblock user", "error", err, "blocked_user_id", blockedID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, "User unblocked successfully")
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"strconv"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+	"github.com/gin-gonic/gin"
+)
+
+// sharePasswordHeader заголовок с паролем защищённой общей ссылки для JSON-представления
+const sharePasswordHeader = "X-Share-Password"
+
+// sharePageTemplate шаблон HTML-страницы общей ссылки
+const sharePageTemplate = "templates/sharePage.html"
+
+// sharePage данные HTML-страницы общей ссылки
+type sharePage struct {
+	Collection       *model.SharedCollectionResponse
+	Error            string
+	PasswordRequired bool
+}
+
+// @Summary Create Share Link
+// @Description Create a public read-only link to a folder (with subfolders), a tag or selected bookmarks. The link can expire and be protected with a password
+// @Tags shares
+// @Accept json
+// @Produce json
+// @Param shareRequest body model.CreateShareLinkRequest true "Share link"
+// @Success 200 {object} model.ShareLinkResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/shares [post]
+func (h *Handler) CreateShareLink(c *gin.Context) {
+	const op = "handler.CreateShareLink"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	var req model.CreateShareLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid request format"))
+		return
+	}
+
+	link, err := h.service.CreateShareLink(userID, &req)
+	if err != nil {
+		log.Error("failed to create share link", "error", err, "user_id", userID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, link)
+}
+
+// @Summary Get Share Links
+// @Description Get share links of the authenticated user with view counts
+// @Tags shares
+// @Produce json
+// @Success 200 {array} model.ShareLinkResponse
+// @Failure 401
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/shares [get]
+func (h *Handler) GetShareLinks(c *gin.Context) {
+	const op = "handler.GetShareLinks"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	links, err := h.service.GetShareLinks(userID)
+	if err != nil {
+		log.Error("failed to get share links", "error", err, "user_id", userID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, links)
+}
+
+// @Summary Revoke Share Link
+// @Description Revoke a share link so that it no longer opens
+// @Tags shares
+// @Produce json
+// @Param id path int true "Share link ID"
+// @Success 200 {object} errors.Response
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/shares/{id} [delete]
+func (h *Handler) DeleteShareLink(c *gin.Context) {
+	const op = "handler.DeleteShareLink"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	shareIDStr := c.Param("id")
+	shareID, err := strconv.ParseUint(shareIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid share link ID", "error", err, "share_id", shareIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid share link ID"))
+		return
+	}
+
+	if err := h.service.DeleteShareLink(userID, uint(shareID)); err != nil {
+		log.Error("failed to revoke share link", "error", err, "share_id", shareID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, "Share link revoked")
+}
+
+// @Summary Get Shared Bookmarks
+// @Description Get the read-only contents of a share link. Password-protected links require the X-Share-Password header
+// @Tags shares
+// @Produce json
+// @Param token path string true "Share token"
+// @Param X-Share-Password header string false "Share link password"
+// @Success 200 {object} model.SharedCollectionResponse
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /v1/shares/{token} [get]
+func (h *Handler) GetSharedCollection(c *gin.Context) {
+	const op = "handler.GetSharedCollection"
+	log := h.log.With("op", op)
+
+	collection, err := h.service.GetSharedCollection(c.Param("token"), c.GetHeader(sharePasswordHeader))
+	setShareHeaders(c)
+	if err != nil {
+		log.Debug("failed to get shared collection", "error", err)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, collection)
+}
+
+// @Summary Shared Bookmarks Page
+// @Description Server-rendered read-only page of a share link. Password-protected links show a form that posts the password back
+// @Tags shares
+// @Produce html
+// @Param token path string true "Share token"
+// @Param password formData string false "Share link password"
+// @Success 200 {string} string "HTML page"
+// @Failure 401
+// @Failure 404
+// @Router /v1/s/{token} [get]
+// @Router /v1/s/{token} [post]
+func (h *Handler) GetSharePage(c *gin.Context) {
+	const op = "handler.GetSharePage"
+	log := h.log.With("op", op)
+
+	var page sharePage
+	status := http.StatusOK
+
+	collection, err := h.service.GetSharedCollection(c.Param("token"), c.PostForm("password"))
+	switch {
+	case err == nil:
+		page.Collection = collection
+	case errors.IsErrorCode(err, errors.CodeUnauthorized):
+		status = http.StatusUnauthorized
+		page.PasswordRequired = true
+		if c.Request.Method == http.MethodPost {
+			page.Error = "Wrong password, try again."
+		}
+	case errors.IsErrorCode(err, errors.CodeNotFound):
+		status = http.StatusNotFound
+		page.Error = "This link does not exist, has expired or was revoked."
+	default:
+		log.Error("failed to get shared collection", "error", err)
+		status = http.StatusInternalServerError
+		page.Error = "Something went wrong, please try again later."
+	}
+
+	tmpl, err := template.ParseFiles(sharePageTemplate)
+	if err != nil {
+		log.Error("failed to parse share page template", "error", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInternalError, "Failed to render page"))
+		return
+	}
+
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, page); err != nil {
+		log.Error("failed to render share page", "error", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInternalError, "Failed to render page"))
+		return
+	}
+
+	setShareHeaders(c)
+	c.Header("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; form-action 'self'; frame-ancestors 'none'")
+	c.Data(status, "text/html; charset=utf-8", body.Bytes())
+}
+
+// setShareHeaders запрещает кэширование и индексацию общих ссылок: у них есть счётчик просмотров,
+// и их можно защитить паролем или отозвать
+func setShareHeaders(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Referrer-Policy", "no-referrer")
+	c.Header("X-Robots-Tag", "noindex, nofollow")
+	c.Header("X-Content-Type-Options", "nosniff")
+}
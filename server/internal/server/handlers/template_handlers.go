@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get Templates
+// @Description Get collections published as templates. Starter packs come first
+// @Tags templates
+// @Produce json
+// @Success 200 {array} model.TemplateResponse
+// @Failure 401
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/templates [get]
+func (h *Handler) GetTemplates(c *gin.Context) {
+	const op = "handler.GetTemplates"
+	log := h.log.With("op", op)
+
+	templates, err := h.service.GetTemplates()
+	if err != nil {
+		log.Error("failed to get templates", "error", err)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, templates)
+}
+
+// @Summary Get Template
+// @Description Get a template with its bookmarks to preview it before cloning
+// @Tags templates
+// @Produce json
+// @Param id path int true "Template collection ID"
+// @Success 200 {object} model.TemplateResponse
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/templates/{id} [get]
+func (h *Handler) GetTemplate(c *gin.Context) {
+	const op = "handler.GetTemplate"
+	log := h.log.With("op", op)
+
+	collectionIDStr := c.Param("id")
+	collectionID, err := strconv.ParseUint(collectionIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid template ID", "error", err, "collection_id", collectionIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid template ID"))
+		return
+	}
+
+	template, err := h.service.GetTemplate(uint(collectionID))
+	if err != nil {
+		log.Error("failed to get template", "error", err, "collection_id", collectionID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, template)
+}
+
+// @Summary Clone Template
+// @Description Copy a template into personal bookmarks: a folder named after the template with the same subfolders, tags and order. Bookmarks the user already has are skipped and listed in the report
+// @Tags templates
+// @Produce json
+// @Param id path int true "Template collection ID"
+// @Success 200 {object} model.TemplateCloneResponse
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/templates/{id}/clone [post]
+func (h *Handler) CloneTemplate(c *gin.Context) {
+	const op = "handler.CloneTemplate"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	collectionIDStr := c.Param("id")
+	collectionID, err := strconv.ParseUint(collectionIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid template ID", "error", err, "collection_id", collectionIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid template ID"))
+		return
+	}
+
+	report, err := h.service.CloneTemplate(userID, uint(collectionID))
+	if err != nil {
+		log.Error("failed to clone template", "error", err, "collection_id", collectionID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, report)
+}
+
+// @Summary Publish Collection Template
+// @Description Publish an owned collection as a template that any user can preview and clone, or unpublish it
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Param templateRequest body model.TemplateRequest true "Template flag"
+// @Success 200 {object} model.CollectionResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/collections/{id}/template [put]
+func (h *Handler) PublishTemplate(c *gin.Context) {
+	const op = "handler.PublishTemplate"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	collectionIDStr := c.Param("id")
+	collectionID, err := strconv.ParseUint(collectionIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid collection ID", "error", err, "collection_id", collectionIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid collection ID"))
+		return
+	}
+
+	var req model.TemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid request format"))
+		return
+	}
+
+	collection, err := h.service.PublishTemplate(userID, uint(collectionID), &req)
+	if err != nil {
+		log.Error("failed to publish template", "error", err, "collection_id", collectionID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, collection)
+}
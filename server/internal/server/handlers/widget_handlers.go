@@ -0,0 +1,356 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+	"github.com/gin-gonic/gin"
+)
+
+// widgetPageTemplate шаблон HTML-виджета коллекции
+const widgetPageTemplate = "templates/widgetPage.html"
+
+// widgetPage данные HTML-виджета коллекции
+type widgetPage struct {
+	Embed *model.EmbedResponse
+	Error string
+}
+
+// @Summary Get Collection Widgets
+// @Description Get embeddable widgets of a public collection (owners only)
+// @Tags widgets
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Success 200 {array} model.WidgetResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/collections/{id}/widgets [get]
+func (h *Handler) GetWidgets(c *gin.Context) {
+	const op = "handler.GetWidgets"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	collectionIDStr := c.Param("id")
+	collectionID, err := strconv.ParseUint(collectionIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid collection ID", "error", err, "collection_id", collectionIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid collection ID"))
+		return
+	}
+
+	widgets, err := h.service.GetWidgets(userID, uint(collectionID))
+	if err != nil {
+		log.Error("failed to get widgets", "error", err, "collection_id", collectionID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, widgets)
+}
+
+// @Summary Create Collection Widget
+// @Description Create a widget to embed a public collection on other sites (owners only). The widget has its own URL and can be embedded only on the allowed origins
+// @Tags widgets
+// @Accept json
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Param widgetRequest body model.WidgetRequest true "Widget"
+// @Success 200 {object} model.WidgetResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/collections/{id}/widgets [post]
+func (h *Handler) CreateWidget(c *gin.Context) {
+	const op = "handler.CreateWidget"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	collectionIDStr := c.Param("id")
+	collectionID, err := strconv.ParseUint(collectionIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid collection ID", "error", err, "collection_id", collectionIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid collection ID"))
+		return
+	}
+
+	var req model.WidgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid request format"))
+		return
+	}
+
+	widget, err := h.service.CreateWidget(userID, uint(collectionID), &req)
+	if err != nil {
+		log.Error("failed to create widget", "error", err, "collection_id", collectionID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, widget)
+}
+
+// @Summary Update Collection Widget
+// @Description Change the layout, limit and allowed origins of a widget (owners only). The widget URL stays the same
+// @Tags widgets
+// @Accept json
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Param widgetId path int true "Widget ID"
+// @Param widgetRequest body model.WidgetRequest true "Widget"
+// @Success 200 {object} model.WidgetResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/collections/{id}/widgets/{widgetId} [put]
+func (h *Handler) UpdateWidget(c *gin.Context) {
+	const op = "handler.UpdateWidget"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	collectionIDStr := c.Param("id")
+	collectionID, err := strconv.ParseUint(collectionIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid collection ID", "error", err, "collection_id", collectionIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid collection ID"))
+		return
+	}
+
+	widgetIDStr := c.Param("widgetId")
+	widgetID, err := strconv.ParseUint(widgetIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid widget ID", "error", err, "widget_id", widgetIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid widget ID"))
+		return
+	}
+
+	var req model.WidgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid request format"))
+		return
+	}
+
+	widget, err := h.service.UpdateWidget(userID, uint(collectionID), uint(widgetID), &req)
+	if err != nil {
+		log.Error("failed to update widget", "error", err, "widget_id", widgetID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, widget)
+}
+
+// @Summary Delete Collection Widget
+// @Description Delete a widget (owners only). Sites that embed it stop showing the collection
+// @Tags widgets
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Param widgetId path int true "Widget ID"
+// @Success 200 {object} errors.Response
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/collections/{id}/widgets/{widgetId} [delete]
+func (h *Handler) DeleteWidget(c *gin.Context) {
+	const op = "handler.DeleteWidget"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	collectionIDStr := c.Param("id")
+	collectionID, err := strconv.ParseUint(collectionIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid collection ID", "error", err, "collection_id", collectionIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid collection ID"))
+		return
+	}
+
+	widgetIDStr := c.Param("widgetId")
+	widgetID, err := strconv.ParseUint(widgetIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid widget ID", "error", err, "widget_id", widgetIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid widget ID"))
+		return
+	}
+
+	if err := h.service.DeleteWidget(userID, uint(collectionID), uint(widgetID)); err != nil {
+		log.Error("failed to delete widget", "error", err, "widget_id", widgetID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, "Widget deleted successfully")
+}
+
+// @Summary Collection Widget
+// @Description HTML widget of a public collection for embedding in an iframe. Only the widget's allowed origins may frame it
+// @Tags widgets
+// @Produce html
+// @Param token path string true "Widget token"
+// @Param layout query string false "Override layout: list or tiles"
+// @Param favicons query bool false "Override showing favicons"
+// @Param limit query int false "Show fewer bookmarks than the widget limit"
+// @Success 200 {string} string "HTML page"
+// @Success 304
+// @Failure 400
+// @Failure 404
+// @Router /v1/embed/{token} [get]
+func (h *Handler) GetWidgetPage(c *gin.Context) {
+	const op = "handler.GetWidgetPage"
+	log := h.log.With("op", op)
+
+	var page widgetPage
+	status := http.StatusOK
+	frameAncestors := "'none'"
+
+	var query model.WidgetQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Debug("binding query", "err", err)
+		status = http.StatusBadRequest
+		page.Error = "Invalid widget parameters."
+	} else if embed, err := h.service.GetEmbed(c.Param("token"), &query); err == nil {
+		page.Embed = embed
+		frameAncestors = strings.Join(embed.AllowedOrigins, " ")
+	} else if errors.IsErrorCode(err, errors.CodeNotFound) {
+		status = http.StatusNotFound
+		page.Error = "This widget does not exist or the collection is no longer public."
+	} else {
+		log.Error("failed to get widget", "error", err)
+		status = http.StatusInternalServerError
+		page.Error = "Something went wrong, please try again later."
+	}
+
+	tmpl, err := template.ParseFiles(widgetPageTemplate)
+	if err != nil {
+		log.Error("failed to parse widget template", "error", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInternalError, "Failed to render widget"))
+		return
+	}
+
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, page); err != nil {
+		log.Error("failed to render widget", "error", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInternalError, "Failed to render widget"))
+		return
+	}
+
+	c.Header("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; img-src https: data:; frame-ancestors "+frameAncestors)
+	c.Header("Referrer-Policy", "no-referrer")
+	c.Header("X-Content-Type-Options", "nosniff")
+	if status != http.StatusOK {
+		c.Header("Cache-Control", "no-store")
+		c.Data(status, "text/html; charset=utf-8", body.Bytes())
+		return
+	}
+	respondEmbed(c, body.Bytes(), "text/html; charset=utf-8")
+}
+
+// @Summary Collection Widget Data
+// @Description JSON content of a collection widget for rendering it with own markup. Cross-origin requests are allowed only from the widget's allowed origins
+// @Tags widgets
+// @Produce json
+// @Param token path string true "Widget token"
+// @Param layout query string false "Override layout: list or tiles"
+// @Param favicons query bool false "Override showing favicons"
+// @Param limit query int false "Return fewer bookmarks than the widget limit"
+// @Success 200 {object} model.EmbedResponse
+// @Success 304
+// @Failure 400
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Router /v1/embed/{token}/json [get]
+func (h *Handler) GetWidgetData(c *gin.Context) {
+	const op = "handler.GetWidgetData"
+	log := h.log.With("op", op)
+
+	var query model.WidgetQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Debug("binding query", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid query parameters"))
+		return
+	}
+
+	embed, err := h.service.GetEmbed(c.Param("token"), &query)
+	if err != nil {
+		log.Debug("failed to get widget", "error", err)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	// Ответ зависит от источника запроса, поэтому кэши хранят его отдельно для каждого источника
+	c.Header("Vary", "Origin")
+	if origin := c.GetHeader("Origin"); origin != "" {
+		if !model.IsOriginAllowed(embed.AllowedOrigins, origin) {
+			log.Debug("origin is not allowed", "origin", origin)
+			errors.RespondWithError(c, errors.New(errors.CodeForbidden, "Origin is not allowed"))
+			return
+		}
+		c.Header("Access-Control-Allow-Origin", origin)
+	}
+
+	body, err := json.Marshal(errors.SuccessResponse(embed))
+	if err != nil {
+		log.Error("failed to encode widget", "error", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInternalError, "Failed to encode widget"))
+		return
+	}
+	respondEmbed(c, body, "application/json; charset=utf-8")
+}
+
+// respondEmbed отдаёт виджет с заголовками кэширования или 304, если по If-None-Match он не изменился
+func respondEmbed(c *gin.Context, body []byte, contentType string) {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "public, max-age=300")
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, contentType, body)
+}
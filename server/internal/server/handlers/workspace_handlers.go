@@ -0,0 +1,415 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get Workspaces
+// @Description Get team workspaces the authenticated user belongs to, with the user's role and plan usage
+// @Tags workspaces
+// @Produce json
+// @Success 200 {array} model.WorkspaceResponse
+// @Failure 401
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/workspaces [get]
+func (h *Handler) GetWorkspaces(c *gin.Context) {
+	const op = "handler.GetWorkspaces"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	workspaces, err := h.service.GetWorkspaces(userID)
+	if err != nil {
+		log.Error("failed to get workspaces", "error", err, "user_id", userID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, workspaces)
+}
+
+// @Summary Create Workspace
+// @Description Create a team workspace on the free plan. The creator becomes its admin
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Param workspaceRequest body model.WorkspaceRequest true "Workspace"
+// @Success 200 {object} model.WorkspaceResponse
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/workspaces [post]
+func (h *Handler) CreateWorkspace(c *gin.Context) {
+	const op = "handler.CreateWorkspace"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	var req model.WorkspaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid request format"))
+		return
+	}
+
+	workspace, err := h.service.CreateWorkspace(userID, &req)
+	if err != nil {
+		log.Error("failed to create workspace", "error", err, "user_id", userID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, workspace)
+}
+
+// @Summary Get Workspace
+// @Description Get a workspace with the user's role and plan usage
+// @Tags workspaces
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} model.WorkspaceResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/workspaces/{id} [get]
+func (h *Handler) GetWorkspace(c *gin.Context) {
+	const op = "handler.GetWorkspace"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	workspaceIDStr := c.Param("id")
+	workspaceID, err := strconv.ParseUint(workspaceIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid workspace ID", "error", err, "workspace_id", workspaceIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid workspace ID"))
+		return
+	}
+
+	workspace, err := h.service.GetWorkspace(userID, uint(workspaceID))
+	if err != nil {
+		log.Error("failed to get workspace", "error", err, "workspace_id", workspaceID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, workspace)
+}
+
+// @Summary Update Workspace
+// @Description Rename a workspace (admins only)
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param workspaceRequest body model.WorkspaceRequest true "Workspace"
+// @Success 200 {object} model.WorkspaceResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/workspaces/{id} [put]
+func (h *Handler) UpdateWorkspace(c *gin.Context) {
+	const op = "handler.UpdateWorkspace"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	workspaceIDStr := c.Param("id")
+	workspaceID, err := strconv.ParseUint(workspaceIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid workspace ID", "error", err, "workspace_id", workspaceIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid workspace ID"))
+		return
+	}
+
+	var req model.WorkspaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid request format"))
+		return
+	}
+
+	workspace, err := h.service.UpdateWorkspace(userID, uint(workspaceID), &req)
+	if err != nil {
+		log.Error("failed to update workspace", "error", err, "workspace_id", workspaceID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, workspace)
+}
+
+// @Summary Delete Workspace
+// @Description Delete a workspace (admins only). Its folders are removed and its bookmarks become personal bookmarks of their authors
+// @Tags workspaces
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} errors.Response
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/workspaces/{id} [delete]
+func (h *Handler) DeleteWorkspace(c *gin.Context) {
+	const op = "handler.DeleteWorkspace"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	workspaceIDStr := c.Param("id")
+	workspaceID, err := strconv.ParseUint(workspaceIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid workspace ID", "error", err, "workspace_id", workspaceIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid workspace ID"))
+		return
+	}
+
+	if err := h.service.DeleteWorkspace(userID, uint(workspaceID)); err != nil {
+		log.Error("failed to delete workspace", "error", err, "workspace_id", workspaceID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, "Workspace deleted successfully")
+}
+
+// @Summary Get Workspace Members
+// @Description Get members of a workspace with their roles. Only usernames are shown, never emails
+// @Tags workspaces
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {array} model.WorkspaceMemberResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/workspaces/{id}/members [get]
+func (h *Handler) GetWorkspaceMembers(c *gin.Context) {
+	const op = "handler.GetWorkspaceMembers"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	workspaceIDStr := c.Param("id")
+	workspaceID, err := strconv.ParseUint(workspaceIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid workspace ID", "error", err, "workspace_id", workspaceIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid workspace ID"))
+		return
+	}
+
+	members, err := h.service.GetWorkspaceMembers(userID, uint(workspaceID))
+	if err != nil {
+		log.Error("failed to get workspace members", "error", err, "workspace_id", workspaceID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, members)
+}
+
+// @Summary Add Workspace Member
+// @Description Add a user to a workspace by username (admins only). The workspace plan limits the number of members
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param memberRequest body model.WorkspaceMemberRequest true "Member"
+// @Success 200 {object} model.WorkspaceMemberResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 409
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/workspaces/{id}/members [post]
+func (h *Handler) AddWorkspaceMember(c *gin.Context) {
+	const op = "handler.AddWorkspaceMember"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	workspaceIDStr := c.Param("id")
+	workspaceID, err := strconv.ParseUint(workspaceIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid workspace ID", "error", err, "workspace_id", workspaceIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid workspace ID"))
+		return
+	}
+
+	var req model.WorkspaceMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid request format"))
+		return
+	}
+
+	member, err := h.service.AddWorkspaceMember(userID, uint(workspaceID), &req)
+	if err != nil {
+		log.Error("failed to add workspace member", "error", err, "workspace_id", workspaceID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, member)
+}
+
+// @Summary Update Workspace Member
+// @Description Change the role of a workspace member (admins only). A workspace always keeps at least one admin
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param userId path int true "Member user ID"
+// @Param roleRequest body model.WorkspaceRoleRequest true "Role"
+// @Success 200 {object} model.WorkspaceMemberResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/workspaces/{id}/members/{userId} [put]
+func (h *Handler) UpdateWorkspaceMember(c *gin.Context) {
+	const op = "handler.UpdateWorkspaceMember"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	workspaceIDStr := c.Param("id")
+	workspaceID, err := strconv.ParseUint(workspaceIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid workspace ID", "error", err, "workspace_id", workspaceIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid workspace ID"))
+		return
+	}
+
+	memberIDStr := c.Param("userId")
+	memberID, err := strconv.ParseUint(memberIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid member ID", "error", err, "member_id", memberIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid member ID"))
+		return
+	}
+
+	var req model.WorkspaceRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug("binding json", "err", err)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid request format"))
+		return
+	}
+
+	member, err := h.service.UpdateWorkspaceMember(userID, uint(workspaceID), uint(memberID), &req)
+	if err != nil {
+		log.Error("failed to update workspace member", "error", err, "workspace_id", workspaceID, "member_id", memberID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, member)
+}
+
+// @Summary Remove Workspace Member
+// @Description Remove a member from a workspace. Admins can remove anyone, other members can only leave. Bookmarks of the member stay in the workspace
+// @Tags workspaces
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param userId path int true "Member user ID"
+// @Success 200 {object} errors.Response
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Security Bearer
+// @Router /v1/api/workspaces/{id}/members/{userId} [delete]
+func (h *Handler) RemoveWorkspaceMember(c *gin.Context) {
+	const op = "handler.RemoveWorkspaceMember"
+	log := h.log.With("op", op)
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		log.Error("user ID not found in context")
+		errors.RespondWithError(c, errors.New(errors.CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	workspaceIDStr := c.Param("id")
+	workspaceID, err := strconv.ParseUint(workspaceIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid workspace ID", "error", err, "workspace_id", workspaceIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid workspace ID"))
+		return
+	}
+
+	memberIDStr := c.Param("userId")
+	memberID, err := strconv.ParseUint(memberIDStr, 10, 32)
+	if err != nil {
+		log.Debug("invalid member ID", "error", err, "member_id", memberIDStr)
+		errors.RespondWithError(c, errors.New(errors.CodeInvalidRequest, "Invalid member ID"))
+		return
+	}
+
+	if err := h.service.RemoveWorkspaceMember(userID, uint(workspaceID), uint(memberID)); err != nil {
+		log.Error("failed to remove workspace member", "error", err, "workspace_id", workspaceID, "member_id", memberID)
+		errors.RespondWithError(c, err)
+		return
+	}
+
+	errors.RespondWithSuccess(c, "Member removed from the workspace")
+}
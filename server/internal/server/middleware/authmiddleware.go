@@ -11,6 +11,7 @@ import (
 
 type AuthMiddleware interface {
 	JWTMiddleware() gin.HandlerFunc
+	OptionalJWTMiddleware() gin.HandlerFunc
 }
 
 type middleware struct {
@@ -101,3 +102,16 @@ func (mw *middleware) JWTMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// OptionalJWTMiddleware проверяет токен, только если он передан. Без заголовка Authorization
+// запрос проходит дальше без userID, и обработчик сам решает, как его авторизовать
+func (mw *middleware) OptionalJWTMiddleware() gin.HandlerFunc {
+	required := mw.JWTMiddleware()
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+		required(c)
+	}
+}
@@ -1,19 +1,24 @@
 package middleware
 
 import (
+	"strings"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
+// embedPathPrefix пути встраиваемых виджетов. Их запрашивают сторонние сайты,
+// а разрешённые источники каждый виджет проверяет сам
+const embedPathPrefix = "/v1/embed/"
+
 func PublicCORS() gin.HandlerFunc {
 	var origins = make([]string, 0, 30)
 	origins = append(origins, []string{
 		"https://theca.oxytocingroup.com",
 	}...)
 
-	return cors.New(cors.Config{
+	handler := cors.New(cors.Config{
 		AllowOrigins:     origins,
 		AllowMethods:     []string{"GET,POST,PATCH,PUT,DELETE,OPTIONS"},
 		AllowHeaders:     []string{"Accept", "Referer", "Origin", "DNT", "User-Agent", "Content-Type", "Authorization"},
@@ -21,4 +26,12 @@ func PublicCORS() gin.HandlerFunc {
 		MaxAge:           1 * time.Hour,
 		AllowWildcard:    true,
 	})
+
+	return func(c *gin.Context) {
+		if strings.HasPrefix(c.Request.URL.Path, embedPathPrefix) {
+			c.Next()
+			return
+		}
+		handler(c)
+	}
 }
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"slices"
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+)
+
+const (
+	defaultActivityLimit = 50
+	maxActivityLimit     = 200
+)
+
+// newActivity формирует запись журнала о действии пользователя с закладкой в её текущем виде
+func newActivity(actorID uint, action string, bookmark *model.Bookmark) model.Activity {
+	return model.Activity{
+		CreatedAt:     time.Now(),
+		ActorID:       actorID,
+		OwnerID:       bookmark.UserID,
+		BookmarkID:    bookmark.ID,
+		CollectionID:  bookmark.CollectionID,
+		WorkspaceID:   bookmark.WorkspaceID,
+		Action:        action,
+		BookmarkTitle: bookmark.Title,
+		BookmarkURL:   bookmark.URL,
+	}
+}
+
+// bookmarkChanges сравнивает закладку до и после изменения: возвращает записи журнала
+// об изменённых полях и о переносе в другую папку или коллекцию
+func bookmarkChanges(actorID uint, before, after *model.Bookmark) []model.Activity {
+	var activities []model.Activity
+
+	var fields []string
+	if before.Title != after.Title {
+		fields = append(fields, "title")
+	}
+	if before.URL != after.URL {
+		fields = append(fields, "url")
+	}
+	if !slices.Equal(before.Tags, after.Tags) {
+		fields = append(fields, "tags")
+	}
+	if before.ShowText != after.ShowText {
+		fields = append(fields, "show_text")
+	}
+	if len(fields) > 0 {
+		edited := newActivity(actorID, model.ActivityEdited, after)
+		edited.Fields = fields
+		activities = append(activities, edited)
+	}
+
+	collectionChanged := !sameID(before.CollectionID, after.CollectionID)
+	if collectionChanged || !sameID(before.FolderID, after.FolderID) {
+		moved := newActivity(actorID, model.ActivityMoved, after)
+		if collectionChanged {
+			moved.FromCollectionID = before.CollectionID
+		}
+		activities = append(activities, moved)
+	}
+
+	return activities
+}
+
+// sameID проверяет, что необязательные ID совпадают
+func sameID(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// recordActivity сохраняет записи журнала; ошибка записи не отменяет само действие
+func (s *service) recordActivity(activities ...model.Activity) {
+	const op = "service.recordActivity"
+	log := s.log.With("op", op)
+
+	if err := s.repo.CreateActivities(activities); err != nil {
+		log.Error("failed to record activity", "error", err, "count", len(activities))
+	}
+}
+
+// recordImport записывает в журнал добавление импортированных закладок
+func (s *service) recordImport(userID uint, bookmarks []model.Bookmark) {
+	activities := make([]model.Activity, len(bookmarks))
+	for i := range bookmarks {
+		activities[i] = newActivity(userID, model.ActivityAdded, &bookmarks[i])
+	}
+	s.recordActivity(activities...)
+}
+
+// GetActivity возвращает журнал активности: действия с личными закладками пользователя, его собственные действия
+// и изменения в коллекциях и рабочих пространствах, где он состоит. Записи идут от новых к старым
+func (s *service) GetActivity(userID uint, filter *model.ActivityFilter) (*model.ActivityPageResponse, error) {
+	const op = "service.GetActivity"
+	log := s.log.With("op", op)
+
+	if filter.CollectionID != 0 {
+		if _, err := s.collectionRole(userID, filter.CollectionID); err != nil {
+			return nil, err
+		}
+	}
+	if filter.WorkspaceID != 0 {
+		if _, err := s.workspaceRole(userID, filter.WorkspaceID); err != nil {
+			return nil, err
+		}
+	}
+
+	members, err := s.repo.GetUserMemberships(userID)
+	if err != nil {
+		return nil, err
+	}
+	collectionIDs := make([]uint, 0, len(members))
+	for _, member := range members {
+		collectionIDs = append(collectionIDs, member.CollectionID)
+	}
+	_, memberships, err := s.repo.GetUserWorkspaces(userID)
+	if err != nil {
+		return nil, err
+	}
+	workspaceIDs := make([]uint, 0, len(memberships))
+	for _, membership := range memberships {
+		workspaceIDs = append(workspaceIDs, membership.WorkspaceID)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultActivityLimit
+	}
+	limit = min(limit, maxActivityLimit)
+
+	// Лишняя запись показывает, есть ли следующая страница
+	activities, err := s.repo.GetActivities(userID, collectionIDs, workspaceIDs, filter, limit+1)
+	if err != nil {
+		log.Error("failed to get activity", "error", err, "user_id", userID)
+		return nil, err
+	}
+	page := &model.ActivityPageResponse{Items: make([]model.ActivityResponse, 0, min(len(activities), limit))}
+	if len(activities) > limit {
+		activities = activities[:limit]
+		page.NextBefore = &activities[limit-1].ID
+	}
+
+	actorIDs := make([]uint, 0, len(activities))
+	for _, activity := range activities {
+		actorIDs = append(actorIDs, activity.ActorID)
+	}
+	users, err := s.repo.GetUsersByIDs(actorIDs)
+	if err != nil {
+		return nil, err
+	}
+	usernames := make(map[uint]string, len(users))
+	for _, user := range users {
+		usernames[user.ID] = user.Username
+	}
+
+	for _, activity := range activities {
+		fields := activity.Fields
+		if fields == nil {
+			fields = []string{}
+		}
+		page.Items = append(page.Items, model.ActivityResponse{
+			ID:               activity.ID,
+			CreatedAt:        activity.CreatedAt,
+			Action:           activity.Action,
+			ActorID:          activity.ActorID,
+			Actor:            usernames[activity.ActorID],
+			BookmarkID:       activity.BookmarkID,
+			BookmarkTitle:    activity.BookmarkTitle,
+			BookmarkURL:      activity.BookmarkURL,
+			CollectionID:     activity.CollectionID,
+			FromCollectionID: activity.FromCollectionID,
+			WorkspaceID:      activity.WorkspaceID,
+			Fields:           fields,
+		})
+	}
+
+	return page, nil
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+	"github.com/aerscs/theca-public/internal/utils/parsers"
+)
+
+// snapshotTimeout ограничивает время создания одного снимка вместе со всеми ресурсами
+const snapshotTimeout = 2 * time.Minute
+
+// archiveBookmarkAsync создаёт снимок страницы в фоне, не задерживая ответ на сохранение закладки
+func (s *service) archiveBookmarkAsync(bookmark *model.Bookmark) {
+	if !s.cfg.SnapshotsEnabled {
+		return
+	}
+
+	copied := *bookmark
+	go func() {
+		if _, err := s.archiveBookmark(context.Background(), &copied); err != nil {
+			s.log.Info("bookmark snapshot skipped", "error", err, "bookmark_id", copied.ID)
+		}
+	}()
+}
+
+// archiveBookmark загружает страницу закладки, сохраняет снимок в хранилище и заменяет им предыдущий.
+// Размер снимка ограничен SnapshotMaxSize и свободным местом в квоте пользователя
+func (s *service) archiveBookmark(ctx context.Context, bookmark *model.Bookmark) (*model.BookmarkArchive, error) {
+	const op = "service.archiveBookmark"
+	log := s.log.With("op", op, "bookmark_id", bookmark.ID)
+
+	user, err := s.repo.GetUserByID(bookmark.UserID)
+	if err != nil {
+		log.Error("failed to get user", "error", err, "user_id", bookmark.UserID)
+		return nil, err
+	}
+
+	// Место, занятое заменяемым снимком, освободится
+	var oldSize int64
+	if bookmark.SnapshotAt != nil {
+		if existing, err := s.repo.GetBookmarkArchive(bookmark.ID); err == nil {
+			oldSize = existing.Size
+		}
+	}
+
+	available := s.storageQuota(user) - user.StorageUsed + oldSize
+	if available <= 0 {
+		log.Info("storage quota exceeded, snapshot skipped", "user_id", user.ID, "storage_used", user.StorageUsed)
+		return nil, errors.New(errors.CodeForbidden, "Storage quota exceeded")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, snapshotTimeout)
+	defer cancel()
+
+	snapshot, err := parsers.TakeSnapshot(ctx, bookmark.URL, min(s.cfg.SnapshotMaxSize, available))
+	if err != nil {
+		log.Debug("failed to take snapshot", "error", err, "url", bookmark.URL)
+		if err == parsers.ErrSnapshotTooLarge {
+			return nil, errors.New(errors.CodeDataInvalid, "Page is too large to archive")
+		}
+		return nil, errors.NewWithError(err, errors.CodeDataInvalid, "Failed to archive page")
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		log.Error("failed to generate archive key", "error", err)
+		return nil, errors.New(errors.CodeInternalError, "Failed to archive page")
+	}
+
+	// Ключ уникален для каждого снимка, чтобы замена не затирала файл, который ещё может читаться
+	key := fmt.Sprintf("archives/%d/%d/%s.html", bookmark.UserID, bookmark.ID, token[:32])
+	if err := s.blobs.Put(ctx, key, snapshot.ContentType, snapshot.Data); err != nil {
+		log.Error("failed to store snapshot", "error", err)
+		return nil, errors.New(errors.CodeInternalError, "Failed to store archive")
+	}
+
+	archive := &model.BookmarkArchive{
+		BookmarkID:  bookmark.ID,
+		UserID:      bookmark.UserID,
+		StorageKey:  key,
+		ContentType: snapshot.ContentType,
+		SourceURL:   bookmark.URL,
+		Size:        int64(len(snapshot.Data)),
+		Resources:   snapshot.Resources,
+		Truncated:   snapshot.Truncated,
+		CreatedAt:   time.Now(),
+	}
+
+	replaced, err := s.repo.SaveBookmarkArchive(archive)
+	if err != nil {
+		s.deleteArchiveBlob(key)
+		return nil, err
+	}
+	if replaced != nil {
+		s.deleteArchiveBlob(replaced.StorageKey)
+	}
+
+	bookmark.SnapshotAt = &archive.CreatedAt
+
+	log.Debug("bookmark archived", "size", archive.Size, "resources", archive.Resources, "truncated", archive.Truncated)
+	return archive, nil
+}
+
+// deleteArchiveBlob удаляет файл снимка; ошибка только логируется, запись о снимке к этому моменту уже удалена
+func (s *service) deleteArchiveBlob(key string) {
+	if err := s.blobs.Delete(context.Background(), key); err != nil {
+		s.log.Error("failed to delete archive blob", "error", err, "key", key)
+	}
+}
+
+// removeBookmarkArchive удаляет снимок закладки вместе с файлом
+func (s *service) removeBookmarkArchive(bookmarkID uint) error {
+	deleted, err := s.repo.DeleteBookmarkArchive(bookmarkID)
+	if err != nil {
+		return err
+	}
+	if deleted != nil {
+		s.deleteArchiveBlob(deleted.StorageKey)
+	}
+	return nil
+}
+
+func (s *service) ArchiveBookmark(userID, bookmarkID uint) (*model.BookmarkArchive, error) {
+	const op = "service.ArchiveBookmark"
+	log := s.log.With("op", op)
+
+	if !s.cfg.SnapshotsEnabled {
+		return nil, errors.New(errors.CodeForbidden, "Page snapshots are disabled")
+	}
+
+	bookmark, err := s.getBookmark(userID, bookmarkID, accessEdit)
+	if err != nil {
+		log.Error("failed to get bookmark for archiving", "error", err, "bookmark_id", bookmarkID, "user_id", userID)
+		return nil, err
+	}
+
+	return s.archiveBookmark(context.Background(), bookmark)
+}
+
+// GetBookmarkArchive возвращает сведения о снимке и его содержимое
+func (s *service) GetBookmarkArchive(userID, bookmarkID uint) (*model.BookmarkArchive, []byte, error) {
+	const op = "service.GetBookmarkArchive"
+	log := s.log.With("op", op)
+
+	if _, err := s.GetBookmarkByID(userID, bookmarkID); err != nil {
+		return nil, nil, err
+	}
+
+	archive, err := s.repo.GetBookmarkArchive(bookmarkID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, _, err := s.blobs.Get(context.Background(), archive.StorageKey)
+	if err != nil {
+		log.Error("failed to read archive blob", "error", err, "bookmark_id", bookmarkID, "key", archive.StorageKey)
+		return nil, nil, errors.New(errors.CodeInternalError, "Failed to read archive")
+	}
+
+	return archive, data, nil
+}
+
+func (s *service) DeleteBookmarkArchive(userID, bookmarkID uint) error {
+	const op = "service.DeleteBookmarkArchive"
+	log := s.log.With("op", op)
+
+	if _, err := s.getBookmark(userID, bookmarkID, accessEdit); err != nil {
+		return err
+	}
+
+	if err := s.removeBookmarkArchive(bookmarkID); err != nil {
+		log.Error("failed to delete bookmark archive", "error", err, "bookmark_id", bookmarkID)
+		return err
+	}
+
+	log.Debug("bookmark archive deleted", "bookmark_id", bookmarkID, "user_id", userID)
+	return nil
+}
@@ -0,0 +1,430 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+	"github.com/aerscs/theca-public/internal/utils/mail"
+)
+
+// roleRank порядок ролей: при повторном приглашении роль участника только повышается
+var roleRank = map[string]int{
+	model.RoleViewer: 1,
+	model.RoleEditor: 2,
+	model.RoleOwner:  3,
+}
+
+// collectionRole возвращает роль пользователя в коллекции; для не-участника — CodeForbidden
+func (s *service) collectionRole(userID, collectionID uint) (string, error) {
+	member, err := s.repo.GetCollectionMember(collectionID, userID)
+	if err != nil {
+		if errors.IsErrorCode(err, errors.CodeNotFound) {
+			return "", errors.New(errors.CodeForbidden, "Not a member of the collection")
+		}
+		return "", err
+	}
+	return member.Role, nil
+}
+
+// requireCollectionOwner проверяет, что пользователь владеет коллекцией
+func (s *service) requireCollectionOwner(userID, collectionID uint) error {
+	role, err := s.collectionRole(userID, collectionID)
+	if err != nil {
+		return err
+	}
+	if role != model.RoleOwner {
+		return errors.New(errors.CodeForbidden, "Only collection owners can do this")
+	}
+	return nil
+}
+
+// applyBookmarkCollection переносит закладку в коллекцию; 0 убирает её из коллекции.
+// Решать, кому видна закладка, может только автор, а добавлять в коллекцию — только её редакторы и владельцы
+func (s *service) applyBookmarkCollection(userID uint, bookmark *model.Bookmark, collectionID uint) error {
+	current := uint(0)
+	if bookmark.CollectionID != nil {
+		current = *bookmark.CollectionID
+	}
+	if collectionID == current {
+		return nil
+	}
+	if bookmark.UserID != userID {
+		return errors.New(errors.CodeForbidden, "Only the author can move the bookmark between collections")
+	}
+
+	if collectionID == 0 {
+		bookmark.CollectionID = nil
+		return nil
+	}
+	// Иначе закладки пространства стали бы видны участникам коллекции вне команды
+	if bookmark.WorkspaceID != nil {
+		return errors.New(errors.CodeInvalidRequest, "Workspace bookmarks can't be added to collections")
+	}
+
+	role, err := s.collectionRole(userID, collectionID)
+	if err != nil {
+		return err
+	}
+	if !model.CanEdit(role) {
+		return errors.New(errors.CodeForbidden, "Insufficient collection role")
+	}
+
+	bookmark.CollectionID = &collectionID
+	return nil
+}
+
+// GetCollections возвращает коллекции, в которых состоит пользователь, с его ролью
+func (s *service) GetCollections(userID uint) ([]model.CollectionResponse, error) {
+	const op = "service.GetCollections"
+	log := s.log.With("op", op)
+
+	members, err := s.repo.GetUserMemberships(userID)
+	if err != nil {
+		log.Error("failed to get memberships", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	roles := make(map[uint]string, len(members))
+	ids := make([]uint, 0, len(members))
+	for _, member := range members {
+		roles[member.CollectionID] = member.Role
+		ids = append(ids, member.CollectionID)
+	}
+
+	collections, err := s.repo.GetCollectionsByIDs(ids)
+	if err != nil {
+		log.Error("failed to get collections", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	responses := make([]model.CollectionResponse, 0, len(collections))
+	for i := range collections {
+		responses = append(responses, model.NewCollectionResponse(&collections[i], roles[collections[i].ID]))
+	}
+	return responses, nil
+}
+
+func (s *service) CreateCollection(userID uint, req *model.CollectionRequest) (*model.CollectionResponse, error) {
+	const op = "service.CreateCollection"
+	log := s.log.With("op", op)
+
+	now := time.Now()
+	collection := &model.Collection{
+		Name:        req.Name,
+		Description: req.Description,
+		IsPublic:    req.IsPublic,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.repo.CreateCollection(collection, userID); err != nil {
+		log.Error("failed to create collection", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	response := model.NewCollectionResponse(collection, model.RoleOwner)
+	return &response, nil
+}
+
+func (s *service) UpdateCollection(userID, collectionID uint, req *model.CollectionRequest) (*model.CollectionResponse, error) {
+	const op = "service.UpdateCollection"
+	log := s.log.With("op", op)
+
+	if err := s.requireCollectionOwner(userID, collectionID); err != nil {
+		return nil, err
+	}
+
+	collection, err := s.repo.GetCollectionByID(collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	collection.Name = req.Name
+	collection.Description = req.Description
+	collection.IsPublic = req.IsPublic
+	collection.UpdatedAt = time.Now()
+	if err := s.repo.SaveCollection(collection); err != nil {
+		log.Error("failed to update collection", "error", err, "collection_id", collectionID)
+		return nil, err
+	}
+
+	response := model.NewCollectionResponse(collection, model.RoleOwner)
+	return &response, nil
+}
+
+// DeleteCollection удаляет коллекцию; закладки остаются у добавивших их пользователей
+func (s *service) DeleteCollection(userID, collectionID uint) error {
+	const op = "service.DeleteCollection"
+	log := s.log.With("op", op)
+
+	if err := s.requireCollectionOwner(userID, collectionID); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteCollection(collectionID); err != nil {
+		log.Error("failed to delete collection", "error", err, "collection_id", collectionID)
+		return err
+	}
+
+	log.Debug("collection deleted", "collection_id", collectionID, "user_id", userID)
+	return nil
+}
+
+func (s *service) GetCollectionBookmarks(userID, collectionID uint) ([]model.Bookmark, error) {
+	if _, err := s.collectionRole(userID, collectionID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetCollectionBookmarks(collectionID)
+}
+
+// GetCollectionMembers возвращает участников коллекции. Email участников не раскрывается
+func (s *service) GetCollectionMembers(userID, collectionID uint) ([]model.CollectionMemberResponse, error) {
+	const op = "service.GetCollectionMembers"
+	log := s.log.With("op", op)
+
+	if _, err := s.collectionRole(userID, collectionID); err != nil {
+		return nil, err
+	}
+
+	members, err := s.repo.GetCollectionMembers(collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(members))
+	for _, member := range members {
+		ids = append(ids, member.UserID)
+	}
+	users, err := s.repo.GetUsersByIDs(ids)
+	if err != nil {
+		log.Error("failed to get member users", "error", err, "collection_id", collectionID)
+		return nil, err
+	}
+	usernames := make(map[uint]string, len(users))
+	for i := range users {
+		usernames[users[i].ID] = users[i].Username
+	}
+
+	responses := make([]model.CollectionMemberResponse, 0, len(members))
+	for _, member := range members {
+		responses = append(responses, model.CollectionMemberResponse{
+			UserID:   member.UserID,
+			Username: usernames[member.UserID],
+			Role:     member.Role,
+			JoinedAt: member.CreatedAt,
+		})
+	}
+	return responses, nil
+}
+
+// InviteToCollection приглашает пользователя в коллекцию по email.
+// Письмо уходит в фоне; принять приглашение можно после входа с этим адресом
+func (s *service) InviteToCollection(userID, collectionID uint, req *model.CollectionInviteRequest) (*model.CollectionInvite, error) {
+	const op = "service.InviteToCollection"
+	log := s.log.With("op", op)
+
+	if err := s.requireCollectionOwner(userID, collectionID); err != nil {
+		return nil, err
+	}
+
+	collection, err := s.repo.GetCollectionByID(collectionID)
+	if err != nil {
+		return nil, err
+	}
+	inviter, err := s.repo.GetUserByID(userID)
+	if err != nil {
+		log.Error("failed to get inviter", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if invitee, err := s.repo.GetUserByEmail(email); err == nil {
+		if _, err := s.repo.GetCollectionMember(collectionID, invitee.ID); err == nil {
+			return nil, errors.New(errors.CodeDataConflict, "User is already a member of the collection")
+		}
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		log.Error("failed to generate invite token", "error", err)
+		return nil, errors.New(errors.CodeInternalError, "Failed to create invitation")
+	}
+
+	now := time.Now()
+	invite := &model.CollectionInvite{
+		CollectionID: collectionID,
+		InvitedBy:    userID,
+		Email:        email,
+		Role:         req.Role,
+		Token:        token,
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(model.CollectionInviteTTL),
+	}
+	if err := s.repo.CreateCollectionInvite(invite); err != nil {
+		return nil, err
+	}
+
+	message := &mail.CollectionInvite{
+		Inviter:       inviter.Username,
+		Collection:    collection.Name,
+		Role:          invite.Role,
+		URL:           s.cfg.ClientURL + "/invites/" + invite.Token,
+		ExpiresInDays: int(model.CollectionInviteTTL / (24 * time.Hour)),
+	}
+	go func() {
+		if err := s.mailer.SendCollectionInviteEmail(email, message); err != nil {
+			log.Error("failed to send collection invite email", "error", err, "collection_id", collectionID)
+		}
+	}()
+
+	log.Debug("collection invite created", "collection_id", collectionID, "invite_id", invite.ID)
+	return invite, nil
+}
+
+func (s *service) GetCollectionInvites(userID, collectionID uint) ([]model.CollectionInvite, error) {
+	if err := s.requireCollectionOwner(userID, collectionID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetCollectionInvites(collectionID)
+}
+
+func (s *service) RevokeCollectionInvite(userID, collectionID, inviteID uint) error {
+	if err := s.requireCollectionOwner(userID, collectionID); err != nil {
+		return err
+	}
+
+	invite, err := s.repo.GetCollectionInviteByID(inviteID)
+	if err != nil {
+		return err
+	}
+	if invite.CollectionID != collectionID {
+		return errors.New(errors.CodeNotFound, "Invitation not found")
+	}
+
+	return s.repo.DeleteCollectionInvite(invite.ID)
+}
+
+// AcceptCollectionInvite добавляет пользователя в коллекцию по приглашению, отправленному на его email
+func (s *service) AcceptCollectionInvite(userID uint, token string) (*model.CollectionResponse, error) {
+	const op = "service.AcceptCollectionInvite"
+	log := s.log.With("op", op)
+
+	invite, err := s.repo.GetCollectionInviteByToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if !invite.ExpiresAt.After(time.Now()) {
+		return nil, errors.New(errors.CodeNotFound, "Invitation has expired")
+	}
+
+	user, err := s.repo.GetUserByID(userID)
+	if err != nil {
+		log.Error("failed to get user", "error", err, "user_id", userID)
+		return nil, err
+	}
+	if !user.IsVerified || !strings.EqualFold(user.Email, invite.Email) {
+		return nil, errors.New(errors.CodeForbidden, "Invitation was sent to another email")
+	}
+
+	member := &model.CollectionMember{
+		CollectionID: invite.CollectionID,
+		UserID:       userID,
+		Role:         invite.Role,
+		CreatedAt:    time.Now(),
+	}
+	if existing, err := s.repo.GetCollectionMember(invite.CollectionID, userID); err == nil {
+		member.CreatedAt = existing.CreatedAt
+		if roleRank[existing.Role] > roleRank[member.Role] {
+			member.Role = existing.Role
+		}
+	}
+
+	if err := s.repo.AcceptCollectionInvite(invite, member); err != nil {
+		return nil, err
+	}
+
+	collection, err := s.repo.GetCollectionByID(invite.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("collection invite accepted", "collection_id", collection.ID, "user_id", userID)
+	response := model.NewCollectionResponse(collection, member.Role)
+	return &response, nil
+}
+
+// UpdateCollectionMember меняет роль участника; в коллекции всегда остаётся хотя бы один владелец
+func (s *service) UpdateCollectionMember(userID, collectionID, memberID uint, req *model.CollectionRoleRequest) (*model.CollectionMemberResponse, error) {
+	if err := s.requireCollectionOwner(userID, collectionID); err != nil {
+		return nil, err
+	}
+
+	member, err := s.repo.GetCollectionMember(collectionID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role == model.RoleOwner && req.Role != model.RoleOwner {
+		if err := s.ensureAnotherOwner(collectionID); err != nil {
+			return nil, err
+		}
+	}
+
+	member.Role = req.Role
+	if err := s.repo.SaveCollectionMember(member); err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetUserByID(memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.CollectionMemberResponse{
+		UserID:   member.UserID,
+		Username: user.Username,
+		Role:     member.Role,
+		JoinedAt: member.CreatedAt,
+	}, nil
+}
+
+// RemoveCollectionMember исключает участника. Владелец может исключить любого, остальные — только выйти сами
+func (s *service) RemoveCollectionMember(userID, collectionID, memberID uint) error {
+	const op = "service.RemoveCollectionMember"
+	log := s.log.With("op", op)
+
+	if userID != memberID {
+		if err := s.requireCollectionOwner(userID, collectionID); err != nil {
+			return err
+		}
+	}
+
+	member, err := s.repo.GetCollectionMember(collectionID, memberID)
+	if err != nil {
+		return err
+	}
+	if member.Role == model.RoleOwner {
+		if err := s.ensureAnotherOwner(collectionID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.DeleteCollectionMember(collectionID, memberID); err != nil {
+		return err
+	}
+
+	log.Debug("collection member removed", "collection_id", collectionID, "member_id", memberID, "user_id", userID)
+	return nil
+}
+
+// ensureAnotherOwner не даёт оставить коллекцию без владельца
+func (s *service) ensureAnotherOwner(collectionID uint) error {
+	owners, err := s.repo.CountCollectionOwners(collectionID)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return errors.New(errors.CodeInvalidRequest, "Collection must keep at least one owner; delete it instead")
+	}
+	return nil
+}
@@ -0,0 +1,359 @@
+package service
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+	"github.com/aerscs/theca-public/internal/utils/mail"
+)
+
+// mentionPattern находит упоминания вида @username, но не адреса почты
+var mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_.@])@([\p{L}\p{N}_.\-]+)`)
+
+// maxCommentMentions ограничивает число писем об упоминаниях от одного комментария
+const maxCommentMentions = 10
+
+// parseMentions возвращает упомянутые в тексте username без повторов, в порядке появления
+func parseMentions(body string) []string {
+	seen := make(map[string]bool)
+	var usernames []string
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		// Точка или дефис в конце — знак препинания после упоминания
+		username := strings.TrimRight(match[1], ".-")
+		if utf8.RuneCountInString(username) < 3 || seen[username] {
+			continue
+		}
+		seen[username] = true
+		usernames = append(usernames, username)
+	}
+	return usernames
+}
+
+// normalizeCommentBody обрезает пробелы по краям и отклоняет пустой комментарий
+func normalizeCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", errors.New(errors.CodeInvalidRequest, "Comment is empty")
+	}
+	return body, nil
+}
+
+// getBookmarkComment возвращает неудалённый комментарий закладки
+func (s *service) getBookmarkComment(bookmarkID, commentID uint) (*model.Comment, error) {
+	comment, err := s.repo.GetCommentByID(commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.BookmarkID != bookmarkID || comment.Deleted {
+		return nil, errors.New(errors.CodeNotFound, "Comment not found")
+	}
+	return comment, nil
+}
+
+// GetComments возвращает ветки комментариев закладки. Комментарии видны всем, кто может просматривать закладку
+func (s *service) GetComments(userID, bookmarkID uint) ([]model.CommentResponse, error) {
+	const op = "service.GetComments"
+	log := s.log.With("op", op)
+
+	if _, err := s.getBookmark(userID, bookmarkID, accessRead); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.repo.GetBookmarkComments(bookmarkID)
+	if err != nil {
+		log.Error("failed to get comments", "error", err, "bookmark_id", bookmarkID)
+		return nil, err
+	}
+	usernames, err := s.commentAuthors(comments)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildCommentThreads(comments, usernames), nil
+}
+
+// CreateComment добавляет комментарий или ответ на комментарий к закладке.
+// Комментировать может любой, кому видна закладка, в том числе читатель коллекции
+func (s *service) CreateComment(userID, bookmarkID uint, req *model.CommentRequest) (*model.CommentResponse, error) {
+	const op = "service.CreateComment"
+	log := s.log.With("op", op)
+
+	bookmark, err := s.getBookmark(userID, bookmarkID, accessRead)
+	if err != nil {
+		return nil, err
+	}
+	body, err := normalizeCommentBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	if req.ParentID != nil {
+		if _, err := s.getBookmarkComment(bookmarkID, *req.ParentID); err != nil {
+			return nil, err
+		}
+	}
+	author, err := s.repo.GetUserByID(userID)
+	if err != nil {
+		log.Error("failed to get comment author", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	now := time.Now()
+	comment := &model.Comment{
+		BookmarkID: bookmarkID,
+		UserID:     userID,
+		ParentID:   req.ParentID,
+		Body:       body,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := s.repo.CreateComment(comment); err != nil {
+		return nil, err
+	}
+	s.notifyMentions(author, bookmark, comment, "")
+
+	log.Debug("comment created", "comment_id", comment.ID, "bookmark_id", bookmarkID, "user_id", userID)
+	response := newCommentResponse(comment, author.Username)
+	return &response, nil
+}
+
+// UpdateComment изменяет текст комментария. Изменить комментарий может только его автор;
+// письма получают только пользователи, упомянутые впервые
+func (s *service) UpdateComment(userID, bookmarkID, commentID uint, req *model.UpdateCommentRequest) (*model.CommentResponse, error) {
+	const op = "service.UpdateComment"
+	log := s.log.With("op", op)
+
+	bookmark, err := s.getBookmark(userID, bookmarkID, accessRead)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := s.getBookmarkComment(bookmarkID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.UserID != userID {
+		return nil, errors.New(errors.CodeForbidden, "Only the author can edit the comment")
+	}
+	body, err := normalizeCommentBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	author, err := s.repo.GetUserByID(userID)
+	if err != nil {
+		log.Error("failed to get comment author", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	previous := comment.Body
+	if body != previous {
+		comment.Body = body
+		comment.UpdatedAt = time.Now()
+		if err := s.repo.SaveComment(comment); err != nil {
+			return nil, err
+		}
+		s.notifyMentions(author, bookmark, comment, previous)
+	}
+
+	response := newCommentResponse(comment, author.Username)
+	return &response, nil
+}
+
+// DeleteComment удаляет комментарий. Кроме автора, удалить его может тот, кому разрешено удалять саму закладку.
+// Комментарий с ответами остаётся в ветке без текста; удалённые комментарии без ответов убираются совсем
+func (s *service) DeleteComment(userID, bookmarkID, commentID uint) error {
+	const op = "service.DeleteComment"
+	log := s.log.With("op", op)
+
+	if _, err := s.getBookmark(userID, bookmarkID, accessRead); err != nil {
+		return err
+	}
+	comment, err := s.getBookmarkComment(bookmarkID, commentID)
+	if err != nil {
+		return err
+	}
+	if comment.UserID != userID {
+		if _, err := s.getBookmark(userID, bookmarkID, accessDelete); err != nil {
+			return err
+		}
+	}
+
+	for comment != nil {
+		replies, err := s.repo.CountCommentReplies(comment.ID)
+		if err != nil {
+			return err
+		}
+		if replies > 0 {
+			if comment.Deleted {
+				break
+			}
+			comment.Deleted = true
+			comment.Body = ""
+			if err := s.repo.SaveComment(comment); err != nil {
+				return err
+			}
+			break
+		}
+
+		if err := s.repo.DeleteComment(comment.ID); err != nil {
+			return err
+		}
+		// Удалённый родитель без оставшихся ответов больше не нужен ветке
+		if comment.ParentID == nil {
+			break
+		}
+		parent, err := s.repo.GetCommentByID(*comment.ParentID)
+		if err != nil || !parent.Deleted {
+			break
+		}
+		comment = parent
+	}
+
+	log.Debug("comment deleted", "comment_id", commentID, "bookmark_id", bookmarkID, "user_id", userID)
+	return nil
+}
+
+// notifyMentions отправляет письма пользователям, впервые упомянутым в комментарии.
+// Письмо получают только подтверждённые пользователи, которым видна закладка
+func (s *service) notifyMentions(author *model.User, bookmark *model.Bookmark, comment *model.Comment, previous string) {
+	const op = "service.notifyMentions"
+	log := s.log.With("op", op)
+
+	mentioned := make(map[string]bool)
+	for _, username := range parseMentions(previous) {
+		mentioned[username] = true
+	}
+
+	sent := 0
+	for _, username := range parseMentions(comment.Body) {
+		if sent == maxCommentMentions {
+			break
+		}
+		if mentioned[username] || username == author.Username {
+			continue
+		}
+		user, err := s.repo.GetUserByUsername(username)
+		if err != nil || !user.IsVerified {
+			continue
+		}
+		if _, err := s.getBookmark(user.ID, bookmark.ID, accessRead); err != nil {
+			continue
+		}
+		sent++
+
+		email := user.Email
+		message := &mail.CommentMention{
+			Author:   author.Username,
+			Bookmark: bookmark.Title,
+			Comment:  comment.Body,
+			URL:      s.cfg.ClientURL,
+		}
+		go func() {
+			if err := s.mailer.SendCommentMentionEmail(email, message); err != nil {
+				log.Error("failed to send mention email", "error", err, "comment_id", comment.ID)
+			}
+		}()
+	}
+}
+
+// commentAuthors возвращает username авторов комментариев по их ID
+func (s *service) commentAuthors(comments []model.Comment) (map[uint]string, error) {
+	ids := make([]uint, 0, len(comments))
+	for _, comment := range comments {
+		ids = append(ids, comment.UserID)
+	}
+	return s.usernamesByID(ids)
+}
+
+// newCommentResponse формирует ответ без веток; у удалённого комментария скрываются текст и автор
+func newCommentResponse(comment *model.Comment, username string) model.CommentResponse {
+	response := model.CommentResponse{
+		ID:        comment.ID,
+		ParentID:  comment.ParentID,
+		UserID:    comment.UserID,
+		Username:  username,
+		Body:      comment.Body,
+		CreatedAt: comment.CreatedAt,
+		UpdatedAt: comment.UpdatedAt,
+		Edited:    comment.Edited(),
+		Deleted:   comment.Deleted,
+		Replies:   []model.CommentResponse{},
+	}
+	if comment.Deleted {
+		response.UserID = 0
+		response.Username = ""
+		response.Edited = false
+	}
+	return response
+}
+
+// buildCommentThreads собирает комментарии, отсортированные по времени, в ветки
+func buildCommentThreads(comments []model.Comment, usernames map[uint]string) []model.CommentResponse {
+	children := make(map[uint][]int)
+	var roots []int
+	for i := range comments {
+		if comments[i].ParentID == nil {
+			roots = append(roots, i)
+			continue
+		}
+		children[*comments[i].ParentID] = append(children[*comments[i].ParentID], i)
+	}
+
+	var build func(i int) model.CommentResponse
+	build = func(i int) model.CommentResponse {
+		response := newCommentResponse(&comments[i], usernames[comments[i].UserID])
+		for _, child := range children[comments[i].ID] {
+			response.Replies = append(response.Replies, build(child))
+		}
+		return response
+	}
+
+	threads := make([]model.CommentResponse, 0, len(roots))
+	for _, i := range roots {
+		threads = append(threads, build(i))
+	}
+	return threads
+}
+
+// exportBookmarks добавляет к закладкам их комментарии для экспорта
+func (s *service) exportBookmarks(bookmarks []model.Bookmark) ([]model.ExportedBookmark, error) {
+	ids := make([]uint, len(bookmarks))
+	for i := range bookmarks {
+		ids[i] = bookmarks[i].ID
+	}
+	comments, err := s.repo.GetCommentsByBookmarkIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	usernames, err := s.commentAuthors(comments)
+	if err != nil {
+		return nil, err
+	}
+
+	byBookmark := make(map[uint][]model.ExportedComment)
+	for _, comment := range comments {
+		exported := model.ExportedComment{
+			ID:        comment.ID,
+			ParentID:  comment.ParentID,
+			Author:    usernames[comment.UserID],
+			Body:      comment.Body,
+			CreatedAt: comment.CreatedAt,
+			Deleted:   comment.Deleted,
+		}
+		if comment.Deleted {
+			exported.Author = ""
+		}
+		byBookmark[comment.BookmarkID] = append(byBookmark[comment.BookmarkID], exported)
+	}
+
+	exported := make([]model.ExportedBookmark, len(bookmarks))
+	for i := range bookmarks {
+		exported[i] = model.ExportedBookmark{Bookmark: bookmarks[i], Comments: byBookmark[bookmarks[i].ID]}
+		if exported[i].Comments == nil {
+			exported[i].Comments = []model.ExportedComment{}
+		}
+	}
+	return exported, nil
+}
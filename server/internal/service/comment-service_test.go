@@ -0,0 +1,42 @@
+package service
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{"start of text", "@alice have a look", []string{"alice"}},
+		{"inside text", "thanks @alice for this", []string{"alice"}},
+		{"trailing dot", "ask @alice.", []string{"alice"}},
+		{"trailing dots and dashes", "ask @alice...- now", []string{"alice"}},
+		{"trailing punctuation", "@alice, @bob! @carol: @dave?", []string{"alice", "bob", "carol", "dave"}},
+		{"in parentheses", "(@alice)", []string{"alice"}},
+		{"dots and dashes inside", "@alice_b.c-d", []string{"alice_b.c-d"}},
+		{"unicode letters", "@алиса привет", []string{"алиса"}},
+		{"repeated mention", "@alice and @alice again", []string{"alice"}},
+		{"order of appearance", "@carol @alice @bob", []string{"carol", "alice", "bob"}},
+		{"email address", "write to bob@example.com", nil},
+		{"glued to a word", "x@alice", nil},
+		{"double at", "@@alice", nil},
+		{"after a dot", "email.@alice", nil},
+		{"too short", "@al", nil},
+		{"too short in runes", "@ал", nil},
+		{"short after trimming", "@ab.", nil},
+		{"lone at", "@ alice", nil},
+		{"empty", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseMentions(tt.body); !slices.Equal(got, tt.want) {
+				t.Fatalf("parseMentions(%q) = %q, want %q", tt.body, got, tt.want)
+			}
+		})
+	}
+}
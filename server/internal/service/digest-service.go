@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+	"github.com/aerscs/theca-public/internal/utils/mail"
+)
+
+const (
+	// digestSectionSize сколько закладок показывается в каждом разделе подборки
+	digestSectionSize = 10
+	// digestMaxPeriod за какой срок максимум собирается подборка, если прошлая не отправлялась
+	digestMaxPeriod = 30 * 24 * time.Hour
+)
+
+// SendDigests отправляет еженедельную подборку пользователям, у которых наступило время рассылки
+func (s *service) SendDigests(ctx context.Context) {
+	const op = "service.SendDigests"
+	log := s.log.With("op", op)
+
+	users, err := s.repo.GetDigestSubscribers()
+	if err != nil {
+		log.Error("failed to get digest subscribers", "error", err)
+		return
+	}
+
+	now := time.Now()
+	sent := 0
+	for i := range users {
+		if ctx.Err() != nil {
+			return
+		}
+
+		user := &users[i]
+		scheduled := lastDigestTime(user, now)
+		if user.DigestSentAt != nil && !user.DigestSentAt.Before(scheduled) {
+			continue
+		}
+
+		// Другой инстанс мог уже отправить эту подборку
+		claimed, err := s.repo.ClaimDigest(user.ID, scheduled, now)
+		if err != nil || !claimed {
+			continue
+		}
+
+		if err := s.sendDigest(user, now); err != nil {
+			log.Error("failed to send digest", "error", err, "user_id", user.ID)
+			continue
+		}
+		sent++
+	}
+
+	if sent > 0 {
+		log.Debug("digests sent", "count", sent)
+	}
+}
+
+// lastDigestTime возвращает последнее наступившее время рассылки по расписанию пользователя
+func lastDigestTime(user *model.User, now time.Time) time.Time {
+	loc, err := time.LoadLocation(user.DigestTimezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	local := now.In(loc)
+	daysSince := (int(local.Weekday()) - user.DigestWeekday + 7) % 7
+	scheduled := time.Date(local.Year(), local.Month(), local.Day()-daysSince, user.DigestHour, 0, 0, 0, loc)
+	if scheduled.After(now) {
+		scheduled = scheduled.AddDate(0, 0, -7)
+	}
+	return scheduled
+}
+
+// sendDigest собирает новые, вернувшиеся во входящие и давно ждущие прочтения закладки.
+// Если показывать нечего, письмо не отправляется
+func (s *service) sendDigest(user *model.User, now time.Time) error {
+	since := now.Add(-7 * 24 * time.Hour)
+	if user.DigestSentAt != nil {
+		since = *user.DigestSentAt
+	}
+	if since.Before(now.Add(-digestMaxPeriod)) {
+		since = now.Add(-digestMaxPeriod)
+	}
+
+	created, err := s.repo.GetBookmarksCreatedSince(user.ID, since, digestSectionSize)
+	if err != nil {
+		return err
+	}
+	resurfaced, err := s.repo.GetResurfacedBookmarks(user.ID, since, digestSectionSize)
+	if err != nil {
+		return err
+	}
+	unread, unreadTotal, err := s.repo.GetOldestUnreadBookmarks(user.ID, since, digestSectionSize)
+	if err != nil {
+		return err
+	}
+
+	if len(created) == 0 && len(resurfaced) == 0 && len(unread) == 0 {
+		return nil
+	}
+
+	return s.mailer.SendDigestEmail(user.Email, &mail.Digest{
+		Username:       user.Username,
+		Language:       user.DigestLanguage,
+		UnsubscribeURL: s.cfg.PublicURL + "/v1/digest/unsubscribe/" + user.UnsubscribeToken,
+		New:            mailBookmarks(created),
+		Resurfaced:     mailBookmarks(resurfaced),
+		Unread:         mailBookmarks(unread),
+		UnreadTotal:    unreadTotal,
+	})
+}
+
+func mailBookmarks(bookmarks []model.Bookmark) []mail.Bookmark {
+	items := make([]mail.Bookmark, len(bookmarks))
+	for i := range bookmarks {
+		items[i] = mail.Bookmark{Title: bookmarks[i].Title, URL: safeLinkURL(bookmarks[i].URL)}
+	}
+	return items
+}
+
+// applyDigestSettings переносит настройки рассылки из запроса.
+// При включении рассылки создаётся токен отписки, а первая подборка уходит в ближайшее время по расписанию
+func applyDigestSettings(user *model.User, req *model.UpdateUserSettingsRequest) error {
+	if req.DigestTimezone != nil {
+		if _, err := time.LoadLocation(*req.DigestTimezone); err != nil || *req.DigestTimezone == "" {
+			return errors.New(errors.CodeInvalidRequest, "Unknown timezone")
+		}
+		user.DigestTimezone = *req.DigestTimezone
+	}
+	if req.DigestWeekday != nil {
+		user.DigestWeekday = *req.DigestWeekday
+	}
+	if req.DigestHour != nil {
+		user.DigestHour = *req.DigestHour
+	}
+	if req.DigestLanguage != nil {
+		user.DigestLanguage = *req.DigestLanguage
+	}
+
+	if req.DigestEnabled != nil && *req.DigestEnabled != user.DigestEnabled {
+		user.DigestEnabled = *req.DigestEnabled
+		if user.DigestEnabled {
+			now := time.Now()
+			user.DigestSentAt = &now
+		}
+	}
+
+	if user.DigestEnabled && user.UnsubscribeToken == "" {
+		token, err := generateToken()
+		if err != nil {
+			return errors.New(errors.CodeInternalError, "Failed to enable digest")
+		}
+		user.UnsubscribeToken = token
+	}
+
+	return nil
+}
+
+// UnsubscribeDigest отключает рассылку по токену из письма
+func (s *service) UnsubscribeDigest(token string) error {
+	const op = "service.UnsubscribeDigest"
+	log := s.log.With("op", op)
+
+	if token == "" {
+		return errors.New(errors.CodeNotFound, "Subscription not found")
+	}
+
+	user, err := s.repo.GetUserByUnsubscribeToken(token)
+	if err != nil {
+		return err
+	}
+
+	user.DigestEnabled = false
+	if err := s.repo.SaveUser(user); err != nil {
+		log.Error("failed to unsubscribe from digest", "error", err, "user_id", user.ID)
+		return err
+	}
+
+	log.Debug("user unsubscribed from digest", "user_id", user.ID)
+	return nil
+}
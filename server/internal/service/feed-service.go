@@ -0,0 +1,240 @@
+package service
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+	"github.com/aerscs/theca-public/internal/utils/feed"
+)
+
+// feedItemsLimit сколько последних закладок попадает в ленту
+const feedItemsLimit = 50
+
+// feedIDPrefix префикс постоянных идентификаторов лент и записей (RFC 4151).
+// Идентификаторы не содержат токен, поэтому не меняются при его смене
+const feedIDPrefix = "tag:theca,2025:"
+
+// ensureFeedToken возвращает токен лент пользователя, создавая его при первом запросе.
+// rotate выпускает новый токен: старые адреса календаря и лент закладок перестают работать
+func (s *service) ensureFeedToken(userID uint, rotate bool) (string, error) {
+	const op = "service.ensureFeedToken"
+	log := s.log.With("op", op)
+
+	user, err := s.repo.GetUserByID(userID)
+	if err != nil {
+		log.Error("failed to get user", "error", err, "user_id", userID)
+		return "", err
+	}
+
+	if user.FeedToken == "" || rotate {
+		token, err := generateToken()
+		if err != nil {
+			log.Error("failed to generate feed token", "error", err)
+			return "", errors.New(errors.CodeInternalError, "Failed to create feed")
+		}
+		user.FeedToken = token
+		if err := s.repo.SaveUser(user); err != nil {
+			log.Error("failed to save feed token", "error", err, "user_id", userID)
+			return "", err
+		}
+	}
+
+	return user.FeedToken, nil
+}
+
+// feedQueryString сохраняет ограничения ленты в адресе подписки
+func feedQueryString(query *model.FeedQuery) string {
+	values := url.Values{}
+	if query.FolderID != 0 {
+		values.Set("folder_id", strconv.FormatUint(uint64(query.FolderID), 10))
+	}
+	if query.Tag != "" {
+		values.Set("tag", query.Tag)
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	return "?" + values.Encode()
+}
+
+// GetFeedURLs возвращает адреса подписки на ленту закладок аккаунта, папки или тега
+func (s *service) GetFeedURLs(userID uint, query *model.FeedQuery, rotate bool) (*model.FeedURLsResponse, error) {
+	if query.FolderID != 0 {
+		if _, err := s.getUserFolder(userID, query.FolderID); err != nil {
+			return nil, err
+		}
+	}
+	if query.Tag != "" {
+		tag, ok := model.NormalizeTag(query.Tag)
+		if !ok {
+			return nil, errors.New(errors.CodeInvalidRequest, "Invalid tag")
+		}
+		query.Tag = tag
+	}
+
+	token, err := s.ensureFeedToken(userID, rotate)
+	if err != nil {
+		return nil, err
+	}
+
+	base := s.cfg.PublicURL + "/v1/feeds/" + token + "/"
+	suffix := feedQueryString(query)
+	return &model.FeedURLsResponse{
+		RSS:  base + model.FeedRSS + suffix,
+		Atom: base + model.FeedAtom + suffix,
+	}, nil
+}
+
+// GetBookmarkFeed формирует ленту закладок по токену пользователя
+func (s *service) GetBookmarkFeed(token, format string, query *model.FeedQuery) (*feed.Feed, error) {
+	const op = "service.GetBookmarkFeed"
+	log := s.log.With("op", op)
+
+	if token == "" || !isFeedFormat(format) {
+		return nil, errors.New(errors.CodeNotFound, "Feed not found")
+	}
+
+	user, err := s.repo.GetUserByFeedToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &feed.Feed{
+		ID:          fmt.Sprintf("%suser/%d", feedIDPrefix, user.ID),
+		Self:        s.cfg.PublicURL + "/v1/feeds/" + token + "/" + format + feedQueryString(query),
+		Title:       "Theca: " + user.Username,
+		Description: "Bookmarks saved by " + user.Username,
+		Link:        s.cfg.PublicURL,
+	}
+
+	var bookmarks []model.Bookmark
+	switch {
+	case query.FolderID != 0:
+		folders, err := s.repo.GetFolders(user.ID)
+		if err != nil {
+			log.Error("failed to get folders", "error", err, "user_id", user.ID)
+			return nil, err
+		}
+		folder := findFolder(folders, query.FolderID)
+		if folder == nil {
+			return nil, errors.New(errors.CodeNotFound, "Feed not found")
+		}
+		out.ID += fmt.Sprintf("/folder/%d", folder.ID)
+		out.Title += " / " + folder.Name
+		out.Description = "Bookmarks in the folder " + folder.Name
+
+		bookmarks, err = s.repo.GetBookmarksInFolders(user.ID, folderSubtree(folders, folder.ID))
+		if err != nil {
+			log.Error("failed to get folder bookmarks", "error", err, "user_id", user.ID)
+			return nil, err
+		}
+	case query.Tag != "":
+		tag, ok := model.NormalizeTag(query.Tag)
+		if !ok {
+			return nil, errors.New(errors.CodeNotFound, "Feed not found")
+		}
+		out.ID += "/tag/" + url.PathEscape(tag)
+		out.Title += " #" + tag
+		out.Description = "Bookmarks tagged " + tag
+
+		bookmarks, err = s.repo.GetFilteredBookmarks(user.ID, &model.BookmarkListFilter{Tag: tag})
+		if err != nil {
+			log.Error("failed to get tagged bookmarks", "error", err, "user_id", user.ID)
+			return nil, err
+		}
+	default:
+		bookmarks, err = s.repo.GetFilteredBookmarks(user.ID, &model.BookmarkListFilter{})
+		if err != nil {
+			log.Error("failed to get bookmarks", "error", err, "user_id", user.ID)
+			return nil, err
+		}
+	}
+
+	fillFeedItems(out, bookmarks)
+	return out, nil
+}
+
+// GetCollectionFeed формирует ленту публичной коллекции; она доступна без токена
+func (s *service) GetCollectionFeed(collectionID uint, format string) (*feed.Feed, error) {
+	const op = "service.GetCollectionFeed"
+	log := s.log.With("op", op)
+
+	if !isFeedFormat(format) {
+		return nil, errors.New(errors.CodeNotFound, "Feed not found")
+	}
+
+	collection, err := s.repo.GetCollectionByID(collectionID)
+	if err != nil || !collection.IsPublic {
+		return nil, errors.New(errors.CodeNotFound, "Feed not found")
+	}
+
+	bookmarks, err := s.repo.GetCollectionBookmarks(collectionID)
+	if err != nil {
+		log.Error("failed to get collection bookmarks", "error", err, "collection_id", collectionID)
+		return nil, err
+	}
+
+	out := &feed.Feed{
+		ID:          fmt.Sprintf("%scollection/%d", feedIDPrefix, collection.ID),
+		Self:        fmt.Sprintf("%s/v1/collections/%d/feed/%s", s.cfg.PublicURL, collection.ID, format),
+		Title:       "Theca: " + collection.Name,
+		Description: collection.Description,
+		Link:        s.cfg.PublicURL,
+	}
+	if out.Description == "" {
+		out.Description = "Bookmarks in the collection " + collection.Name
+	}
+
+	fillFeedItems(out, bookmarks)
+	return out, nil
+}
+
+func isFeedFormat(format string) bool {
+	return format == model.FeedRSS || format == model.FeedAtom
+}
+
+func findFolder(folders []model.Folder, folderID uint) *model.Folder {
+	for i := range folders {
+		if folders[i].ID == folderID {
+			return &folders[i]
+		}
+	}
+	return nil
+}
+
+// fillFeedItems добавляет в ленту последние закладки; время обновления ленты — самое позднее изменение среди них,
+// у пустой ленты — текущее. Закладки приходят отсортированными от новых к старым
+func fillFeedItems(out *feed.Feed, bookmarks []model.Bookmark) {
+	if len(bookmarks) > feedItemsLimit {
+		bookmarks = bookmarks[:feedItemsLimit]
+	}
+
+	out.Items = make([]feed.Item, 0, len(bookmarks))
+	for i := range bookmarks {
+		bookmark := &bookmarks[i]
+		title := bookmark.Title
+		if title == "" {
+			title = bookmark.URL
+		}
+		out.Items = append(out.Items, feed.Item{
+			ID:          fmt.Sprintf("%sbookmark/%d", feedIDPrefix, bookmark.ID),
+			Title:       title,
+			URL:         safeLinkURL(bookmark.URL),
+			Description: bookmark.Description,
+			Saved:       bookmark.CreatedAt,
+		})
+		if bookmark.UpdatedAt.After(out.Updated) {
+			out.Updated = bookmark.UpdatedAt
+		}
+		if bookmark.CreatedAt.After(out.Updated) {
+			out.Updated = bookmark.CreatedAt
+		}
+	}
+	if out.Updated.IsZero() {
+		out.Updated = time.Now()
+	}
+}
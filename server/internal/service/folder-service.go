@@ -0,0 +1,249 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+)
+
+// GetFolders возвращает личные папки пользователя или папки рабочего пространства, если workspaceID не 0
+func (s *service) GetFolders(userID, workspaceID uint) ([]model.Folder, error) {
+	return s.scopeFolders(userID, workspaceID)
+}
+
+// scopeFolders возвращает личные папки или папки пространства, проверяя участие в нём
+func (s *service) scopeFolders(userID, workspaceID uint) ([]model.Folder, error) {
+	if workspaceID == 0 {
+		return s.repo.GetFolders(userID)
+	}
+	if _, err := s.workspaceRole(userID, workspaceID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetWorkspaceFolders(workspaceID)
+}
+
+// getUserFolder возвращает папку, только если это личная папка пользователя
+func (s *service) getUserFolder(userID, folderID uint) (*model.Folder, error) {
+	folder, err := s.repo.GetFolderByID(folderID)
+	if err != nil {
+		return nil, err
+	}
+	if folder.UserID != userID || folder.WorkspaceID != nil {
+		return nil, errors.New(errors.CodeForbidden, "Folder doesn't belong to user")
+	}
+	return folder, nil
+}
+
+// getEditableFolder возвращает личную папку пользователя или папку пространства, в котором он состоит
+func (s *service) getEditableFolder(userID, folderID uint) (*model.Folder, error) {
+	folder, err := s.repo.GetFolderByID(folderID)
+	if err != nil {
+		return nil, err
+	}
+	if folder.WorkspaceID != nil {
+		if _, err := s.workspaceRole(userID, *folder.WorkspaceID); err != nil {
+			return nil, err
+		}
+		return folder, nil
+	}
+	if folder.UserID != userID {
+		return nil, errors.New(errors.CodeForbidden, "Folder doesn't belong to user")
+	}
+	return folder, nil
+}
+
+// resolveFolder проверяет, что папка из того же места, что и закладка: личная папка пользователя
+// или папка того же пространства. 0 и nil означают верхний уровень
+func (s *service) resolveFolder(userID uint, workspaceID, folderID *uint) (*uint, error) {
+	if folderID == nil || *folderID == 0 {
+		return nil, nil
+	}
+	if workspaceID == nil {
+		if _, err := s.getUserFolder(userID, *folderID); err != nil {
+			return nil, err
+		}
+		return folderID, nil
+	}
+
+	folder, err := s.repo.GetFolderByID(*folderID)
+	if err != nil {
+		return nil, err
+	}
+	if folder.WorkspaceID == nil || *folder.WorkspaceID != *workspaceID {
+		return nil, errors.New(errors.CodeForbidden, "Folder belongs to another workspace")
+	}
+	return folderID, nil
+}
+
+func (s *service) CreateFolder(userID uint, req *model.FolderRequest) (*model.Folder, error) {
+	const op = "service.CreateFolder"
+	log := s.log.With("op", op)
+
+	workspaceID, err := s.resolveWorkspace(userID, req.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	parentID, err := s.resolveFolder(userID, workspaceID, req.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	folder := &model.Folder{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		ParentID:    parentID,
+		Name:        req.Name,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.repo.SaveFolder(folder); err != nil {
+		log.Error("failed to create folder", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	log.Debug("folder created", "folder_id", folder.ID, "user_id", userID)
+	return folder, nil
+}
+
+func (s *service) UpdateFolder(userID, folderID uint, req *model.FolderRequest) (*model.Folder, error) {
+	const op = "service.UpdateFolder"
+	log := s.log.With("op", op)
+
+	folder, err := s.getEditableFolder(userID, folderID)
+	if err != nil {
+		return nil, err
+	}
+
+	parentID, err := s.resolveFolder(userID, folder.WorkspaceID, req.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	if parentID != nil {
+		// Папку нельзя переместить внутрь неё самой или её подпапок
+		var workspaceID uint
+		if folder.WorkspaceID != nil {
+			workspaceID = *folder.WorkspaceID
+		}
+		folders, err := s.scopeFolders(userID, workspaceID)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range folderSubtree(folders, folder.ID) {
+			if id == *parentID {
+				return nil, errors.New(errors.CodeInvalidRequest, "Folder cannot be moved into itself")
+			}
+		}
+	}
+
+	folder.Name = req.Name
+	folder.ParentID = parentID
+	folder.UpdatedAt = time.Now()
+	if err := s.repo.SaveFolder(folder); err != nil {
+		log.Error("failed to update folder", "error", err, "folder_id", folderID)
+		return nil, err
+	}
+
+	log.Debug("folder updated", "folder_id", folderID, "user_id", userID)
+	return folder, nil
+}
+
+// DeleteFolder удаляет папку; её закладки и подпапки переносятся в родительскую папку.
+// Папку пространства удаляет её создатель или администратор пространства
+func (s *service) DeleteFolder(userID, folderID uint) error {
+	const op = "service.DeleteFolder"
+	log := s.log.With("op", op)
+
+	folder, err := s.getEditableFolder(userID, folderID)
+	if err != nil {
+		return err
+	}
+	if folder.WorkspaceID != nil && folder.UserID != userID {
+		if err := s.requireWorkspaceAdmin(userID, *folder.WorkspaceID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.DeleteFolder(folder); err != nil {
+		log.Error("failed to delete folder", "error", err, "folder_id", folderID)
+		return err
+	}
+
+	log.Debug("folder deleted", "folder_id", folderID, "user_id", userID)
+	return nil
+}
+
+// folderSubtree возвращает идентификаторы папки и всех её подпапок
+func folderSubtree(folders []model.Folder, rootID uint) []uint {
+	children := make(map[uint][]uint, len(folders))
+	for _, folder := range folders {
+		if folder.ParentID != nil {
+			children[*folder.ParentID] = append(children[*folder.ParentID], folder.ID)
+		}
+	}
+
+	ids := []uint{rootID}
+	seen := map[uint]bool{rootID: true}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range children[ids[i]] {
+			if !seen[child] {
+				seen[child] = true
+				ids = append(ids, child)
+			}
+		}
+	}
+	return ids
+}
+
+// GetTags возвращает теги личных закладок или закладок пространства с их количеством, самые частые первыми
+func (s *service) GetTags(userID, workspaceID uint) ([]model.TagResponse, error) {
+	const op = "service.GetTags"
+	log := s.log.With("op", op)
+
+	bookmarks, err := s.scopeBookmarks(userID, workspaceID)
+	if err != nil {
+		log.Error("failed to get bookmarks", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for i := range bookmarks {
+		for _, tag := range bookmarks[i].Tags {
+			counts[tag]++
+		}
+	}
+
+	tags := make([]model.TagResponse, 0, len(counts))
+	for name, count := range counts {
+		tags = append(tags, model.TagResponse{Name: name, Count: count})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+		return tags[i].Name < tags[j].Name
+	})
+
+	return tags, nil
+}
+
+// applyBookmarkPlacement проверяет и задаёт папку и теги закладки
+func (s *service) applyBookmarkPlacement(bookmark *model.Bookmark, folderID *uint, tags *[]string) error {
+	if folderID != nil {
+		resolved, err := s.resolveFolder(bookmark.UserID, bookmark.WorkspaceID, folderID)
+		if err != nil {
+			return err
+		}
+		bookmark.FolderID = resolved
+	}
+	if tags != nil {
+		normalized, ok := model.NormalizeTags(*tags)
+		if !ok {
+			return errors.New(errors.CodeInvalidRequest, "Invalid tags")
+		}
+		bookmark.Tags = normalized
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	neturl "net/url"
+	"strings"
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+	"github.com/aerscs/theca-public/internal/utils/parsers"
+)
+
+// linkCheckRetryDelay через сколько повторить проверку, если сайт попросил подождать или его предохранитель сработал
+const linkCheckRetryDelay = time.Hour
+
+// CheckDueBookmarks проверяет очередную порцию закладок, которые давно не проверялись.
+// Запросы идут последовательно, а общий HTTP-клиент дополнительно ограничивает частоту запросов к хосту.
+// Если хост попросил подождать, остальные его закладки в порции откладываются без запросов
+func (s *service) CheckDueBookmarks(ctx context.Context) {
+	const op = "service.CheckDueBookmarks"
+	log := s.log.With("op", op)
+
+	checkedBefore := time.Now().Add(-time.Duration(s.cfg.LinkCheckIntervalHours) * time.Hour)
+	bookmarks, err := s.repo.GetBookmarksDueForCheck(checkedBefore, s.cfg.LinkCheckBatchSize)
+	if err != nil {
+		log.Error("failed to get bookmarks due for check", "error", err)
+		return
+	}
+
+	busyHosts := make(map[string]bool)
+	for i := range bookmarks {
+		if ctx.Err() != nil {
+			return
+		}
+		host := linkHost(bookmarks[i].URL)
+		if busyHosts[host] {
+			if err := s.postponeBookmarkCheck(&bookmarks[i]); err != nil {
+				log.Error("failed to postpone bookmark check", "error", err, "bookmark_id", bookmarks[i].ID)
+			}
+			continue
+		}
+		check, err := s.checkBookmark(ctx, &bookmarks[i])
+		if err != nil {
+			log.Error("failed to check bookmark", "error", err, "bookmark_id", bookmarks[i].ID)
+		}
+		if check != nil && check.RetryLater() && host != "" {
+			busyHosts[host] = true
+		}
+	}
+
+	if len(bookmarks) > 0 {
+		log.Debug("bookmarks checked", "count", len(bookmarks))
+	}
+}
+
+// CheckBookmarkNow проверяет ссылку закладки вне очереди. Проверка может заменить URL по настройке автора,
+// поэтому запускать её может только он
+func (s *service) CheckBookmarkNow(userID, bookmarkID uint) (*model.Bookmark, error) {
+	const op = "service.CheckBookmarkNow"
+	log := s.log.With("op", op)
+
+	bookmark, err := s.getBookmark(userID, bookmarkID, accessAuthor)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.checkBookmark(context.Background(), bookmark); err != nil {
+		log.Error("failed to check bookmark", "error", err, "bookmark_id", bookmarkID)
+		return nil, err
+	}
+
+	return bookmark, nil
+}
+
+// ScheduleBookmarksCheck ставит все закладки пользователя в начало очереди проверки
+func (s *service) ScheduleBookmarksCheck(userID uint) (int64, error) {
+	return s.repo.ScheduleBookmarksCheck(userID)
+}
+
+func (s *service) GetBookmarksHealth(userID uint, filter *model.BookmarkHealthFilter) ([]model.Bookmark, error) {
+	const op = "service.GetBookmarksHealth"
+	log := s.log.With("op", op)
+
+	var statuses []string
+	switch filter.Status {
+	case "":
+		statuses = []string{model.HealthStatusBroken, model.HealthStatusRedirected}
+	case "all":
+	case model.HealthStatusOK, model.HealthStatusRedirected, model.HealthStatusBroken, model.HealthStatusUnknown:
+		statuses = []string{filter.Status}
+	default:
+		return nil, errors.New(errors.CodeInvalidRequest, "Invalid status filter")
+	}
+	if filter.WorkspaceID != 0 {
+		if _, err := s.workspaceRole(userID, filter.WorkspaceID); err != nil {
+			return nil, err
+		}
+	}
+
+	bookmarks, err := s.repo.GetBookmarksHealth(userID, filter.WorkspaceID, statuses, filter.MinFailures)
+	if err != nil {
+		log.Error("failed to get bookmarks health", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	return bookmarks, nil
+}
+
+// checkBookmark проверяет ссылку и сохраняет результат. Возвращает nil вместо результата,
+// если проверка прервана остановкой сервиса
+func (s *service) checkBookmark(ctx context.Context, bookmark *model.Bookmark) (*parsers.LinkCheck, error) {
+	check := parsers.CheckLink(ctx, bookmark.URL)
+	if check.Err != nil && ctx.Err() != nil {
+		// Проверка прервана остановкой сервиса, результат не сохраняем
+		return nil, nil
+	}
+	if check.RetryLater() {
+		// Сбоем это не считаем: состояние и число ошибок не меняются, закладка уходит в конец очереди до повтора
+		return check, s.postponeBookmarkCheck(bookmark)
+	}
+
+	applyLinkCheck(bookmark, check)
+	suggestPermanentURL(bookmark, check)
+	if err := s.dropUndoneSuggestion(bookmark); err != nil {
+		return check, err
+	}
+	if err := s.repo.UpdateBookmarkHealth(bookmark); err != nil {
+		return check, err
+	}
+
+	if bookmark.SuggestedURL != "" && s.autoRewriteEnabled(bookmark.UserID) {
+		if _, err := s.rewriteBookmarkURL(bookmark, true); err != nil {
+			return check, err
+		}
+	}
+
+	return check, nil
+}
+
+// postponeBookmarkCheck откладывает проверку закладки на linkCheckRetryDelay. Время последней проверки
+// сдвигается так, чтобы закладка снова стала в очередь через это время
+func (s *service) postponeBookmarkCheck(bookmark *model.Bookmark) error {
+	checkedAt := time.Now().Add(linkCheckRetryDelay - time.Duration(s.cfg.LinkCheckIntervalHours)*time.Hour)
+	return s.repo.PostponeBookmarkCheck(bookmark.ID, checkedAt)
+}
+
+// linkHost возвращает хост ссылки в нижнем регистре или пустую строку, если адрес не разбирается
+func linkHost(rawURL string) string {
+	u, err := neturl.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+// applyLinkCheck переводит результат запроса в состояние ссылки
+func applyLinkCheck(bookmark *model.Bookmark, check *parsers.LinkCheck) {
+	now := time.Now()
+	bookmark.LastCheckedAt = &now
+	bookmark.HTTPStatus = check.StatusCode
+	bookmark.FinalURL = check.FinalURL
+
+	switch status := check.StatusCode; {
+	case check.Err != nil:
+		bookmark.HealthStatus = model.HealthStatusBroken
+		bookmark.CheckFailures++
+	case status >= 200 && status < 400:
+		bookmark.HealthStatus = model.HealthStatusOK
+		if !sameLocation(bookmark.URL, check.FinalURL) {
+			bookmark.HealthStatus = model.HealthStatusRedirected
+		}
+		bookmark.CheckFailures = 0
+	case status == 401 || status == 403 || status == 999:
+		// Защита от ботов не говорит о том, что страница пропала
+		bookmark.HealthStatus = model.HealthStatusUnknown
+	default:
+		bookmark.HealthStatus = model.HealthStatusBroken
+		bookmark.CheckFailures++
+	}
+}
+
+// sameLocation сравнивает адреса без учёта схемы, "www.", завершающего слэша и фрагмента
+func sameLocation(a, b string) bool {
+	ua, errA := neturl.Parse(a)
+	ub, errB := neturl.Parse(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+
+	normalize := func(u *neturl.URL) string {
+		host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+		return host + strings.TrimSuffix(u.EscapedPath(), "/") + "?" + u.RawQuery
+	}
+	return normalize(ua) == normalize(ub)
+}
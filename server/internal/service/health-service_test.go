@@ -0,0 +1,112 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/httpclient"
+	"github.com/aerscs/theca-public/internal/utils/parsers"
+)
+
+func TestSameLocation(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"https://example.com/page", "https://example.com/page", true},
+		{"http://example.com/page", "https://example.com/page", true},
+		{"https://www.example.com/page", "https://example.com/page", true},
+		{"https://example.com/page/", "https://example.com/page", true},
+		{"https://example.com/", "https://example.com", true},
+		{"https://example.com/page#intro", "https://example.com/page", true},
+		{"https://EXAMPLE.com/page", "https://example.com/page", true},
+		{"https://example.com/page?a=1", "https://example.com/page?a=1", true},
+		{"https://example.com/page?a=1", "https://example.com/page?a=2", false},
+		{"https://example.com/page?a=1", "https://example.com/page", false},
+		{"https://example.com/Page", "https://example.com/page", false},
+		{"https://example.com/old", "https://example.com/new", false},
+		{"https://example.com/page", "https://example.org/page", false},
+		{"https://blog.example.com/page", "https://example.com/page", false},
+		{"https://example.com:8080/page", "https://example.com/page", false},
+		{"%zz", "%zz", true},
+		{"%zz", "https://example.com", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			if got := sameLocation(tt.a, tt.b); got != tt.want {
+				t.Fatalf("sameLocation(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyLinkCheck(t *testing.T) {
+	tests := []struct {
+		name         string
+		check        parsers.LinkCheck
+		failures     int
+		wantStatus   string
+		wantFailures int
+	}{
+		{"ok", parsers.LinkCheck{StatusCode: 200, FinalURL: "https://example.com/page"}, 2, model.HealthStatusOK, 0},
+		{"redirected", parsers.LinkCheck{StatusCode: 200, FinalURL: "https://example.com/new"}, 2, model.HealthStatusRedirected, 0},
+		{"not found", parsers.LinkCheck{StatusCode: 404}, 2, model.HealthStatusBroken, 3},
+		{"server error", parsers.LinkCheck{StatusCode: 500}, 0, model.HealthStatusBroken, 1},
+		{"network error", parsers.LinkCheck{Err: errors.New("dial failed")}, 0, model.HealthStatusBroken, 1},
+		{"forbidden", parsers.LinkCheck{StatusCode: 403}, 2, model.HealthStatusUnknown, 2},
+		{"bot protection", parsers.LinkCheck{StatusCode: 999}, 1, model.HealthStatusUnknown, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bookmark := &model.Bookmark{URL: "https://example.com/page", CheckFailures: tt.failures}
+			applyLinkCheck(bookmark, &tt.check)
+			if bookmark.HealthStatus != tt.wantStatus || bookmark.CheckFailures != tt.wantFailures {
+				t.Fatalf("status %q, failures %d; want %q, %d", bookmark.HealthStatus, bookmark.CheckFailures, tt.wantStatus, tt.wantFailures)
+			}
+			if bookmark.LastCheckedAt == nil {
+				t.Fatal("last_checked_at not set")
+			}
+		})
+	}
+}
+
+func TestLinkCheckRetryLater(t *testing.T) {
+	tests := []struct {
+		name  string
+		check parsers.LinkCheck
+		want  bool
+	}{
+		{"rate limited", parsers.LinkCheck{StatusCode: 429}, true},
+		{"circuit open", parsers.LinkCheck{Err: httpclient.ErrCircuitOpen}, true},
+		{"wrapped circuit open", parsers.LinkCheck{Err: errors.Join(errors.New("head"), httpclient.ErrCircuitOpen)}, true},
+		{"server error", parsers.LinkCheck{StatusCode: 503}, false},
+		{"network error", parsers.LinkCheck{Err: errors.New("dial failed")}, false},
+		{"ok", parsers.LinkCheck{StatusCode: 200}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.check.RetryLater(); got != tt.want {
+				t.Fatalf("RetryLater() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkHost(t *testing.T) {
+	tests := map[string]string{
+		"https://Example.com/page":      "example.com",
+		"https://www.example.com:8443/": "www.example.com",
+		"http://[::1]:8080/":            "::1",
+		"not a url":                     "",
+		"%zz":                           "",
+	}
+	for rawURL, want := range tests {
+		if got := linkHost(rawURL); got != want {
+			t.Errorf("linkHost(%q) = %q, want %q", rawURL, got, want)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+	"github.com/aerscs/theca-public/internal/utils/parsers"
+)
+
+// IsAdmin проверяет, есть ли у пользователя права администратора
+func (s *service) IsAdmin(userID uint) (bool, error) {
+	const op = "service.IsAdmin"
+	log := s.log.With("op", op)
+
+	user, err := s.repo.GetUserByID(userID)
+	if err != nil {
+		log.Error("failed to get user", "error", err, "user_id", userID)
+		return false, err
+	}
+
+	return user.IsAdmin, nil
+}
+
+// SeedIconOverrides заполняет таблицу переопределений встроенным списком при первом запуске.
+// Вызывается один раз при старте, чтобы удалённые администратором записи не возвращались
+func (s *service) SeedIconOverrides() error {
+	const op = "service.SeedIconOverrides"
+	log := s.log.With("op", op)
+
+	if err := s.repo.SeedIconOverrides(parsers.DefaultIconOverrides()); err != nil {
+		log.Error("failed to seed icon overrides", "error", err)
+		return err
+	}
+	return nil
+}
+
+// ReloadIconOverrides загружает переопределения иконок из базы в парсер
+func (s *service) ReloadIconOverrides() error {
+	const op = "service.ReloadIconOverrides"
+	log := s.log.With("op", op)
+
+	overrides, err := s.repo.GetIconOverrides()
+	if err != nil {
+		log.Error("failed to get icon overrides", "error", err)
+		return err
+	}
+
+	parsers.SetIconOverrides(overrides)
+	log.Debug("icon overrides reloaded", "count", len(overrides))
+	return nil
+}
+
+func (s *service) GetIconOverrides() ([]model.IconOverride, error) {
+	return s.repo.GetIconOverrides()
+}
+
+func (s *service) CreateIconOverride(req *model.IconOverrideRequest) (*model.IconOverride, error) {
+	const op = "service.CreateIconOverride"
+	log := s.log.With("op", op)
+
+	pattern, ok := parsers.NormalizeIconPattern(req.Pattern)
+	if !ok {
+		return nil, errors.New(errors.CodeInvalidRequest, "Invalid domain pattern")
+	}
+
+	override := &model.IconOverride{Pattern: pattern, IconURL: req.IconURL}
+	if err := s.repo.SaveIconOverride(override); err != nil {
+		log.Error("failed to create icon override", "error", err, "pattern", pattern)
+		return nil, err
+	}
+
+	s.applyIconOverrideChange(pattern)
+	log.Info("icon override created", "id", override.ID, "pattern", pattern)
+	return override, nil
+}
+
+func (s *service) UpdateIconOverride(id uint, req *model.IconOverrideRequest) (*model.IconOverride, error) {
+	const op = "service.UpdateIconOverride"
+	log := s.log.With("op", op)
+
+	pattern, ok := parsers.NormalizeIconPattern(req.Pattern)
+	if !ok {
+		return nil, errors.New(errors.CodeInvalidRequest, "Invalid domain pattern")
+	}
+
+	override, err := s.repo.GetIconOverrideByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	oldPattern := override.Pattern
+	override.Pattern = pattern
+	override.IconURL = req.IconURL
+	if err := s.repo.SaveIconOverride(override); err != nil {
+		log.Error("failed to update icon override", "error", err, "id", id)
+		return nil, err
+	}
+
+	s.applyIconOverrideChange(oldPattern, pattern)
+	log.Info("icon override updated", "id", id, "pattern", pattern)
+	return override, nil
+}
+
+func (s *service) DeleteIconOverride(id uint) error {
+	const op = "service.DeleteIconOverride"
+	log := s.log.With("op", op)
+
+	override, err := s.repo.GetIconOverrideByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteIconOverride(id); err != nil {
+		log.Error("failed to delete icon override", "error", err, "id", id)
+		return err
+	}
+
+	s.applyIconOverrideChange(override.Pattern)
+	log.Info("icon override deleted", "id", id, "pattern", override.Pattern)
+	return nil
+}
+
+// applyIconOverrideChange перезагружает переопределения и сбрасывает кэш иконок
+// затронутых доменов, чтобы новая иконка подхватилась при следующем запросе
+func (s *service) applyIconOverrideChange(patterns ...string) {
+	const op = "service.applyIconOverrideChange"
+	log := s.log.With("op", op)
+
+	if err := s.ReloadIconOverrides(); err != nil {
+		log.Error("failed to reload icon overrides", "error", err)
+	}
+
+	deleted, err := s.cache.InvalidateFavicons(context.Background(), func(host string) bool {
+		for _, pattern := range patterns {
+			if parsers.MatchIconPattern(pattern, host) {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		log.Error("failed to invalidate favicon cache", "error", err, "patterns", patterns)
+		return
+	}
+
+	log.Debug("favicon cache invalidated", "patterns", patterns, "deleted", deleted)
+}
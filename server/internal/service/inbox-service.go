@@ -0,0 +1,260 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+	"github.com/aerscs/theca-public/internal/utils/mail"
+)
+
+// maxPendingFromSender ограничивает число непринятых закладок от одного отправителя во входящих получателя
+const maxPendingFromSender = 50
+
+// SendBookmark отправляет копию закладки во входящие другого пользователя и уведомляет его письмом.
+// Отправить можно любую закладку, которая видна отправителю
+func (s *service) SendBookmark(userID, bookmarkID uint, req *model.SendBookmarkRequest) error {
+	const op = "service.SendBookmark"
+	log := s.log.With("op", op)
+
+	bookmark, err := s.getBookmark(userID, bookmarkID, accessRead)
+	if err != nil {
+		return err
+	}
+	recipient, err := s.repo.GetUserByUsername(req.Username)
+	if err != nil || !recipient.IsVerified {
+		return errors.New(errors.CodeNotFound, "User not found")
+	}
+	if recipient.ID == userID {
+		return errors.New(errors.CodeInvalidRequest, "You can't send a bookmark to yourself")
+	}
+	blocked, err := s.repo.IsUserBlocked(recipient.ID, userID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return errors.New(errors.CodeForbidden, "The user doesn't accept bookmarks from you")
+	}
+
+	duplicates, err := s.repo.CountPendingInboxItems(userID, recipient.ID, bookmark.URL)
+	if err != nil {
+		return err
+	}
+	if duplicates > 0 {
+		return errors.New(errors.CodeDataConflict, "This bookmark is already waiting in the user's inbox")
+	}
+	pending, err := s.repo.CountPendingInboxItems(userID, recipient.ID, "")
+	if err != nil {
+		return err
+	}
+	if pending >= maxPendingFromSender {
+		return errors.New(errors.CodeInvalidRequest, "Too many of your bookmarks are waiting in the user's inbox")
+	}
+	sender, err := s.repo.GetUserByID(userID)
+	if err != nil {
+		log.Error("failed to get sender", "error", err, "user_id", userID)
+		return err
+	}
+
+	item := &model.InboxItem{
+		SenderID:    userID,
+		RecipientID: recipient.ID,
+		Title:       bookmark.Title,
+		URL:         bookmark.URL,
+		Description: bookmark.Description,
+		Favicon:     bookmark.Favicon,
+		Icons:       bookmark.Icons,
+		ImageURL:    bookmark.ImageURL,
+		SiteName:    bookmark.SiteName,
+		Kind:        bookmark.Kind,
+		Note:        strings.TrimSpace(req.Note),
+		Status:      model.InboxPending,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.repo.CreateInboxItem(item); err != nil {
+		return err
+	}
+
+	email := recipient.Email
+	message := &mail.InboxBookmark{
+		Sender: sender.Username,
+		Title:  item.Title,
+		Note:   item.Note,
+		URL:    s.cfg.ClientURL,
+	}
+	go func() {
+		if err := s.mailer.SendInboxEmail(email, message); err != nil {
+			log.Error("failed to send inbox email", "error", err, "item_id", item.ID)
+		}
+	}()
+
+	log.Debug("bookmark sent", "item_id", item.ID, "sender_id", userID, "recipient_id", recipient.ID)
+	return nil
+}
+
+// GetInbox возвращает закладки во входящих пользователя, которые он ещё не принял и не отклонил
+func (s *service) GetInbox(userID uint) ([]model.InboxItemResponse, error) {
+	const op = "service.GetInbox"
+	log := s.log.With("op", op)
+
+	items, err := s.repo.GetPendingInboxItems(userID)
+	if err != nil {
+		log.Error("failed to get inbox", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	senderIDs := make([]uint, 0, len(items))
+	for _, item := range items {
+		senderIDs = append(senderIDs, item.SenderID)
+	}
+	usernames, err := s.usernamesByID(senderIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.InboxItemResponse, 0, len(items))
+	for _, item := range items {
+		responses = append(responses, model.InboxItemResponse{
+			ID:          item.ID,
+			SenderID:    item.SenderID,
+			Sender:      usernames[item.SenderID],
+			Title:       item.Title,
+			URL:         item.URL,
+			Description: item.Description,
+			Favicon:     item.Favicon,
+			ImageURL:    item.ImageURL,
+			SiteName:    item.SiteName,
+			Kind:        item.Kind,
+			Note:        item.Note,
+			CreatedAt:   item.CreatedAt,
+		})
+	}
+	return responses, nil
+}
+
+// getPendingInboxItem возвращает ещё не обработанную закладку из входящих пользователя
+func (s *service) getPendingInboxItem(userID, itemID uint) (*model.InboxItem, error) {
+	item, err := s.repo.GetInboxItemByID(itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item.RecipientID != userID || item.Status != model.InboxPending {
+		return nil, errors.New(errors.CodeNotFound, "Inbox item not found")
+	}
+	return item, nil
+}
+
+// AcceptInboxItem сохраняет закладку из входящих в личные закладки пользователя как непрочитанную
+func (s *service) AcceptInboxItem(userID, itemID uint) (*model.Bookmark, error) {
+	const op = "service.AcceptInboxItem"
+	log := s.log.With("op", op)
+
+	item, err := s.getPendingInboxItem(userID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	bookmark := &model.Bookmark{
+		UserID:      userID,
+		Title:       item.Title,
+		URL:         item.URL,
+		Description: item.Description,
+		Favicon:     item.Favicon,
+		Icons:       item.Icons,
+		ImageURL:    item.ImageURL,
+		SiteName:    item.SiteName,
+		Kind:        item.Kind,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	bookmark.SetState(model.StateUnread, now)
+
+	if err := s.repo.AcceptInboxItem(item, bookmark, now); err != nil {
+		return nil, err
+	}
+	s.recordActivity(newActivity(userID, model.ActivityAdded, bookmark))
+
+	log.Debug("inbox item accepted", "item_id", item.ID, "bookmark_id", bookmark.ID, "user_id", userID)
+	return bookmark, nil
+}
+
+func (s *service) DismissInboxItem(userID, itemID uint) error {
+	item, err := s.getPendingInboxItem(userID, itemID)
+	if err != nil {
+		return err
+	}
+	return s.repo.DismissInboxItem(item.ID, time.Now())
+}
+
+// GetBlockedUsers возвращает пользователей, от которых пользователь не принимает закладки
+func (s *service) GetBlockedUsers(userID uint) ([]model.BlockedUserResponse, error) {
+	blocks, err := s.repo.GetUserBlocks(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	blockedIDs := make([]uint, 0, len(blocks))
+	for _, block := range blocks {
+		blockedIDs = append(blockedIDs, block.BlockedUserID)
+	}
+	usernames, err := s.usernamesByID(blockedIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.BlockedUserResponse, 0, len(blocks))
+	for _, block := range blocks {
+		responses = append(responses, model.BlockedUserResponse{
+			UserID:    block.BlockedUserID,
+			Username:  usernames[block.BlockedUserID],
+			BlockedAt: block.CreatedAt,
+		})
+	}
+	return responses, nil
+}
+
+// BlockUser запрещает пользователю отправлять закладки во входящие. Уже отправленные им закладки отклоняются
+func (s *service) BlockUser(userID uint, req *model.BlockUserRequest) (*model.BlockedUserResponse, error) {
+	const op = "service.BlockUser"
+	log := s.log.With("op", op)
+
+	user, err := s.repo.GetUserByUsername(req.Username)
+	if err != nil {
+		return nil, errors.New(errors.CodeNotFound, "User not found")
+	}
+	if user.ID == userID {
+		return nil, errors.New(errors.CodeInvalidRequest, "You can't block yourself")
+	}
+
+	now := time.Now()
+	block := &model.UserBlock{UserID: userID, BlockedUserID: user.ID, CreatedAt: now}
+	if err := s.repo.SaveUserBlock(block); err != nil {
+		return nil, err
+	}
+	if err := s.repo.DismissInboxItemsFrom(userID, user.ID, now); err != nil {
+		return nil, err
+	}
+
+	log.Debug("user blocked", "user_id", userID, "blocked_user_id", user.ID)
+	return &model.BlockedUserResponse{UserID: user.ID, Username: user.Username, BlockedAt: now}, nil
+}
+
+func (s *service) UnblockUser(userID, blockedUserID uint) error {
+	return s.repo.DeleteUserBlock(userID, blockedUserID)
+}
+
+// usernamesByID возвращает username пользователей по их ID
+func (s *service) usernamesByID(userIDs []uint) (map[uint]string, error) {
+	users, err := s.repo.GetUsersByIDs(userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	usernames := make(map[uint]string, len(users))
+	for _, user := range users {
+		usernames[user.ID] = user.Username
+	}
+	return usernames, nil
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+
+	"github.com/aerscs/theca-public/internal/utils/parsers"
+)
+
+// classifyBatchSize количество закладок, классифицируемых за один запуск задачи
+const classifyBatchSize = 500
+
+// ClassifyBookmarks определяет вид содержимого закладок, сохранённых до появления классификации.
+// Страницы не загружаются повторно, вид определяется по адресу
+func (s *service) ClassifyBookmarks(ctx context.Context) {
+	const op = "service.ClassifyBookmarks"
+	log := s.log.With("op", op)
+
+	bookmarks, err := s.repo.GetUnclassifiedBookmarks(classifyBatchSize)
+	if err != nil {
+		log.Error("failed to get unclassified bookmarks", "error", err)
+		return
+	}
+
+	for i := range bookmarks {
+		if ctx.Err() != nil {
+			return
+		}
+		kind := parsers.ClassifyURL(bookmarks[i].URL)
+		if err := s.repo.UpdateBookmarkKind(bookmarks[i].ID, kind); err != nil {
+			log.Error("failed to save bookmark kind", "error", err, "bookmark_id", bookmarks[i].ID)
+		}
+	}
+
+	if len(bookmarks) > 0 {
+		log.Debug("bookmarks classified", "count", len(bookmarks))
+	}
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+)
+
+// applyProfileSettings переносит поля публичного профиля из запроса.
+// Аватар принимается только как ссылка http(s), чтобы его можно было безопасно показать другим
+func applyProfileSettings(user *model.User, req *model.UpdateUserSettingsRequest) error {
+	if req.DisplayName != nil {
+		user.DisplayName = strings.TrimSpace(*req.DisplayName)
+	}
+	if req.Bio != nil {
+		user.Bio = strings.TrimSpace(*req.Bio)
+	}
+	if req.AvatarURL != nil {
+		avatarURL := strings.TrimSpace(*req.AvatarURL)
+		if avatarURL != "" {
+			u, err := url.Parse(avatarURL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return errors.New(errors.CodeInvalidRequest, "Avatar must be an http(s) URL")
+			}
+		}
+		user.AvatarURL = avatarURL
+	}
+	if req.ProfilePublic != nil {
+		user.ProfilePublic = *req.ProfilePublic
+	}
+	return nil
+}
+
+// GetPublicProfile возвращает опубликованный профиль по username.
+// Скрытый профиль неотличим от несуществующего, чтобы по нему нельзя было перебирать аккаунты
+func (s *service) GetPublicProfile(username string) (*model.PublicProfileResponse, error) {
+	const op = "service.GetPublicProfile"
+	log := s.log.With("op", op)
+
+	user, err := s.repo.GetUserByUsername(username)
+	if err != nil || !user.ProfilePublic || !user.IsVerified {
+		return nil, errors.New(errors.CodeNotFound, "Profile not found")
+	}
+
+	collections, err := s.repo.GetPublicCollectionsByOwner(user.ID)
+	if err != nil {
+		log.Error("failed to get public collections", "error", err, "user_id", user.ID)
+		return nil, err
+	}
+
+	profile := &model.PublicProfileResponse{
+		Username:    user.Username,
+		DisplayName: user.DisplayName,
+		Bio:         user.Bio,
+		AvatarURL:   user.AvatarURL,
+		Collections: make([]model.PublicCollectionResponse, 0, len(collections)),
+	}
+	for i := range collections {
+		profile.Collections = append(profile.Collections, model.NewPublicCollectionResponse(&collections[i]))
+	}
+	return profile, nil
+}
+
+// GetPublicCollection возвращает публичную коллекцию с закладками. Авторы закладок не раскрываются
+func (s *service) GetPublicCollection(collectionID uint) (*model.PublicCollectionResponse, error) {
+	const op = "service.GetPublicCollection"
+	log := s.log.With("op", op)
+
+	collection, err := s.repo.GetCollectionByID(collectionID)
+	if err != nil || !collection.IsPublic {
+		return nil, errors.New(errors.CodeNotFound, "Collection not found")
+	}
+
+	bookmarks, err := s.repo.GetCollectionBookmarks(collectionID)
+	if err != nil {
+		log.Error("failed to get collection bookmarks", "error", err, "collection_id", collectionID)
+		return nil, err
+	}
+
+	response := model.NewPublicCollectionResponse(collection)
+	response.Bookmarks = model.NewSharedBookmarks(bookmarks)
+	return &response, nil
+}
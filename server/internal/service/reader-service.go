@@ -0,0 +1,63 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+)
+
+const (
+	defaultSearchLimit = 50
+	maxSearchLimit     = 200
+	maxSearchQueryLen  = 200
+)
+
+// GetReaderView возвращает закладку вместе с извлечённым текстом статьи
+func (s *service) GetReaderView(userID, bookmarkID uint) (*model.Bookmark, *model.BookmarkContent, error) {
+	const op = "service.GetReaderView"
+	log := s.log.With("op", op)
+
+	bookmark, err := s.GetBookmarkByID(userID, bookmarkID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.repo.GetBookmarkContent(bookmark.ID)
+	if err != nil {
+		log.Debug("bookmark content not found", "error", err, "bookmark_id", bookmarkID)
+		return nil, nil, err
+	}
+
+	return bookmark, content, nil
+}
+
+func (s *service) SearchBookmarks(userID uint, req *model.SearchBookmarksRequest) ([]model.Bookmark, error) {
+	const op = "service.SearchBookmarks"
+	log := s.log.With("op", op)
+
+	query := strings.TrimSpace(req.Query)
+	if query == "" || len(query) > maxSearchQueryLen {
+		return nil, errors.New(errors.CodeInvalidRequest, "Search query must be between 1 and 200 characters")
+	}
+
+	if req.WorkspaceID != 0 {
+		if _, err := s.workspaceRole(userID, req.WorkspaceID); err != nil {
+			return nil, err
+		}
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	limit = min(limit, maxSearchLimit)
+
+	bookmarks, err := s.repo.SearchBookmarks(userID, req.WorkspaceID, query, limit)
+	if err != nil {
+		log.Error("failed to search bookmarks", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	return bookmarks, nil
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+	"github.com/aerscs/theca-public/internal/utils/ical"
+	"github.com/aerscs/theca-public/internal/utils/mail"
+)
+
+// reminderBatchSize сколько напоминаний обрабатывается за один запуск планировщика
+const reminderBatchSize = 200
+
+// SetBookmarkReminder назначает напоминание о закладке на указанное время
+func (s *service) SetBookmarkReminder(userID, bookmarkID uint, at time.Time) (*model.Bookmark, error) {
+	return s.updateReminder(userID, bookmarkID, func(bookmark *model.Bookmark, now time.Time) error {
+		if !at.After(now) {
+			return errors.New(errors.CodeInvalidRequest, "Reminder time must be in the future")
+		}
+		bookmark.RemindAt = &at
+		return nil
+	})
+}
+
+func (s *service) ClearBookmarkReminder(userID, bookmarkID uint) (*model.Bookmark, error) {
+	return s.updateReminder(userID, bookmarkID, func(bookmark *model.Bookmark, _ time.Time) error {
+		bookmark.RemindAt = nil
+		return nil
+	})
+}
+
+// SnoozeBookmark скрывает закладку из входящих до указанного времени
+func (s *service) SnoozeBookmark(userID, bookmarkID uint, until time.Time) (*model.Bookmark, error) {
+	return s.updateReminder(userID, bookmarkID, func(bookmark *model.Bookmark, now time.Time) error {
+		if !until.After(now) {
+			return errors.New(errors.CodeInvalidRequest, "Snooze time must be in the future")
+		}
+		bookmark.SnoozedUntil = &until
+		return nil
+	})
+}
+
+// UnsnoozeBookmark сразу возвращает отложенную закладку во входящие
+func (s *service) UnsnoozeBookmark(userID, bookmarkID uint) (*model.Bookmark, error) {
+	return s.updateReminder(userID, bookmarkID, func(bookmark *model.Bookmark, now time.Time) error {
+		if bookmark.SnoozedUntil != nil {
+			bookmark.SnoozedUntil = nil
+			bookmark.SetState(model.StateUnread, now)
+		}
+		return nil
+	})
+}
+
+// updateReminder загружает закладку пользователя, применяет изменение и сохраняет её состояние
+func (s *service) updateReminder(userID, bookmarkID uint, apply func(bookmark *model.Bookmark, now time.Time) error) (*model.Bookmark, error) {
+	const op = "service.updateReminder"
+	log := s.log.With("op", op)
+
+	bookmark, err := s.getBookmark(userID, bookmarkID, accessAuthor)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := apply(bookmark, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateBookmarkState(bookmark); err != nil {
+		log.Error("failed to update bookmark reminder", "error", err, "bookmark_id", bookmarkID)
+		return nil, err
+	}
+
+	log.Debug("bookmark reminder updated", "bookmark_id", bookmarkID, "user_id", userID)
+	return bookmark, nil
+}
+
+// SendDueReminders возвращает во входящие закладки с наступившим напоминанием или окончанием
+// откладывания и отправляет владельцам одно письмо со всеми такими закладками
+func (s *service) SendDueReminders(ctx context.Context) {
+	const op = "service.SendDueReminders"
+	log := s.log.With("op", op)
+
+	now := time.Now()
+	bookmarks, err := s.repo.GetDueReminders(now, reminderBatchSize)
+	if err != nil {
+		log.Error("failed to get due reminders", "error", err)
+		return
+	}
+
+	due := make(map[uint][]mail.Bookmark)
+	for i := range bookmarks {
+		bookmark := &bookmarks[i]
+		if bookmark.RemindAt != nil && !bookmark.RemindAt.After(now) {
+			bookmark.RemindAt = nil
+		}
+		if bookmark.SnoozedUntil != nil && !bookmark.SnoozedUntil.After(now) {
+			bookmark.SnoozedUntil = nil
+		}
+		bookmark.SetState(model.StateUnread, now)
+		bookmark.ResurfacedAt = &now
+
+		// Другой инстанс мог уже обработать это напоминание
+		claimed, err := s.repo.ClaimDueReminder(bookmark, now)
+		if err != nil || !claimed {
+			continue
+		}
+
+		due[bookmark.UserID] = append(due[bookmark.UserID], mail.Bookmark{
+			Title: bookmark.Title,
+			URL:   safeLinkURL(bookmark.URL),
+		})
+	}
+
+	for userID, items := range due {
+		if ctx.Err() != nil {
+			return
+		}
+
+		user, err := s.repo.GetUserByID(userID)
+		if err != nil {
+			log.Error("failed to get user for reminder", "error", err, "user_id", userID)
+			continue
+		}
+
+		if err := s.mailer.SendReminderEmail(user.Email, user.Username, items); err != nil {
+			log.Error("failed to send reminder email", "error", err, "user_id", userID)
+			continue
+		}
+	}
+
+	if len(due) > 0 {
+		log.Debug("reminders sent", "users", len(due), "bookmarks", len(bookmarks))
+	}
+}
+
+// safeLinkURL оставляет в письме только http(s)-ссылки
+func safeLinkURL(rawURL string) string {
+	lower := strings.ToLower(rawURL)
+	if strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") {
+		return rawURL
+	}
+	return ""
+}
+
+// GetCalendarFeedURL возвращает адрес подписки на календарь напоминаний.
+// Токен общий с лентами закладок; rotate выпускает новый, и старые ссылки перестают работать
+func (s *service) GetCalendarFeedURL(userID uint, rotate bool) (string, error) {
+	token, err := s.ensureFeedToken(userID, rotate)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s/v1/calendar/%s.ics", s.cfg.PublicURL, token), nil
+}
+
+// GetCalendarFeed формирует календарь предстоящих напоминаний по токену подписки
+func (s *service) GetCalendarFeed(token string) ([]byte, error) {
+	const op = "service.GetCalendarFeed"
+	log := s.log.With("op", op)
+
+	if token == "" {
+		return nil, errors.New(errors.CodeNotFound, "Feed not found")
+	}
+
+	user, err := s.repo.GetUserByFeedToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	bookmarks, err := s.repo.GetUpcomingReminders(user.ID, now)
+	if err != nil {
+		log.Error("failed to get upcoming reminders", "error", err, "user_id", user.ID)
+		return nil, err
+	}
+
+	events := make([]ical.Event, 0, len(bookmarks))
+	for _, bookmark := range bookmarks {
+		if bookmark.RemindAt != nil && bookmark.RemindAt.After(now) {
+			events = append(events, ical.Event{
+				UID:         fmt.Sprintf("reminder-%d@theca", bookmark.ID),
+				Start:       *bookmark.RemindAt,
+				Summary:     "Read: " + bookmark.Title,
+				Description: bookmark.URL,
+				URL:         safeLinkURL(bookmark.URL),
+			})
+		}
+		if bookmark.SnoozedUntil != nil && bookmark.SnoozedUntil.After(now) {
+			events = append(events, ical.Event{
+				UID:         fmt.Sprintf("snooze-%d@theca", bookmark.ID),
+				Start:       *bookmark.SnoozedUntil,
+				Summary:     "Back in inbox: " + bookmark.Title,
+				Description: bookmark.URL,
+				URL:         safeLinkURL(bookmark.URL),
+			})
+		}
+	}
+
+	return ical.Calendar("Theca reminders", events, now), nil
+}
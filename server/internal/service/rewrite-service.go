@@ -0,0 +1,205 @@
+package service
+
+import (
+	neturl "net/url"
+	"strings"
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+	"github.com/aerscs/theca-public/internal/utils/parsers"
+)
+
+// suggestPermanentURL предлагает адрес, на который постоянно перенаправляет ссылка закладки.
+// Редиректы на страницы входа не предлагаются: это не новое место страницы
+func suggestPermanentURL(bookmark *model.Bookmark, check *parsers.LinkCheck) {
+	bookmark.SuggestedURL = ""
+
+	if check.Err != nil || check.StatusCode < 200 || check.StatusCode >= 300 || check.PermanentURL == "" {
+		return
+	}
+	if strings.TrimSuffix(check.PermanentURL, "/") == strings.TrimSuffix(bookmark.URL, "/") {
+		return
+	}
+
+	if isLoginURL(check.PermanentURL) {
+		return
+	}
+
+	bookmark.SuggestedURL = check.PermanentURL
+}
+
+// authSegments части адреса, которые целиком обозначают вход или авторизацию.
+// Подстрокой их искать нельзя: "auth" встречается в "authors" и "author"
+var authSegments = map[string]bool{
+	"auth": true, "oauth": true, "oauth2": true, "sso": true, "cas": true,
+	"authorize": true, "authenticate": true, "authentication": true,
+	"sign-in": true, "sign_in": true, "logon": true, "saml": true,
+}
+
+// isLoginURL проверяет, что адрес ведёт на страницу входа: "login" или "signin" в хосте или пути
+// либо отдельный сегмент вроде /auth/ или поддомена auth.
+func isLoginURL(rawURL string) bool {
+	u, err := neturl.Parse(strings.ToLower(rawURL))
+	if err != nil {
+		return true
+	}
+
+	parts := strings.Split(u.Hostname(), ".")
+	parts = append(parts, strings.Split(u.Path, "/")...)
+	for _, part := range parts {
+		if strings.Contains(part, "login") || strings.Contains(part, "signin") {
+			return true
+		}
+		// Расширение не меняет смысла: /auth.php тоже страница входа
+		if name, _, _ := strings.Cut(part, "."); authSegments[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// dropUndoneSuggestion убирает предложение, если пользователь уже отменял замену этого URL на тот же адрес.
+// Иначе после отмены следующая проверка снова предложила бы замену, а автозамена сразу применила бы её
+func (s *service) dropUndoneSuggestion(bookmark *model.Bookmark) error {
+	if bookmark.SuggestedURL == "" {
+		return nil
+	}
+	undone, err := s.repo.IsURLRewriteUndone(bookmark.ID, bookmark.URL, bookmark.SuggestedURL)
+	if err != nil {
+		return err
+	}
+	if undone {
+		bookmark.SuggestedURL = ""
+	}
+	return nil
+}
+
+// autoRewriteEnabled проверяет, включена ли у владельца закладки автоматическая замена URL
+func (s *service) autoRewriteEnabled(userID uint) bool {
+	user, err := s.repo.GetUserByID(userID)
+	if err != nil {
+		return false
+	}
+	return user.AutoRewriteRedirects
+}
+
+// rewriteBookmarkURL заменяет URL закладки на предложенный и записывает замену в журнал
+func (s *service) rewriteBookmarkURL(bookmark *model.Bookmark, automatic bool) (*model.URLRewrite, error) {
+	const op = "service.rewriteBookmarkURL"
+	log := s.log.With("op", op)
+
+	if bookmark.SuggestedURL == "" {
+		return nil, errors.New(errors.CodeInvalidRequest, "Bookmark has no redirect suggestion")
+	}
+
+	rewrite := &model.URLRewrite{
+		OldURL:     bookmark.URL,
+		NewURL:     bookmark.SuggestedURL,
+		BookmarkID: bookmark.ID,
+		UserID:     bookmark.UserID,
+		Automatic:  automatic,
+	}
+
+	bookmark.URL = bookmark.SuggestedURL
+	bookmark.FinalURL = bookmark.SuggestedURL
+	bookmark.SuggestedURL = ""
+	bookmark.HealthStatus = model.HealthStatusOK
+	bookmark.CheckFailures = 0
+	bookmark.UpdatedAt = time.Now()
+
+	if err := s.repo.RewriteBookmarkURL(bookmark, rewrite); err != nil {
+		log.Error("failed to rewrite bookmark URL", "error", err, "bookmark_id", bookmark.ID)
+		return nil, err
+	}
+
+	log.Info("bookmark URL rewritten", "bookmark_id", bookmark.ID, "automatic", automatic)
+	return rewrite, nil
+}
+
+func (s *service) GetRedirectSuggestions(userID uint) ([]model.Bookmark, error) {
+	return s.repo.GetBookmarksWithSuggestedURL(userID)
+}
+
+// ApplyRedirectSuggestion заменяет URL одной закладки на предложенный. Замена записывается на автора закладки,
+// поэтому применить и отменить её может только он
+func (s *service) ApplyRedirectSuggestion(userID, bookmarkID uint) (*model.URLRewrite, error) {
+	bookmark, err := s.getBookmark(userID, bookmarkID, accessAuthor)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.rewriteBookmarkURL(bookmark, false)
+}
+
+// ApplyAllRedirectSuggestions заменяет URL всех закладок пользователя, для которых есть предложения
+func (s *service) ApplyAllRedirectSuggestions(userID uint) (*model.URLRewriteReport, error) {
+	const op = "service.ApplyAllRedirectSuggestions"
+	log := s.log.With("op", op)
+
+	bookmarks, err := s.repo.GetBookmarksWithSuggestedURL(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.URLRewriteReport{Rewrites: make([]model.URLRewrite, 0, len(bookmarks))}
+	for i := range bookmarks {
+		rewrite, err := s.rewriteBookmarkURL(&bookmarks[i], false)
+		if err != nil {
+			log.Error("failed to apply redirect suggestion", "error", err, "bookmark_id", bookmarks[i].ID)
+			continue
+		}
+		report.Rewrites = append(report.Rewrites, *rewrite)
+		report.Applied++
+	}
+
+	return report, nil
+}
+
+// GetURLRewriteReport возвращает замены URL за последние days дней
+func (s *service) GetURLRewriteReport(userID uint, days int) (*model.URLRewriteReport, error) {
+	if days <= 0 {
+		days = 30
+	}
+
+	rewrites, err := s.repo.GetURLRewrites(userID, time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.URLRewriteReport{Rewrites: rewrites}
+	for _, rewrite := range rewrites {
+		if rewrite.UndoneAt != nil {
+			report.Undone++
+		} else {
+			report.Applied++
+		}
+	}
+
+	return report, nil
+}
+
+// UndoURLRewrite возвращает закладке URL, который был до замены
+func (s *service) UndoURLRewrite(userID, rewriteID uint) (*model.URLRewrite, error) {
+	const op = "service.UndoURLRewrite"
+	log := s.log.With("op", op)
+
+	rewrite, err := s.repo.GetURLRewriteByID(rewriteID)
+	if err != nil {
+		return nil, err
+	}
+	if rewrite.UserID != userID {
+		log.Warn("attempt to undo another user's rewrite", "rewrite_id", rewriteID, "user_id", userID)
+		return nil, errors.New(errors.CodeForbidden, "Access denied")
+	}
+	if rewrite.UndoneAt != nil {
+		return nil, errors.New(errors.CodeDataConflict, "Rewrite is already undone")
+	}
+
+	if err := s.repo.UndoURLRewrite(rewrite); err != nil {
+		return nil, err
+	}
+
+	log.Info("URL rewrite undone", "rewrite_id", rewriteID, "bookmark_id", rewrite.BookmarkID)
+	return rewrite, nil
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/parsers"
+)
+
+func TestSuggestPermanentURL(t *testing.T) {
+	const bookmarkURL = "http://example.com/page"
+
+	tests := []struct {
+		name  string
+		check parsers.LinkCheck
+		want  string
+	}{
+		{"moved", parsers.LinkCheck{StatusCode: 200, PermanentURL: "https://example.com/page"}, "https://example.com/page"},
+		{"moved to another site", parsers.LinkCheck{StatusCode: 200, PermanentURL: "https://example.org/article"}, "https://example.org/article"},
+		{"author page", parsers.LinkCheck{StatusCode: 200, PermanentURL: "https://example.com/authors/jane"}, "https://example.com/authors/jane"},
+		{"auth in a word of the host", parsers.LinkCheck{StatusCode: 200, PermanentURL: "https://auth0.com/blog/post"}, "https://auth0.com/blog/post"},
+		{"no permanent redirect", parsers.LinkCheck{StatusCode: 200, FinalURL: "https://example.com/temp"}, ""},
+		{"same URL with a trailing slash", parsers.LinkCheck{StatusCode: 200, PermanentURL: bookmarkURL + "/"}, ""},
+		{"destination is broken", parsers.LinkCheck{StatusCode: 404, PermanentURL: "https://example.com/gone"}, ""},
+		{"destination redirects further", parsers.LinkCheck{StatusCode: 302, PermanentURL: "https://example.com/next"}, ""},
+		{"network error", parsers.LinkCheck{Err: errors.New("timeout"), PermanentURL: "https://example.com/new"}, ""},
+		{"login page", parsers.LinkCheck{StatusCode: 200, PermanentURL: "https://example.com/login?next=/page"}, ""},
+		{"login script", parsers.LinkCheck{StatusCode: 200, PermanentURL: "https://example.com/wp-login.php"}, ""},
+		{"service login", parsers.LinkCheck{StatusCode: 200, PermanentURL: "https://accounts.google.com/ServiceLogin"}, ""},
+		{"sign in page", parsers.LinkCheck{StatusCode: 200, PermanentURL: "https://example.com/users/SignIn"}, ""},
+		{"sign-in page", parsers.LinkCheck{StatusCode: 200, PermanentURL: "https://example.com/sign-in"}, ""},
+		{"auth path", parsers.LinkCheck{StatusCode: 200, PermanentURL: "https://example.com/auth/realms/main"}, ""},
+		{"oauth authorize", parsers.LinkCheck{StatusCode: 200, PermanentURL: "https://example.com/oauth/authorize"}, ""},
+		{"auth subdomain", parsers.LinkCheck{StatusCode: 200, PermanentURL: "https://auth.example.com/"}, ""},
+		{"login subdomain", parsers.LinkCheck{StatusCode: 200, PermanentURL: "https://login.example.com/"}, ""},
+		{"sso path", parsers.LinkCheck{StatusCode: 200, PermanentURL: "https://example.com/sso"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bookmark := &model.Bookmark{URL: bookmarkURL, SuggestedURL: "https://stale.example.com/"}
+			suggestPermanentURL(bookmark, &tt.check)
+			if bookmark.SuggestedURL != tt.want {
+				t.Fatalf("SuggestedURL = %q, want %q", bookmark.SuggestedURL, tt.want)
+			}
+		})
+	}
+}
@@ -5,13 +5,17 @@ import (
 	cryptorand "crypto/rand"
 	"fmt"
 	"log/slog"
+	neturl "net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aerscs/theca-public/internal/config"
 	"github.com/aerscs/theca-public/internal/model"
 	"github.com/aerscs/theca-public/internal/repository"
+	"github.com/aerscs/theca-public/internal/storage/blob"
 	"github.com/aerscs/theca-public/internal/utils/errors"
+	"github.com/aerscs/theca-public/internal/utils/feed"
 	jwtauth "github.com/aerscs/theca-public/internal/utils/jwt"
 	"github.com/aerscs/theca-public/internal/utils/mail"
 	"github.com/aerscs/theca-public/internal/utils/parsers"
@@ -28,17 +32,146 @@ type Service interface {
 	RequestPasswordReset(email string) error
 	ResetPassword(token, password string) error
 	GetUser(userID any) (*model.UserResponse, error)
+	UpdateUserSettings(userID uint, req *model.UpdateUserSettingsRequest) (*model.UserResponse, error)
 
 	// Методы для работы с закладками
-	AddBookmark(userID uint, title, url string, showText bool) (*model.Bookmark, error)
-	GetBookmarks(userID uint) ([]model.Bookmark, error)
+	AddBookmark(userID uint, req *model.AddBookmarkRequest) (*model.Bookmark, error)
+	GetBookmarks(userID uint, filter *model.BookmarkListFilter) ([]model.Bookmark, error)
 	GetBookmarkByID(userID, bookmarkID uint) (*model.Bookmark, error)
 	PatchBookmark(userID, bookmarkID uint, patch *model.PatchBookmarkRequest) (*model.Bookmark, error)
 	DeleteBookmark(userID, bookmarkID uint) error
-	ImportBookmarks(userID uint, base64Data string) ([]model.Bookmark, error)
-	ExportBookmarks(userID uint) (string, error)
-	ImportBookmarksV2(userID uint, bookmarks []model.BookmarkV2Request) ([]model.Bookmark, error)
-	ExportBookmarksV2(userID uint) ([]model.Bookmark, error)
+	GetBookmarkPreview(token string) (*model.BookmarkPreview, error)
+	ImportBookmarks(userID, workspaceID uint, base64Data string) ([]model.Bookmark, error)
+	ExportBookmarks(userID, workspaceID uint) (string, error)
+	ImportBookmarksV2(userID, workspaceID uint, bookmarks []model.BookmarkV2Request) ([]model.Bookmark, error)
+	ExportBookmarksV2(userID, workspaceID uint) ([]model.ExportedBookmark, error)
+	GetReaderView(userID, bookmarkID uint) (*model.Bookmark, *model.BookmarkContent, error)
+	SearchBookmarks(userID uint, req *model.SearchBookmarksRequest) ([]model.Bookmark, error)
+
+	// Методы для списка «прочитать позже»
+	UpdateBookmarkState(userID, bookmarkID uint, req *model.UpdateBookmarkStateRequest) (*model.Bookmark, error)
+	UpdateReadingProgress(userID, bookmarkID uint, progress float64) (*model.Bookmark, error)
+
+	// Методы для работы с папками и тегами
+	GetFolders(userID, workspaceID uint) ([]model.Folder, error)
+	CreateFolder(userID uint, req *model.FolderRequest) (*model.Folder, error)
+	UpdateFolder(userID, folderID uint, req *model.FolderRequest) (*model.Folder, error)
+	DeleteFolder(userID, folderID uint) error
+	GetTags(userID, workspaceID uint) ([]model.TagResponse, error)
+
+	// Методы для комментариев к закладкам
+	GetComments(userID, bookmarkID uint) ([]model.CommentResponse, error)
+	CreateComment(userID, bookmarkID uint, req *model.CommentRequest) (*model.CommentResponse, error)
+	UpdateComment(userID, bookmarkID, commentID uint, req *model.UpdateCommentRequest) (*model.CommentResponse, error)
+	DeleteComment(userID, bookmarkID, commentID uint) error
+
+	// Журнал активности
+	GetActivity(userID uint, filter *model.ActivityFilter) (*model.ActivityPageResponse, error)
+
+	// Методы для рабочих пространств
+	GetWorkspaces(userID uint) ([]model.WorkspaceResponse, error)
+	CreateWorkspace(userID uint, req *model.WorkspaceRequest) (*model.WorkspaceResponse, error)
+	GetWorkspace(userID, workspaceID uint) (*model.WorkspaceResponse, error)
+	UpdateWorkspace(userID, workspaceID uint, req *model.WorkspaceRequest) (*model.WorkspaceResponse, error)
+	DeleteWorkspace(userID, workspaceID uint) error
+	GetWorkspaceMembers(userID, workspaceID uint) ([]model.WorkspaceMemberResponse, error)
+	AddWorkspaceMember(userID, workspaceID uint, req *model.WorkspaceMemberRequest) (*model.WorkspaceMemberResponse, error)
+	UpdateWorkspaceMember(userID, workspaceID, memberID uint, req *model.WorkspaceRoleRequest) (*model.WorkspaceMemberResponse, error)
+	RemoveWorkspaceMember(userID, workspaceID, memberID uint) error
+
+	// Методы для общих коллекций
+	GetCollections(userID uint) ([]model.CollectionResponse, error)
+	CreateCollection(userID uint, req *model.CollectionRequest) (*model.CollectionResponse, error)
+	UpdateCollection(userID, collectionID uint, req *model.CollectionRequest) (*model.CollectionResponse, error)
+	DeleteCollection(userID, collectionID uint) error
+	GetCollectionBookmarks(userID, collectionID uint) ([]model.Bookmark, error)
+	GetCollectionMembers(userID, collectionID uint) ([]model.CollectionMemberResponse, error)
+	UpdateCollectionMember(userID, collectionID, memberID uint, req *model.CollectionRoleRequest) (*model.CollectionMemberResponse, error)
+	RemoveCollectionMember(userID, collectionID, memberID uint) error
+	InviteToCollection(userID, collectionID uint, req *model.CollectionInviteRequest) (*model.CollectionInvite, error)
+	GetCollectionInvites(userID, collectionID uint) ([]model.CollectionInvite, error)
+	RevokeCollectionInvite(userID, collectionID, inviteID uint) error
+	AcceptCollectionInvite(userID uint, token string) (*model.CollectionResponse, error)
+	GetWidgets(userID, collectionID uint) ([]model.WidgetResponse, error)
+	CreateWidget(userID, collectionID uint, req *model.WidgetRequest) (*model.WidgetResponse, error)
+	UpdateWidget(userID, collectionID, widgetID uint, req *model.WidgetRequest) (*model.WidgetResponse, error)
+	DeleteWidget(userID, collectionID, widgetID uint) error
+	GetEmbed(token string, query *model.WidgetQuery) (*model.EmbedResponse, error)
+	GetTemplates() ([]model.TemplateResponse, error)
+	GetTemplate(collectionID uint) (*model.TemplateResponse, error)
+	PublishTemplate(userID, collectionID uint, req *model.TemplateRequest) (*model.CollectionResponse, error)
+	CloneTemplate(userID, collectionID uint) (*model.TemplateCloneResponse, error)
+	GetPublicProfile(username string) (*model.PublicProfileResponse, error)
+	GetPublicCollection(collectionID uint) (*model.PublicCollectionResponse, error)
+	GetFeedURLs(userID uint, query *model.FeedQuery, rotate bool) (*model.FeedURLsResponse, error)
+	GetBookmarkFeed(token, format string, query *model.FeedQuery) (*feed.Feed, error)
+	GetCollectionFeed(collectionID uint, format string) (*feed.Feed, error)
+
+	// Методы для входящих закладок от других пользователей
+	SendBookmark(userID, bookmarkID uint, req *model.SendBookmarkRequest) error
+	GetInbox(userID uint) ([]model.InboxItemResponse, error)
+	AcceptInboxItem(userID, itemID uint) (*model.Bookmark, error)
+	DismissInboxItem(userID, itemID uint) error
+	GetBlockedUsers(userID uint) ([]model.BlockedUserResponse, error)
+	BlockUser(userID uint, req *model.BlockUserRequest) (*model.BlockedUserResponse, error)
+	UnblockUser(userID, blockedUserID uint) error
+
+	// Методы для общих ссылок
+	CreateShareLink(userID uint, req *model.CreateShareLinkRequest) (*model.ShareLinkResponse, error)
+	GetShareLinks(userID uint) ([]model.ShareLinkResponse, error)
+	DeleteShareLink(userID, shareID uint) error
+	GetSharedCollection(token, password string) (*model.SharedCollectionResponse, error)
+
+	// Классификация закладок, сохранённых до появления вида содержимого
+	ClassifyBookmarks(ctx context.Context)
+
+	// Методы для напоминаний и откладывания закладок
+	SetBookmarkReminder(userID, bookmarkID uint, at time.Time) (*model.Bookmark, error)
+	ClearBookmarkReminder(userID, bookmarkID uint) (*model.Bookmark, error)
+	SnoozeBookmark(userID, bookmarkID uint, until time.Time) (*model.Bookmark, error)
+	UnsnoozeBookmark(userID, bookmarkID uint) (*model.Bookmark, error)
+	SendDueReminders(ctx context.Context)
+	GetCalendarFeedURL(userID uint, rotate bool) (string, error)
+	GetCalendarFeed(token string) ([]byte, error)
+
+	// Методы для статистики переходов по закладкам
+	VisitBookmark(userID, bookmarkID uint, signature string) (string, error)
+	GetBookmarkUsage(userID uint, filter *model.UsageStatsFilter) ([]model.Bookmark, error)
+	GetDomainUsage(userID uint, filter *model.UsageStatsFilter) ([]model.DomainUsageResponse, error)
+	GetTopSites(userID uint, limit int) ([]model.TopSiteResponse, error)
+
+	// Методы для еженедельной рассылки
+	SendDigests(ctx context.Context)
+	UnsubscribeDigest(token string) error
+
+	// Методы для работы с офлайн-снимками страниц
+	ArchiveBookmark(userID, bookmarkID uint) (*model.BookmarkArchive, error)
+	GetBookmarkArchive(userID, bookmarkID uint) (*model.BookmarkArchive, []byte, error)
+	DeleteBookmarkArchive(userID, bookmarkID uint) error
+
+	// Методы для проверки доступности ссылок
+	CheckDueBookmarks(ctx context.Context)
+	CheckBookmarkNow(userID, bookmarkID uint) (*model.Bookmark, error)
+	ScheduleBookmarksCheck(userID uint) (int64, error)
+	GetBookmarksHealth(userID uint, filter *model.BookmarkHealthFilter) ([]model.Bookmark, error)
+
+	// Методы для замены URL при постоянных редиректах
+	GetRedirectSuggestions(userID uint) ([]model.Bookmark, error)
+	ApplyRedirectSuggestion(userID, bookmarkID uint) (*model.URLRewrite, error)
+	ApplyAllRedirectSuggestions(userID uint) (*model.URLRewriteReport, error)
+	GetURLRewriteReport(userID uint, days int) (*model.URLRewriteReport, error)
+	UndoURLRewrite(userID, rewriteID uint) (*model.URLRewrite, error)
+
+	// Методы администратора
+	IsAdmin(userID uint) (bool, error)
+	SeedIconOverrides() error
+	ReloadIconOverrides() error
+	GetIconOverrides() ([]model.IconOverride, error)
+	CreateIconOverride(req *model.IconOverrideRequest) (*model.IconOverride, error)
+	UpdateIconOverride(id uint, req *model.IconOverrideRequest) (*model.IconOverride, error)
+	DeleteIconOverride(id uint) error
+	SetWorkspacePlan(workspaceID uint, req *model.WorkspacePlanRequest) (*model.Workspace, error)
+	SetCollectionTemplate(collectionID uint, req *model.AdminTemplateRequest) (*model.Collection, error)
 }
 
 type service struct {
@@ -47,15 +180,17 @@ type service struct {
 	log    *slog.Logger
 	cfg    *config.Config
 	mailer mail.Mailer
+	blobs  blob.Storage
 }
 
-func NewService(repo repository.Repository, cache repository.CacheRepository, log *slog.Logger, cfg *config.Config) Service {
+func NewService(repo repository.Repository, cache repository.CacheRepository, blobs blob.Storage, log *slog.Logger, cfg *config.Config) Service {
 	return &service{
 		repo:   repo,
 		cache:  cache,
 		log:    log,
 		cfg:    cfg,
 		mailer: mail.NewMailer(cfg),
+		blobs:  blobs,
 	}
 }
 
@@ -437,6 +572,11 @@ func (s *service) ResetPassword(token, password string) error {
 
 // generateResetToken генерирует уникальный токен для сброса пароля
 func generateResetToken() (string, error) {
+	return generateToken()
+}
+
+// generateToken генерирует криптографически стойкий случайный токен
+func generateToken() (string, error) {
 	b := make([]byte, 32)
 	_, err := cryptorand.Read(b)
 	if err != nil {
@@ -445,24 +585,43 @@ func generateResetToken() (string, error) {
 	return fmt.Sprintf("%x", b), nil
 }
 
-func (s *service) AddBookmark(userID uint, title, url string, showText bool) (*model.Bookmark, error) {
+func (s *service) AddBookmark(userID uint, req *model.AddBookmarkRequest) (*model.Bookmark, error) {
 	const op = "service.AddBookmark"
 	log := s.log.With("op", op)
 
-	ctx := context.Background()
-	faviconBase64, err := parsers.FetchFaviconBase64(ctx, s.cache, url)
+	now := time.Now()
+	bookmark := &model.Bookmark{
+		UserID:    userID,
+		Title:     req.Title,
+		URL:       req.URL,
+		ShowText:  req.ShowText,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	workspaceID, err := s.resolveWorkspace(userID, req.WorkspaceID)
 	if err != nil {
-		log.Error("failed to fetch favicon", "error", err, "url", url)
+		return nil, err
+	}
+	if workspaceID != nil {
+		if err := s.checkWorkspaceBookmarkLimit(*workspaceID, 1); err != nil {
+			return nil, err
+		}
+		bookmark.WorkspaceID = workspaceID
 	}
+	if err := s.applyBookmarkPlacement(bookmark, req.FolderID, &req.Tags); err != nil {
+		return nil, err
+	}
+	if req.CollectionID != nil {
+		if err := s.applyBookmarkCollection(userID, bookmark, *req.CollectionID); err != nil {
+			return nil, err
+		}
+	}
+	// Новая закладка попадает во входящие
+	bookmark.SetState(model.StateUnread, now)
 
-	bookmark := &model.Bookmark{
-		UserID:    userID,
-		Title:     title,
-		URL:       url,
-		ShowText:  showText,
-		Favicon:   faviconBase64,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+	articleText := s.fillPageDetails(bookmark)
+	if bookmark.Title == "" {
+		bookmark.Title = titleFromURL(req.URL)
 	}
 
 	err = s.repo.AddBookmark(bookmark)
@@ -471,15 +630,207 @@ func (s *service) AddBookmark(userID uint, title, url string, showText bool) (*m
 		return nil, err
 	}
 
+	s.saveBookmarkContent(bookmark, articleText)
+	s.refreshPreview(bookmark)
+	s.archiveBookmarkAsync(bookmark)
+	s.recordActivity(newActivity(userID, model.ActivityAdded, bookmark))
+
 	log.Debug("bookmark added successfully", "bookmark_id", bookmark.ID, "user_id", userID)
 	return bookmark, nil
 }
 
-func (s *service) GetBookmarks(userID uint) ([]model.Bookmark, error) {
+// fillPageDetails загружает страницу закладки и заполняет иконки, метаданные и сведения о статье.
+// Пустой заголовок заменяется заголовком страницы. Возвращает извлечённый текст статьи
+func (s *service) fillPageDetails(bookmark *model.Bookmark) string {
+	const op = "service.fillPageDetails"
+	log := s.log.With("op", op)
+
+	ctx := context.Background()
+	details, err := parsers.FetchPageDetails(ctx, s.cache, bookmark.URL)
+	if err != nil {
+		log.Error("failed to fetch page details", "error", err, "url", bookmark.URL)
+	}
+
+	bookmark.Favicon = ""
+	bookmark.Icons = nil
+	metadata := &parsers.PageMetadata{}
+	if details != nil {
+		bookmark.Favicon = details.Favicon
+		bookmark.Icons = details.Icons
+		if details.Metadata != nil {
+			metadata = details.Metadata
+		}
+	}
+
+	bookmark.Description = metadata.Description
+	bookmark.ImageURL = metadata.Image
+	bookmark.SiteName = metadata.SiteName
+	bookmark.Language = metadata.Language
+	bookmark.CanonicalURL = metadata.CanonicalURL
+	if bookmark.Title == "" {
+		bookmark.Title = metadata.Title
+	}
+	bookmark.Kind = parsers.ClassifyPage(bookmark.URL, details)
+
+	bookmark.Author = ""
+	bookmark.PublishedAt = nil
+	bookmark.WordCount = 0
+	if details == nil || details.Article == nil {
+		return ""
+	}
+	bookmark.Author = details.Article.Author
+	bookmark.PublishedAt = details.Article.PublishedAt
+	bookmark.WordCount = details.Article.WordCount
+	return details.Article.Text
+}
+
+// saveBookmarkContent сохраняет текст статьи закладки; пустой текст удаляет сохранённый ранее
+func (s *service) saveBookmarkContent(bookmark *model.Bookmark, text string) {
+	const op = "service.saveBookmarkContent"
+	log := s.log.With("op", op, "bookmark_id", bookmark.ID)
+
+	if text == "" {
+		if err := s.repo.DeleteBookmarkContent(bookmark.ID); err != nil {
+			log.Error("failed to delete stale bookmark content", "error", err)
+		}
+		return
+	}
+
+	content := &model.BookmarkContent{
+		BookmarkID: bookmark.ID,
+		UserID:     bookmark.UserID,
+		Text:       text,
+		UpdatedAt:  time.Now(),
+	}
+	if err := s.repo.SaveBookmarkContent(content); err != nil {
+		log.Error("failed to save bookmark content", "error", err)
+	}
+}
+
+// refreshPreview строит миниатюру превью по og:image закладки с учётом квоты хранилища.
+// Если у страницы нет изображения или его не удалось обработать, старое превью удаляется
+func (s *service) refreshPreview(bookmark *model.Bookmark) {
+	const op = "service.refreshPreview"
+	log := s.log.With("op", op, "bookmark_id", bookmark.ID)
+
+	var thumbnail *parsers.Thumbnail
+	if bookmark.ImageURL != "" {
+		var err error
+		thumbnail, err = parsers.FetchThumbnail(context.Background(), bookmark.ImageURL)
+		if err != nil {
+			log.Debug("failed to build preview thumbnail", "error", err, "image_url", bookmark.ImageURL)
+		}
+	}
+
+	if thumbnail == nil {
+		if bookmark.PreviewToken != "" {
+			if err := s.repo.DeleteBookmarkPreview(bookmark.ID); err != nil {
+				log.Error("failed to delete stale preview", "error", err)
+				return
+			}
+			bookmark.PreviewToken = ""
+		}
+		return
+	}
+
+	user, err := s.repo.GetUserByID(bookmark.UserID)
+	if err != nil {
+		log.Error("failed to get user", "error", err, "user_id", bookmark.UserID)
+		return
+	}
+
+	// Размер заменяемого превью не учитываем: квота проверяется с запасом
+	size := int64(len(thumbnail.Data))
+	if user.StorageUsed+size > s.storageQuota(user) {
+		log.Info("storage quota exceeded, preview skipped", "user_id", user.ID, "storage_used", user.StorageUsed)
+		return
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		log.Error("failed to generate preview token", "error", err)
+		return
+	}
+
+	preview := &model.BookmarkPreview{
+		BookmarkID:  bookmark.ID,
+		UserID:      bookmark.UserID,
+		Token:       token,
+		ContentType: thumbnail.ContentType,
+		SourceURL:   bookmark.ImageURL,
+		Data:        thumbnail.Data,
+		Size:        size,
+		Width:       thumbnail.Width,
+		Height:      thumbnail.Height,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.repo.SaveBookmarkPreview(preview); err != nil {
+		log.Error("failed to save preview", "error", err)
+		return
+	}
+
+	bookmark.PreviewToken = token
+}
+
+// storageQuota возвращает лимит хранилища пользователя в байтах
+func (s *service) storageQuota(user *model.User) int64 {
+	if user.IsPremium {
+		return s.cfg.PremiumStorageQuota
+	}
+	return s.cfg.StorageQuota
+}
+
+func (s *service) GetBookmarkPreview(token string) (*model.BookmarkPreview, error) {
+	const op = "service.GetBookmarkPreview"
+	log := s.log.With("op", op)
+
+	preview, err := s.repo.GetBookmarkPreviewByToken(token)
+	if err != nil {
+		log.Debug("failed to get bookmark preview", "error", err)
+		return nil, err
+	}
+
+	return preview, nil
+}
+
+// titleFromURL возвращает имя хоста в качестве запасного заголовка
+func titleFromURL(rawURL string) string {
+	u, err := neturl.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		u, err = neturl.Parse("https://" + rawURL)
+	}
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	return strings.TrimPrefix(u.Hostname(), "www.")
+}
+
+// GetBookmarks возвращает закладки пользователя; filter может быть nil
+func (s *service) GetBookmarks(userID uint, filter *model.BookmarkListFilter) ([]model.Bookmark, error) {
 	const op = "service.GetBookmarks"
 	log := s.log.With("op", op)
 
-	bookmarks, err := s.repo.GetBookmarks(userID)
+	if filter != nil && filter.WorkspaceID != 0 {
+		if _, err := s.workspaceRole(userID, filter.WorkspaceID); err != nil {
+			return nil, err
+		}
+	}
+	if filter != nil && filter.Tag != "" {
+		tag, ok := model.NormalizeTag(filter.Tag)
+		if !ok {
+			return nil, errors.New(errors.CodeInvalidRequest, "Invalid tag")
+		}
+		filter.Tag = tag
+	}
+
+	var bookmarks []model.Bookmark
+	var err error
+	if filter != nil && (filter.State != "" || filter.Favorite != nil || filter.Snoozed != nil || filter.Kind != "" ||
+		filter.FolderID != nil || filter.Tag != "" || filter.WorkspaceID != 0) {
+		bookmarks, err = s.repo.GetFilteredBookmarks(userID, filter)
+	} else {
+		bookmarks, err = s.repo.GetBookmarks(userID)
+	}
 	if err != nil {
 		log.Error("failed to get bookmarks", "error", err, "user_id", userID)
 		return nil, err
@@ -489,8 +840,28 @@ func (s *service) GetBookmarks(userID uint) ([]model.Bookmark, error) {
 	return bookmarks, nil
 }
 
+// GetBookmarkByID возвращает закладку пользователя или закладку коллекции, в которой он состоит
 func (s *service) GetBookmarkByID(userID, bookmarkID uint) (*model.Bookmark, error) {
-	const op = "service.GetBookmarkByID"
+	return s.getBookmark(userID, bookmarkID, accessRead)
+}
+
+// bookmarkAccess уровень доступа, который нужен для действия с закладкой
+type bookmarkAccess int
+
+const (
+	// accessRead просмотр: автор закладки или любой участник её коллекции или рабочего пространства
+	accessRead bookmarkAccess = iota
+	// accessEdit изменение: автор, редактор или владелец коллекции, любой участник пространства
+	accessEdit
+	// accessDelete удаление: автор, владелец коллекции или администратор пространства
+	accessDelete
+	// accessAuthor личные настройки вроде напоминаний, которые действуют только для автора
+	accessAuthor
+)
+
+// getBookmark возвращает закладку, если у пользователя есть нужный уровень доступа к ней
+func (s *service) getBookmark(userID, bookmarkID uint, access bookmarkAccess) (*model.Bookmark, error) {
+	const op = "service.getBookmark"
 	log := s.log.With("op", op)
 
 	bookmark, err := s.repo.GetBookmarkByID(bookmarkID)
@@ -499,40 +870,93 @@ func (s *service) GetBookmarkByID(userID, bookmarkID uint) (*model.Bookmark, err
 		return nil, err
 	}
 
-	if bookmark.UserID != userID {
-		log.Error("bookmark doesn't belong to user", "user_id", userID, "bookmark_id", bookmarkID, "bookmark_user_id", bookmark.UserID)
-		return nil, errors.New(errors.CodeForbidden, "Bookmark doesn't belong to user")
+	// Закладки пространства доступны только его участникам, в том числе их авторам
+	if bookmark.WorkspaceID != nil {
+		role, err := s.workspaceRole(userID, *bookmark.WorkspaceID)
+		if err != nil {
+			log.Debug("bookmark belongs to another workspace", "user_id", userID, "bookmark_id", bookmarkID)
+			return nil, err
+		}
+		if bookmark.UserID != userID && (access == accessAuthor || access == accessDelete && role != model.WorkspaceAdmin) {
+			return nil, errors.New(errors.CodeForbidden, "Insufficient workspace role")
+		}
+		return bookmark, nil
 	}
 
-	log.Debug("bookmark retrieved successfully", "bookmark_id", bookmarkID, "user_id", userID)
-	return bookmark, nil
+	if bookmark.UserID == userID {
+		log.Debug("bookmark retrieved successfully", "bookmark_id", bookmarkID, "user_id", userID)
+		return bookmark, nil
+	}
+
+	if bookmark.CollectionID != nil && access != accessAuthor {
+		role, err := s.collectionRole(userID, *bookmark.CollectionID)
+		if err != nil && !errors.IsErrorCode(err, errors.CodeForbidden) {
+			return nil, err
+		}
+		if role != "" {
+			if !roleAllows(role, access) {
+				log.Debug("insufficient collection role", "user_id", userID, "bookmark_id", bookmarkID, "role", role)
+				return nil, errors.New(errors.CodeForbidden, "Insufficient collection role")
+			}
+			return bookmark, nil
+		}
+	}
+
+	log.Error("bookmark doesn't belong to user", "user_id", userID, "bookmark_id", bookmarkID, "bookmark_user_id", bookmark.UserID)
+	return nil, errors.New(errors.CodeForbidden, "Bookmark doesn't belong to user")
+}
+
+// roleAllows проверяет, достаточно ли роли в коллекции для действия с её закладкой
+func roleAllows(role string, access bookmarkAccess) bool {
+	switch access {
+	case accessRead:
+		return model.IsValidRole(role)
+	case accessEdit:
+		return model.CanEdit(role)
+	case accessDelete:
+		return role == model.RoleOwner
+	}
+	return false
 }
 
 func (s *service) PatchBookmark(userID, bookmarkID uint, patch *model.PatchBookmarkRequest) (*model.Bookmark, error) {
 	const op = "service.PatchBookmark"
 	log := s.log.With("op", op)
 
-	bookmark, err := s.GetBookmarkByID(userID, bookmarkID)
+	bookmark, err := s.getBookmark(userID, bookmarkID, accessEdit)
 	if err != nil {
 		log.Error("failed to get bookmark for update", "error", err, "bookmark_id", bookmarkID, "user_id", userID)
 		return nil, err
 	}
 
+	// Личные папки видит только автор, поэтому переносить между ними может только он
+	if patch.FolderID != nil && bookmark.WorkspaceID == nil && bookmark.UserID != userID {
+		return nil, errors.New(errors.CodeForbidden, "Only the author can move the bookmark between folders")
+	}
+	before := *bookmark
+	if err := s.applyBookmarkPlacement(bookmark, patch.FolderID, patch.Tags); err != nil {
+		return nil, err
+	}
+	if patch.CollectionID != nil {
+		if err := s.applyBookmarkCollection(userID, bookmark, *patch.CollectionID); err != nil {
+			return nil, err
+		}
+	}
 	if patch.Title != nil {
 		bookmark.Title = *patch.Title
 	}
+	var articleText string
 	if patch.URL != nil {
 		bookmark.URL = *patch.URL
-		ctx := context.Background()
-		faviconBase64, err := parsers.FetchFaviconBase64(ctx, s.cache, *patch.URL)
-		if err != nil {
-			log.Error("failed to fetch favicon", "error", err, "url", *patch.URL)
-		}
-		bookmark.Favicon = faviconBase64
+		bookmark.ResetHealth()
+		articleText = s.fillPageDetails(bookmark)
 	}
 	if patch.ShowText != nil {
 		bookmark.ShowText = *patch.ShowText
 	}
+	if bookmark.Title == "" {
+		bookmark.Title = titleFromURL(bookmark.URL)
+	}
 	bookmark.UpdatedAt = time.Now()
 
 	err = s.repo.UpdateBookmark(bookmark)
@@ -541,6 +965,16 @@ func (s *service) PatchBookmark(userID, bookmarkID uint, patch *model.PatchBookm
 		return nil, err
 	}
 
+	if patch.URL != nil {
+		s.saveBookmarkContent(bookmark, articleText)
+		s.refreshPreview(bookmark)
+		s.archiveBookmarkAsync(bookmark)
+	}
+	if bookmark.VisitCount > 0 {
+		s.invalidateTopSites(bookmark.UserID)
+	}
+	s.recordActivity(bookmarkChanges(userID, &before, bookmark)...)
+
 	log.Debug("bookmark updated successfully", "bookmark_id", bookmarkID, "user_id", userID)
 	return bookmark, nil
 }
@@ -549,23 +983,48 @@ func (s *service) DeleteBookmark(userID, bookmarkID uint) error {
 	const op = "service.DeleteBookmark"
 	log := s.log.With("op", op)
 
-	bookmark, err := s.GetBookmarkByID(userID, bookmarkID)
+	bookmark, err := s.getBookmark(userID, bookmarkID, accessDelete)
 	if err != nil {
 		log.Error("failed to get bookmark for deletion", "error", err, "bookmark_id", bookmarkID, "user_id", userID)
 		return err
 	}
 
+	if bookmark.PreviewToken != "" {
+		if err := s.repo.DeleteBookmarkPreview(bookmark.ID); err != nil {
+			log.Error("failed to delete bookmark preview", "error", err, "bookmark_id", bookmarkID)
+		}
+	}
+
+	if bookmark.SnapshotAt != nil {
+		if err := s.removeBookmarkArchive(bookmark.ID); err != nil {
+			log.Error("failed to delete bookmark archive", "error", err, "bookmark_id", bookmarkID)
+		}
+	}
+
+	if err := s.repo.DeleteBookmarkContent(bookmark.ID); err != nil {
+		log.Error("failed to delete bookmark content", "error", err, "bookmark_id", bookmarkID)
+	}
+
+	if err := s.repo.DeleteBookmarkComments(bookmark.ID); err != nil {
+		log.Error("failed to delete bookmark comments", "error", err, "bookmark_id", bookmarkID)
+	}
+
 	err = s.repo.DeleteBookmark(bookmark.ID)
 	if err != nil {
 		log.Error("failed to delete bookmark", "error", err, "bookmark_id", bookmarkID)
 		return err
 	}
+	if bookmark.VisitCount > 0 {
+		s.invalidateTopSites(bookmark.UserID)
+	}
+	s.recordActivity(newActivity(userID, model.ActivityDeleted, bookmark))
 
 	log.Debug("bookmark deleted successfully", "bookmark_id", bookmarkID, "user_id", userID)
 	return nil
 }
 
-func (s *service) ImportBookmarks(userID uint, base64Data string) ([]model.Bookmark, error) {
+// ImportBookmarks импортирует закладки из HTML-файла в личные закладки или в рабочее пространство, если workspaceID не 0
+func (s *service) ImportBookmarks(userID, workspaceID uint, base64Data string) ([]model.Bookmark, error) {
 	const op = "service.ImportBookmarks"
 	log := s.log.With("op", op)
 
@@ -577,15 +1036,25 @@ func (s *service) ImportBookmarks(userID uint, base64Data string) ([]model.Bookm
 		return nil, errors.New(errors.CodeInvalidRequest, "Failed to parse bookmarks file")
 	}
 
-	user, err := s.repo.GetUserByID(userID)
+	scope, err := s.resolveWorkspace(userID, &workspaceID)
 	if err != nil {
-		log.Error("failed to get user", "error", err, "user_id", userID)
-		return nil, errors.New(errors.CodeInvalidRequest, "Failed to get user")
+		return nil, err
 	}
+	if scope != nil {
+		if err := s.checkWorkspaceBookmarkLimit(*scope, len(parsedBookmarks)); err != nil {
+			return nil, err
+		}
+	} else {
+		user, err := s.repo.GetUserByID(userID)
+		if err != nil {
+			log.Error("failed to get user", "error", err, "user_id", userID)
+			return nil, errors.New(errors.CodeInvalidRequest, "Failed to get user")
+		}
 
-	if int(user.AmountOfBookmarks)-len(parsedBookmarks) < 0 {
-		log.Error("reached maximum bookmarks", "user_id", userID, "amount", user.AmountOfBookmarks)
-		return nil, errors.New(errors.CodeInvalidRequest, "Reached maximum bookmarks")
+		if int(user.AmountOfBookmarks)-len(parsedBookmarks) < 0 {
+			log.Error("reached maximum bookmarks", "user_id", userID, "amount", user.AmountOfBookmarks)
+			return nil, errors.New(errors.CodeInvalidRequest, "Reached maximum bookmarks")
+		}
 	}
 
 	now := time.Now()
@@ -593,8 +1062,11 @@ func (s *service) ImportBookmarks(userID uint, base64Data string) ([]model.Bookm
 
 	for _, bookmark := range parsedBookmarks {
 		bookmark.UserID = userID
+		bookmark.WorkspaceID = scope
 		bookmark.CreatedAt = now
 		bookmark.UpdatedAt = now
+		bookmark.Kind = parsers.ClassifyURL(bookmark.URL)
+		applyImportedState(&bookmark, bookmark.State, bookmark.Favorite, now)
 
 		err = s.repo.AddBookmark(&bookmark)
 		if err != nil {
@@ -604,22 +1076,30 @@ func (s *service) ImportBookmarks(userID uint, base64Data string) ([]model.Bookm
 
 		savedBookmarks = append(savedBookmarks, bookmark)
 	}
+	s.recordImport(userID, savedBookmarks)
 
 	log.Debug("bookmarks imported successfully", "user_id", userID, "count", len(savedBookmarks))
 	return savedBookmarks, nil
 }
 
-func (s *service) ExportBookmarks(userID uint) (string, error) {
+// ExportBookmarks выгружает в HTML личные закладки или закладки рабочего пространства, если workspaceID не 0
+func (s *service) ExportBookmarks(userID, workspaceID uint) (string, error) {
 	const op = "service.ExportBookmarks"
 	log := s.log.With("op", op)
 
-	bookmarks, err := s.repo.GetBookmarks(userID)
+	bookmarks, err := s.scopeBookmarks(userID, workspaceID)
 	if err != nil {
 		log.Error("failed to get bookmarks for export", "error", err, "user_id", userID)
 		return "", err
 	}
 
-	htmlBase64, err := parsers.ExportBookmarksToHTML(bookmarks)
+	exported, err := s.exportBookmarks(bookmarks)
+	if err != nil {
+		log.Error("failed to get comments for export", "error", err, "user_id", userID)
+		return "", err
+	}
+
+	htmlBase64, err := parsers.ExportBookmarksToHTML(exported)
 	if err != nil {
 		log.Error("failed to export bookmarks to HTML", "error", err, "user_id", userID)
 		return "", errors.New(errors.CodeInternalError, "Failed to export bookmarks")
@@ -629,53 +1109,77 @@ func (s *service) ExportBookmarks(userID uint) (string, error) {
 	return htmlBase64, nil
 }
 
-func (s *service) ImportBookmarksV2(userID uint, bookmarks []model.BookmarkV2Request) ([]model.Bookmark, error) {
+func (s *service) ImportBookmarksV2(userID, workspaceID uint, bookmarks []model.BookmarkV2Request) ([]model.Bookmark, error) {
 	const op = "service.ImportBookmarksV2"
 	log := s.log.With("op", op)
 
+	scope, err := s.resolveWorkspace(userID, &workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if scope != nil {
+		if err := s.checkWorkspaceBookmarkLimit(*scope, len(bookmarks)); err != nil {
+			return nil, err
+		}
+	}
+
 	importedBookmarks := make([]model.Bookmark, 0, len(bookmarks))
 
 	for i, bookmark := range bookmarks {
+		now := time.Now()
 		importedBookmarks = append(importedBookmarks, model.Bookmark{
-			UserID:    userID,
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
-			Title:     bookmark.Title,
-			URL:       bookmark.URL,
-			ShowText:  bookmark.ShowText,
-			Favicon:   bookmark.Favicon,
+			UserID:      userID,
+			WorkspaceID: scope,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+			Title:       bookmark.Title,
+			URL:         bookmark.URL,
+			ShowText:    bookmark.ShowText,
+			Favicon:     bookmark.Favicon,
+			Kind:        parsers.ClassifyURL(bookmark.URL),
 		})
+		applyImportedState(&importedBookmarks[i], bookmark.State, bookmark.Favorite, now)
 
 		if bookmark.Favicon == "" {
 			ctx := context.Background()
-			faviconBase64, err := parsers.FetchFaviconBase64(ctx, s.cache, bookmark.URL)
+			icons, err := parsers.FetchIconSet(ctx, s.cache, bookmark.URL)
 			if err != nil {
 				log.Error("failed to fetch favicon", "error", err, "url", bookmark.URL)
 			}
-			importedBookmarks[i].Favicon = faviconBase64
+			importedBookmarks[i].Icons = icons
+			importedBookmarks[i].Favicon = parsers.PrimaryIcon(icons)
 		}
 
 		err := s.repo.AddBookmark(&importedBookmarks[i])
 		if err != nil {
 			log.Error("failed to add bookmark", "error", err, "user_id", userID, "url", bookmark.URL)
+			s.recordImport(userID, importedBookmarks[:i])
 			return nil, err
 		}
 	}
+	s.recordImport(userID, importedBookmarks)
 
 	return importedBookmarks, nil
 }
 
-func (s *service) ExportBookmarksV2(userID uint) ([]model.Bookmark, error) {
+// ExportBookmarksV2 выгружает закладки вместе с комментариями к ним
+func (s *service) ExportBookmarksV2(userID, workspaceID uint) ([]model.ExportedBookmark, error) {
 	const op = "service.ExportBookmarksV2"
 	log := s.log.With("op", op)
 
-	bookmarks, err := s.repo.GetBookmarks(userID)
+	bookmarks, err := s.scopeBookmarks(userID, workspaceID)
 	if err != nil {
 		log.Error("failed to get bookmarks for export", "error", err, "user_id", userID)
 		return nil, err
 	}
 
-	return bookmarks, nil
+	exported, err := s.exportBookmarks(bookmarks)
+	if err != nil {
+		log.Error("failed to get comments for export", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	return exported, nil
 }
 
 func (s *service) GetUser(userID any) (*model.UserResponse, error) {
@@ -689,11 +1193,66 @@ func (s *service) GetUser(userID any) (*model.UserResponse, error) {
 	}
 
 	userResp := model.UserResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		Username:  user.Username,
-		IsPremium: user.IsPremium,
+		ID:                   user.ID,
+		Email:                user.Email,
+		Username:             user.Username,
+		IsPremium:            user.IsPremium,
+		StorageUsed:          user.StorageUsed,
+		StorageQuota:         s.storageQuota(user),
+		AutoRewriteRedirects: user.AutoRewriteRedirects,
+		DigestEnabled:        user.DigestEnabled,
+		DigestWeekday:        user.DigestWeekday,
+		DigestHour:           user.DigestHour,
+		DigestTimezone:       user.DigestTimezone,
+		DigestLanguage:       user.DigestLanguage,
+		TrackVisits:          user.TrackVisits,
+		DisplayName:          user.DisplayName,
+		Bio:                  user.Bio,
+		AvatarURL:            user.AvatarURL,
+		ProfilePublic:        user.ProfilePublic,
 	}
 
 	return &userResp, nil
 }
+
+func (s *service) UpdateUserSettings(userID uint, req *model.UpdateUserSettingsRequest) (*model.UserResponse, error) {
+	const op = "service.UpdateUserSettings"
+	log := s.log.With("op", op)
+
+	user, err := s.repo.GetUserByID(userID)
+	if err != nil {
+		log.Error("failed to get user", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	if req.AutoRewriteRedirects != nil {
+		user.AutoRewriteRedirects = *req.AutoRewriteRedirects
+	}
+	if err := applyDigestSettings(user, req); err != nil {
+		return nil, err
+	}
+	if err := applyProfileSettings(user, req); err != nil {
+		return nil, err
+	}
+	resetVisits := req.TrackVisits != nil && !*req.TrackVisits && user.TrackVisits
+	if req.TrackVisits != nil {
+		user.TrackVisits = *req.TrackVisits
+	}
+
+	if err := s.repo.SaveUser(user); err != nil {
+		log.Error("failed to save user settings", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	// Отключая статистику, пользователь ожидает, что собранные данные тоже исчезнут
+	if resetVisits {
+		if err := s.repo.ResetBookmarkVisits(userID); err != nil {
+			log.Error("failed to reset visit statistics", "error", err, "user_id", userID)
+			return nil, err
+		}
+		s.invalidateTopSites(userID)
+	}
+
+	log.Debug("user settings updated", "user_id", userID)
+	return s.GetUser(userID)
+}
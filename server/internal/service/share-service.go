@@ -0,0 +1,189 @@
+package service
+
+import (
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// CreateShareLink создаёт публичную ссылку только для чтения на папку, тег или выбранные закладки
+func (s *service) CreateShareLink(userID uint, req *model.CreateShareLinkRequest) (*model.ShareLinkResponse, error) {
+	const op = "service.CreateShareLink"
+	log := s.log.With("op", op)
+
+	now := time.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, errors.New(errors.CodeInvalidRequest, "Expiry time must be in the future")
+	}
+
+	link := &model.ShareLink{
+		UserID:    userID,
+		Kind:      req.Kind,
+		Title:     req.Title,
+		ExpiresAt: req.ExpiresAt,
+		CreatedAt: now,
+	}
+
+	switch req.Kind {
+	case model.ShareFolder:
+		if req.FolderID == nil {
+			return nil, errors.New(errors.CodeInvalidRequest, "Folder ID is required")
+		}
+		folder, err := s.getUserFolder(userID, *req.FolderID)
+		if err != nil {
+			return nil, err
+		}
+		link.FolderID = &folder.ID
+		if link.Title == "" {
+			link.Title = folder.Name
+		}
+	case model.ShareTag:
+		tag, ok := model.NormalizeTag(req.Tag)
+		if !ok {
+			return nil, errors.New(errors.CodeInvalidRequest, "Invalid tag")
+		}
+		link.Tag = tag
+		if link.Title == "" {
+			link.Title = "#" + tag
+		}
+	case model.ShareSelection:
+		if len(req.BookmarkIDs) == 0 || len(req.BookmarkIDs) > model.MaxShareSelection {
+			return nil, errors.New(errors.CodeInvalidRequest, "Select from 1 to 500 bookmarks")
+		}
+		bookmarks, err := s.repo.GetBookmarksByIDs(userID, req.BookmarkIDs)
+		if err != nil {
+			return nil, err
+		}
+		if len(bookmarks) == 0 {
+			return nil, errors.New(errors.CodeNotFound, "Bookmarks not found")
+		}
+		link.BookmarkIDs = make([]uint, 0, len(bookmarks))
+		for i := range bookmarks {
+			link.BookmarkIDs = append(link.BookmarkIDs, bookmarks[i].ID)
+		}
+		if link.Title == "" {
+			link.Title = "Shared bookmarks"
+		}
+	}
+
+	if req.Password != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		if err != nil {
+			log.Debug("failed to hash share password", "error", err)
+			return nil, errors.New(errors.CodeInvalidRequest, "Invalid password")
+		}
+		link.PasswordHash = string(hash)
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		log.Error("failed to generate share token", "error", err)
+		return nil, errors.New(errors.CodeInternalError, "Failed to create share link")
+	}
+	link.Token = token
+
+	if err := s.repo.CreateShareLink(link); err != nil {
+		return nil, err
+	}
+
+	log.Debug("share link created", "share_id", link.ID, "user_id", userID, "kind", link.Kind)
+	response := model.NewShareLinkResponse(link, s.cfg.PublicURL)
+	return &response, nil
+}
+
+func (s *service) GetShareLinks(userID uint) ([]model.ShareLinkResponse, error) {
+	links, err := s.repo.GetShareLinks(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.ShareLinkResponse, 0, len(links))
+	for i := range links {
+		responses = append(responses, model.NewShareLinkResponse(&links[i], s.cfg.PublicURL))
+	}
+	return responses, nil
+}
+
+// DeleteShareLink отзывает общую ссылку, после чего она перестаёт открываться
+func (s *service) DeleteShareLink(userID, shareID uint) error {
+	const op = "service.DeleteShareLink"
+	log := s.log.With("op", op)
+
+	link, err := s.repo.GetShareLinkByID(shareID)
+	if err != nil {
+		return err
+	}
+	if link.UserID != userID {
+		return errors.New(errors.CodeForbidden, "Share link doesn't belong to user")
+	}
+
+	if err := s.repo.DeleteShareLink(link.ID); err != nil {
+		return err
+	}
+
+	log.Debug("share link revoked", "share_id", shareID, "user_id", userID)
+	return nil
+}
+
+// GetSharedCollection возвращает содержимое общей ссылки и учитывает просмотр.
+// Для защищённой ссылки без пароля или с неверным паролем возвращается CodeUnauthorized
+func (s *service) GetSharedCollection(token, password string) (*model.SharedCollectionResponse, error) {
+	const op = "service.GetSharedCollection"
+	log := s.log.With("op", op)
+
+	link, err := s.repo.GetShareLinkByToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if link.IsExpired(now) {
+		return nil, errors.New(errors.CodeNotFound, "Share link has expired")
+	}
+
+	if link.PasswordHash != "" {
+		if password == "" {
+			return nil, errors.New(errors.CodeUnauthorized, "Password required")
+		}
+		if bcrypt.CompareHashAndPassword([]byte(link.PasswordHash), []byte(password)) != nil {
+			return nil, errors.New(errors.CodeUnauthorized, "Invalid password")
+		}
+	}
+
+	var bookmarks []model.Bookmark
+	switch link.Kind {
+	case model.ShareFolder:
+		if link.FolderID == nil {
+			return nil, errors.New(errors.CodeNotFound, "Share link not found")
+		}
+		folders, err := s.repo.GetFolders(link.UserID)
+		if err != nil {
+			return nil, err
+		}
+		bookmarks, err = s.repo.GetBookmarksInFolders(link.UserID, folderSubtree(folders, *link.FolderID))
+		if err != nil {
+			return nil, err
+		}
+	case model.ShareTag:
+		bookmarks, err = s.repo.GetFilteredBookmarks(link.UserID, &model.BookmarkListFilter{Tag: link.Tag})
+		if err != nil {
+			return nil, err
+		}
+	case model.ShareSelection:
+		if len(link.BookmarkIDs) > 0 {
+			bookmarks, err = s.repo.GetBookmarksByIDs(link.UserID, link.BookmarkIDs)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := s.repo.RecordShareView(link.ID, now); err != nil {
+		log.Error("failed to record share view", "error", err, "share_id", link.ID)
+	}
+
+	response := model.NewSharedCollectionResponse(link, bookmarks)
+	return &response, nil
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+)
+
+// applyImportedState переносит состояние из импортируемого файла.
+// Закладки без сведений о прочтении считаются прочитанными, чтобы импорт не переполнял входящие
+func applyImportedState(bookmark *model.Bookmark, state string, favorite bool, now time.Time) {
+	if !model.IsValidState(state) {
+		state = model.StateRead
+	}
+	bookmark.SetState(state, now)
+	bookmark.SetFavorite(favorite, now)
+}
+
+// UpdateBookmarkState переводит закладку в другое состояние и/или меняет отметку избранного.
+// Состояние личное, поэтому менять его может только автор закладки
+func (s *service) UpdateBookmarkState(userID, bookmarkID uint, req *model.UpdateBookmarkStateRequest) (*model.Bookmark, error) {
+	const op = "service.UpdateBookmarkState"
+	log := s.log.With("op", op)
+
+	if req.State == nil && req.Favorite == nil {
+		return nil, errors.New(errors.CodeInvalidRequest, "No fields to update")
+	}
+	if req.State != nil && !model.IsValidState(*req.State) {
+		return nil, errors.New(errors.CodeInvalidRequest, "Invalid bookmark state")
+	}
+
+	bookmark, err := s.getBookmark(userID, bookmarkID, accessAuthor)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if req.State != nil {
+		bookmark.SetState(*req.State, now)
+	}
+	if req.Favorite != nil {
+		bookmark.SetFavorite(*req.Favorite, now)
+	}
+
+	if err := s.repo.UpdateBookmarkState(bookmark); err != nil {
+		log.Error("failed to update bookmark state", "error", err, "bookmark_id", bookmarkID)
+		return nil, err
+	}
+
+	log.Debug("bookmark state updated", "bookmark_id", bookmarkID, "user_id", userID, "state", bookmark.State)
+	return bookmark, nil
+}
+
+// UpdateReadingProgress сохраняет позицию чтения автора закладки. Дочитанная до конца непрочитанная закладка
+// считается прочитанной
+func (s *service) UpdateReadingProgress(userID, bookmarkID uint, progress float64) (*model.Bookmark, error) {
+	const op = "service.UpdateReadingProgress"
+	log := s.log.With("op", op)
+
+	if progress < 0 || progress > 1 {
+		return nil, errors.New(errors.CodeInvalidRequest, "Progress must be between 0 and 1")
+	}
+
+	bookmark, err := s.getBookmark(userID, bookmarkID, accessAuthor)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	bookmark.Progress = progress
+	bookmark.ProgressAt = &now
+	if progress >= 1 && bookmark.State == model.StateUnread {
+		bookmark.SetState(model.StateRead, now)
+	}
+
+	if err := s.repo.UpdateBookmarkState(bookmark); err != nil {
+		log.Error("failed to update reading progress", "error", err, "bookmark_id", bookmarkID)
+		return nil, err
+	}
+
+	return bookmark, nil
+}
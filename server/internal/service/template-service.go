@@ -0,0 +1,354 @@
+package service
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/repository"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+)
+
+// maxFolderDepth ограничивает глубину пути папки, чтобы испорченная иерархия не зациклила копирование
+const maxFolderDepth = 32
+
+// bookmarkURLKey приводит адрес к виду для поиска дублей: схема и хост в нижнем регистре,
+// без фрагмента и завершающего слэша
+func bookmarkURLKey(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return rawURL
+	}
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	parsed.Host = strings.ToLower(parsed.Host)
+	parsed.Fragment = ""
+	parsed.RawFragment = ""
+	parsed.Path = strings.TrimRight(parsed.Path, "/")
+	parsed.RawPath = ""
+	return parsed.String()
+}
+
+// getTemplate возвращает коллекцию, опубликованную как шаблон
+func (s *service) getTemplate(collectionID uint) (*model.Collection, error) {
+	collection, err := s.repo.GetCollectionByID(collectionID)
+	if err != nil || !collection.IsTemplate {
+		return nil, errors.New(errors.CodeNotFound, "Template not found")
+	}
+	return collection, nil
+}
+
+// newTemplateResponse формирует данные шаблона без закладок
+func newTemplateResponse(collection *model.Collection, bookmarkCount int64) model.TemplateResponse {
+	return model.TemplateResponse{
+		ID:            collection.ID,
+		Name:          collection.Name,
+		Description:   collection.Description,
+		StarterPack:   collection.StarterPack,
+		BookmarkCount: bookmarkCount,
+		UpdatedAt:     collection.UpdatedAt,
+	}
+}
+
+// GetTemplates возвращает опубликованные шаблоны коллекций; стартовые наборы идут первыми
+func (s *service) GetTemplates() ([]model.TemplateResponse, error) {
+	const op = "service.GetTemplates"
+	log := s.log.With("op", op)
+
+	collections, err := s.repo.GetTemplateCollections()
+	if err != nil {
+		log.Error("failed to get templates", "error", err)
+		return nil, err
+	}
+	ids := make([]uint, 0, len(collections))
+	for _, collection := range collections {
+		ids = append(ids, collection.ID)
+	}
+	counts, err := s.repo.CountCollectionBookmarks(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]model.TemplateResponse, 0, len(collections))
+	for i := range collections {
+		templates = append(templates, newTemplateResponse(&collections[i], counts[collections[i].ID]))
+	}
+	return templates, nil
+}
+
+// GetTemplate возвращает шаблон вместе с закладками, чтобы посмотреть его перед копированием
+func (s *service) GetTemplate(collectionID uint) (*model.TemplateResponse, error) {
+	const op = "service.GetTemplate"
+	log := s.log.With("op", op)
+
+	collection, err := s.getTemplate(collectionID)
+	if err != nil {
+		return nil, err
+	}
+	bookmarks, err := s.repo.GetCollectionBookmarks(collectionID)
+	if err != nil {
+		log.Error("failed to get template bookmarks", "error", err, "collection_id", collectionID)
+		return nil, err
+	}
+
+	response := newTemplateResponse(collection, int64(len(bookmarks)))
+	response.Bookmarks = model.NewSharedBookmarks(bookmarks)
+	return &response, nil
+}
+
+// PublishTemplate публикует коллекцию как шаблон или снимает её с публикации. Доступно только владельцу коллекции;
+// снятый с публикации шаблон перестаёт быть стартовым набором
+func (s *service) PublishTemplate(userID, collectionID uint, req *model.TemplateRequest) (*model.CollectionResponse, error) {
+	if err := s.requireCollectionOwner(userID, collectionID); err != nil {
+		return nil, err
+	}
+
+	collection, err := s.setTemplate(collectionID, req.IsTemplate, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := model.NewCollectionResponse(collection, model.RoleOwner)
+	return &response, nil
+}
+
+// SetCollectionTemplate публикует любую коллекцию как шаблон или стартовый набор от имени администратора
+func (s *service) SetCollectionTemplate(collectionID uint, req *model.AdminTemplateRequest) (*model.Collection, error) {
+	if req.StarterPack && !req.IsTemplate {
+		return nil, errors.New(errors.CodeInvalidRequest, "A starter pack must be published as a template")
+	}
+	return s.setTemplate(collectionID, req.IsTemplate, &req.StarterPack)
+}
+
+// setTemplate меняет признаки шаблона коллекции; starterPack nil оставляет признак стартового набора как есть
+func (s *service) setTemplate(collectionID uint, isTemplate bool, starterPack *bool) (*model.Collection, error) {
+	const op = "service.setTemplate"
+	log := s.log.With("op", op)
+
+	collection, err := s.repo.GetCollectionByID(collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	collection.IsTemplate = isTemplate
+	if starterPack != nil {
+		collection.StarterPack = *starterPack
+	}
+	if !isTemplate {
+		collection.StarterPack = false
+	}
+	collection.UpdatedAt = time.Now()
+	if err := s.repo.SaveCollection(collection); err != nil {
+		log.Error("failed to update collection template", "error", err, "collection_id", collectionID)
+		return nil, err
+	}
+
+	log.Info("collection template changed", "collection_id", collectionID, "is_template", collection.IsTemplate, "starter_pack", collection.StarterPack)
+	return collection, nil
+}
+
+// CloneTemplate копирует шаблон в личные закладки пользователя: в папку с именем шаблона, сохраняя вложенные папки,
+// теги и порядок закладок. Закладки, которые уже есть у пользователя, пропускаются; повторное копирование
+// дополняет ту же папку
+func (s *service) CloneTemplate(userID, collectionID uint) (*model.TemplateCloneResponse, error) {
+	const op = "service.CloneTemplate"
+	log := s.log.With("op", op)
+
+	collection, err := s.getTemplate(collectionID)
+	if err != nil {
+		return nil, err
+	}
+	// Закладки коллекции идут от новых к старым, копируем от старых, чтобы сохранить порядок
+	source, err := s.repo.GetCollectionBookmarks(collectionID)
+	if err != nil {
+		log.Error("failed to get template bookmarks", "error", err, "collection_id", collectionID)
+		return nil, err
+	}
+	slices.Reverse(source)
+
+	paths, err := s.templateFolderPaths(source)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetBookmarks(userID)
+	if err != nil {
+		log.Error("failed to get bookmarks", "error", err, "user_id", userID)
+		return nil, err
+	}
+	known := make(map[string]uint, len(existing))
+	for _, bookmark := range existing {
+		known[bookmarkURLKey(bookmark.URL)] = bookmark.ID
+	}
+
+	folders, err := newFolderTree(s.repo, userID)
+	if err != nil {
+		return nil, err
+	}
+	rootID, err := folders.ensure(0, collection.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.TemplateCloneResponse{
+		FolderID: rootID,
+		Added:    make([]model.Bookmark, 0, len(source)),
+		Skipped:  []model.SkippedBookmarkResponse{},
+	}
+	now := time.Now()
+	for i := range source {
+		original := &source[i]
+		key := bookmarkURLKey(original.URL)
+		if id, ok := known[key]; ok {
+			report.Skipped = append(report.Skipped, model.SkippedBookmarkResponse{Title: original.Title, URL: original.URL, BookmarkID: id})
+			continue
+		}
+
+		folderID := rootID
+		if original.FolderID != nil {
+			for _, name := range paths[*original.FolderID] {
+				if folderID, err = folders.ensure(folderID, name); err != nil {
+					s.recordImport(userID, report.Added)
+					return nil, err
+				}
+			}
+		}
+
+		// Время создания растёт вместе с позицией в шаблоне, последняя закладка получает текущее время
+		createdAt := now.Add(-time.Duration(len(source)-1-i) * time.Millisecond)
+		bookmark := model.Bookmark{
+			UserID:      userID,
+			FolderID:    &folderID,
+			Title:       original.Title,
+			URL:         original.URL,
+			Description: original.Description,
+			Favicon:     original.Favicon,
+			Icons:       original.Icons,
+			ImageURL:    original.ImageURL,
+			SiteName:    original.SiteName,
+			Language:    original.Language,
+			Kind:        original.Kind,
+			Tags:        original.Tags,
+			ShowText:    original.ShowText,
+			CreatedAt:   createdAt,
+			UpdatedAt:   createdAt,
+		}
+		bookmark.SetState(model.StateUnread, createdAt)
+		if err := s.repo.AddBookmark(&bookmark); err != nil {
+			log.Error("failed to add bookmark", "error", err, "user_id", userID, "url", original.URL)
+			s.recordImport(userID, report.Added)
+			return nil, err
+		}
+		known[key] = bookmark.ID
+		report.Added = append(report.Added, bookmark)
+	}
+	s.recordImport(userID, report.Added)
+	report.FoldersCreated = folders.created
+
+	log.Debug("template cloned", "collection_id", collectionID, "user_id", userID, "added", len(report.Added), "skipped", len(report.Skipped))
+	return report, nil
+}
+
+// templateFolderPaths возвращает для папок закладок шаблона путь из имён от верхнего уровня.
+// Папки принадлежат авторам закладок, поэтому загружаются по каждому автору
+func (s *service) templateFolderPaths(bookmarks []model.Bookmark) (map[uint][]string, error) {
+	byID := make(map[uint]model.Folder)
+	loaded := make(map[uint]bool)
+	for _, bookmark := range bookmarks {
+		if bookmark.FolderID == nil || loaded[bookmark.UserID] {
+			continue
+		}
+		loaded[bookmark.UserID] = true
+		folders, err := s.repo.GetFolders(bookmark.UserID)
+		if err != nil {
+			return nil, err
+		}
+		for _, folder := range folders {
+			byID[folder.ID] = folder
+		}
+	}
+
+	paths := make(map[uint][]string)
+	for _, bookmark := range bookmarks {
+		if bookmark.FolderID == nil {
+			continue
+		}
+		if _, ok := paths[*bookmark.FolderID]; ok {
+			continue
+		}
+		var path []string
+		for id := bookmark.FolderID; id != nil && len(path) < maxFolderDepth; {
+			folder, ok := byID[*id]
+			if !ok {
+				break
+			}
+			path = append(path, folder.Name)
+			id = folder.ParentID
+		}
+		slices.Reverse(path)
+		paths[*bookmark.FolderID] = path
+	}
+	return paths, nil
+}
+
+// folderTree личные папки пользователя по родителю и имени; недостающие папки создаются при обращении
+type folderTree struct {
+	repo    repository.Repository
+	ids     map[folderKey]uint
+	userID  uint
+	created int
+}
+
+// folderKey папка по родителю и имени; parentID 0 — верхний уровень
+type folderKey struct {
+	name     string
+	parentID uint
+}
+
+func newFolderTree(repo repository.Repository, userID uint) (*folderTree, error) {
+	folders, err := repo.GetFolders(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := &folderTree{repo: repo, userID: userID, ids: make(map[folderKey]uint, len(folders))}
+	for _, folder := range folders {
+		key := folderKey{name: folder.Name}
+		if folder.ParentID != nil {
+			key.parentID = *folder.ParentID
+		}
+		// GetFolders сортирует по имени, при одинаковых именах берём первую папку
+		if _, ok := tree.ids[key]; !ok {
+			tree.ids[key] = folder.ID
+		}
+	}
+	return tree, nil
+}
+
+// ensure возвращает ID папки с таким именем внутри родителя, создавая её при необходимости
+func (t *folderTree) ensure(parentID uint, name string) (uint, error) {
+	key := folderKey{name: name, parentID: parentID}
+	if id, ok := t.ids[key]; ok {
+		return id, nil
+	}
+
+	now := time.Now()
+	folder := &model.Folder{
+		UserID:    t.userID,
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if parentID != 0 {
+		folder.ParentID = &parentID
+	}
+	if err := t.repo.SaveFolder(folder); err != nil {
+		return 0, err
+	}
+
+	t.ids[key] = folder.ID
+	t.created++
+	return folder.ID, nil
+}
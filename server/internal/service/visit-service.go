@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+)
+
+const (
+	defaultUsageLimit = 50
+	maxUsageLimit     = 200
+
+	defaultTopSitesLimit = 12
+	// maxTopSites сколько закладок ранжируется и хранится в кэше
+	maxTopSites = 100
+)
+
+// VisitBookmark возвращает адрес для перехода по закладке и учитывает посещение,
+// если пользователь не отключил статистику. Без авторизации (userID == 0) нужна подпись ссылки.
+// Учитываются только переходы автора: участники коллекции или пространства просто переходят по ссылке
+func (s *service) VisitBookmark(userID, bookmarkID uint, signature string) (string, error) {
+	const op = "service.VisitBookmark"
+	log := s.log.With("op", op)
+
+	var bookmark *model.Bookmark
+	var err error
+	if userID != 0 {
+		bookmark, err = s.GetBookmarkByID(userID, bookmarkID)
+	} else {
+		if !model.ValidClickSignature(bookmarkID, signature) {
+			return "", errors.New(errors.CodeUnauthorized, "Invalid link signature")
+		}
+		bookmark, err = s.repo.GetBookmarkByID(bookmarkID)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	target := safeLinkURL(bookmark.URL)
+	if target == "" {
+		return "", errors.New(errors.CodeDataInvalid, "Bookmark URL can't be opened")
+	}
+
+	if userID != 0 && userID != bookmark.UserID {
+		return target, nil
+	}
+
+	// Подписанные ссылки открываются из плиток новой вкладки автора, поэтому такой переход считается переходом автора
+	user, err := s.repo.GetUserByID(bookmark.UserID)
+	if err != nil {
+		log.Error("failed to get visitor", "error", err, "user_id", bookmark.UserID)
+		return target, nil
+	}
+
+	// Ошибка учёта не должна мешать переходу
+	if user.TrackVisits {
+		now := time.Now()
+		frecency := bookmark.FrecencyAt(now, s.frecencyHalfLife()) + 1
+		if err := s.repo.RecordBookmarkVisit(bookmark.ID, now, frecency); err != nil {
+			log.Error("failed to record visit", "error", err, "bookmark_id", bookmark.ID)
+		}
+		s.invalidateTopSites(bookmark.UserID)
+	}
+
+	return target, nil
+}
+
+// GetBookmarkUsage возвращает самые посещаемые закладки пользователя
+func (s *service) GetBookmarkUsage(userID uint, filter *model.UsageStatsFilter) ([]model.Bookmark, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultUsageLimit
+	}
+	limit = min(limit, maxUsageLimit)
+
+	return s.repo.GetVisitedBookmarks(userID, limit)
+}
+
+// GetDomainUsage возвращает статистику переходов, сгруппированную по сайтам
+func (s *service) GetDomainUsage(userID uint, filter *model.UsageStatsFilter) ([]model.DomainUsageResponse, error) {
+	const op = "service.GetDomainUsage"
+	log := s.log.With("op", op)
+
+	bookmarks, err := s.repo.GetVisitedBookmarks(userID, 0)
+	if err != nil {
+		log.Error("failed to get visited bookmarks", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	byDomain := make(map[string]*model.DomainUsageResponse)
+	for i := range bookmarks {
+		domain := titleFromURL(bookmarks[i].URL)
+		usage, ok := byDomain[domain]
+		if !ok {
+			usage = &model.DomainUsageResponse{Domain: domain}
+			byDomain[domain] = usage
+		}
+		usage.VisitCount += bookmarks[i].VisitCount
+		usage.Bookmarks++
+		if visited := bookmarks[i].LastVisitedAt; visited != nil && (usage.LastVisitedAt == nil || visited.After(*usage.LastVisitedAt)) {
+			usage.LastVisitedAt = visited
+		}
+	}
+
+	domains := make([]model.DomainUsageResponse, 0, len(byDomain))
+	for _, usage := range byDomain {
+		domains = append(domains, *usage)
+	}
+	sort.Slice(domains, func(i, j int) bool {
+		if domains[i].VisitCount != domains[j].VisitCount {
+			return domains[i].VisitCount > domains[j].VisitCount
+		}
+		return domains[i].Domain < domains[j].Domain
+	})
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultUsageLimit
+	}
+	if len(domains) > min(limit, maxUsageLimit) {
+		domains = domains[:min(limit, maxUsageLimit)]
+	}
+
+	return domains, nil
+}
+
+func (s *service) frecencyHalfLife() time.Duration {
+	return time.Duration(s.cfg.FrecencyHalfLifeDays) * 24 * time.Hour
+}
+
+// invalidateTopSites сбрасывает кэш «топ сайтов»; ошибка только логируется, кэш истечёт сам
+func (s *service) invalidateTopSites(userID uint) {
+	if err := s.cache.InvalidateTopSites(context.Background(), userID); err != nil {
+		s.log.Error("failed to invalidate top sites", "error", err, "user_id", userID)
+	}
+}
+
+// GetTopSites возвращает самые используемые закладки, ранжированные по частоте посещений
+// с затуханием по давности. Рейтинг кэшируется в Redis на TopSitesCacheTTLSec
+func (s *service) GetTopSites(userID uint, limit int) ([]model.TopSiteResponse, error) {
+	const op = "service.GetTopSites"
+	log := s.log.With("op", op)
+
+	if limit <= 0 {
+		limit = defaultTopSitesLimit
+	}
+	limit = min(limit, maxTopSites)
+
+	ctx := context.Background()
+	sites, err := s.cache.GetTopSites(ctx, userID)
+	if err != nil {
+		log.Error("failed to get cached top sites", "error", err, "user_id", userID)
+	}
+
+	if sites == nil {
+		sites, err = s.rankTopSites(userID)
+		if err != nil {
+			return nil, err
+		}
+		ttl := time.Duration(s.cfg.TopSitesCacheTTLSec) * time.Second
+		if ttl > 0 {
+			_ = s.cache.StoreTopSites(ctx, userID, sites, ttl)
+		}
+	}
+
+	if len(sites) > limit {
+		sites = sites[:limit]
+	}
+	return sites, nil
+}
+
+// rankTopSites вычисляет рейтинг всех посещённых закладок пользователя
+func (s *service) rankTopSites(userID uint) ([]model.TopSiteResponse, error) {
+	bookmarks, err := s.repo.GetVisitedBookmarks(userID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	halfLife := s.frecencyHalfLife()
+	sites := make([]model.TopSiteResponse, len(bookmarks))
+	for i := range bookmarks {
+		sites[i] = model.TopSiteResponse{
+			ID:            bookmarks[i].ID,
+			Title:         bookmarks[i].Title,
+			URL:           bookmarks[i].URL,
+			Favicon:       bookmarks[i].Favicon,
+			GoURL:         model.ClickPath(bookmarks[i].ID),
+			VisitCount:    bookmarks[i].VisitCount,
+			LastVisitedAt: bookmarks[i].LastVisitedAt,
+			Score:         bookmarks[i].FrecencyAt(now, halfLife),
+		}
+	}
+
+	sort.SliceStable(sites, func(i, j int) bool {
+		return sites[i].Score > sites[j].Score
+	})
+	if len(sites) > maxTopSites {
+		sites = sites[:maxTopSites]
+	}
+
+	return sites, nil
+}
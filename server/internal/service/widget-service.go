@@ -0,0 +1,192 @@
+package service
+
+import (
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+)
+
+// applyWidgetSettings проверяет и сохраняет в виджет настройки оформления и список разрешённых источников
+func applyWidgetSettings(widget *model.Widget, req *model.WidgetRequest) error {
+	if len(req.AllowedOrigins) == 0 || len(req.AllowedOrigins) > model.MaxWidgetOrigins {
+		return errors.New(errors.CodeInvalidRequest, "Specify from 1 to 20 allowed origins")
+	}
+	origins := make([]string, 0, len(req.AllowedOrigins))
+	seen := make(map[string]bool, len(req.AllowedOrigins))
+	for _, origin := range req.AllowedOrigins {
+		normalized, ok := model.NormalizeOrigin(origin)
+		if !ok {
+			return errors.New(errors.CodeInvalidRequest, "Invalid origin: "+origin)
+		}
+		if seen[normalized] {
+			continue
+		}
+		seen[normalized] = true
+		origins = append(origins, normalized)
+	}
+
+	widget.AllowedOrigins = origins
+	widget.Layout = req.Layout
+	if widget.Layout == "" {
+		widget.Layout = model.WidgetList
+	}
+	widget.Limit = req.Limit
+	if widget.Limit == 0 {
+		widget.Limit = model.DefaultWidgetLimit
+	}
+	widget.ShowFavicons = req.ShowFavicons == nil || *req.ShowFavicons
+	return nil
+}
+
+// getCollectionWidget возвращает виджет коллекции, если пользователь владеет коллекцией
+func (s *service) getCollectionWidget(userID, collectionID, widgetID uint) (*model.Widget, error) {
+	if err := s.requireCollectionOwner(userID, collectionID); err != nil {
+		return nil, err
+	}
+	widget, err := s.repo.GetWidgetByID(widgetID)
+	if err != nil {
+		return nil, err
+	}
+	if widget.CollectionID != collectionID {
+		return nil, errors.New(errors.CodeNotFound, "Widget not found")
+	}
+	return widget, nil
+}
+
+func (s *service) GetWidgets(userID, collectionID uint) ([]model.WidgetResponse, error) {
+	if err := s.requireCollectionOwner(userID, collectionID); err != nil {
+		return nil, err
+	}
+
+	widgets, err := s.repo.GetCollectionWidgets(collectionID)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]model.WidgetResponse, 0, len(widgets))
+	for i := range widgets {
+		responses = append(responses, model.NewWidgetResponse(&widgets[i], s.cfg.PublicURL))
+	}
+	return responses, nil
+}
+
+// CreateWidget создаёт виджет для встраивания коллекции на сторонние сайты. Встроить можно только публичную коллекцию
+func (s *service) CreateWidget(userID, collectionID uint, req *model.WidgetRequest) (*model.WidgetResponse, error) {
+	const op = "service.CreateWidget"
+	log := s.log.With("op", op)
+
+	if err := s.requireCollectionOwner(userID, collectionID); err != nil {
+		return nil, err
+	}
+	collection, err := s.repo.GetCollectionByID(collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if !collection.IsPublic {
+		return nil, errors.New(errors.CodeInvalidRequest, "Only public collections can be embedded")
+	}
+
+	now := time.Now()
+	widget := &model.Widget{
+		CollectionID: collectionID,
+		UserID:       userID,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := applyWidgetSettings(widget, req); err != nil {
+		return nil, err
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		log.Error("failed to generate widget token", "error", err)
+		return nil, errors.New(errors.CodeInternalError, "Failed to create widget")
+	}
+	widget.Token = token
+
+	if err := s.repo.CreateWidget(widget); err != nil {
+		return nil, err
+	}
+
+	log.Debug("widget created", "widget_id", widget.ID, "collection_id", collectionID)
+	response := model.NewWidgetResponse(widget, s.cfg.PublicURL)
+	return &response, nil
+}
+
+// UpdateWidget меняет оформление виджета и список сайтов, где его можно встроить; адрес виджета не меняется
+func (s *service) UpdateWidget(userID, collectionID, widgetID uint, req *model.WidgetRequest) (*model.WidgetResponse, error) {
+	widget, err := s.getCollectionWidget(userID, collectionID, widgetID)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyWidgetSettings(widget, req); err != nil {
+		return nil, err
+	}
+	widget.UpdatedAt = time.Now()
+
+	if err := s.repo.SaveWidget(widget); err != nil {
+		return nil, err
+	}
+
+	response := model.NewWidgetResponse(widget, s.cfg.PublicURL)
+	return &response, nil
+}
+
+func (s *service) DeleteWidget(userID, collectionID, widgetID uint) error {
+	widget, err := s.getCollectionWidget(userID, collectionID, widgetID)
+	if err != nil {
+		return err
+	}
+	return s.repo.DeleteWidget(widget.ID)
+}
+
+// GetEmbed возвращает содержимое виджета по его токену. Виджет перестаёт работать,
+// если коллекция стала закрытой; параметры запроса могут уменьшить лимит, но не увеличить его
+func (s *service) GetEmbed(token string, query *model.WidgetQuery) (*model.EmbedResponse, error) {
+	const op = "service.GetEmbed"
+	log := s.log.With("op", op)
+
+	widget, err := s.repo.GetWidgetByToken(token)
+	if err != nil {
+		return nil, err
+	}
+	collection, err := s.repo.GetCollectionByID(widget.CollectionID)
+	if err != nil || !collection.IsPublic {
+		return nil, errors.New(errors.CodeNotFound, "Widget not found")
+	}
+
+	bookmarks, err := s.repo.GetCollectionBookmarks(collection.ID)
+	if err != nil {
+		log.Error("failed to get collection bookmarks", "error", err, "collection_id", collection.ID)
+		return nil, err
+	}
+	limit := widget.Limit
+	if query.Limit > 0 {
+		limit = min(limit, query.Limit)
+	}
+	if len(bookmarks) > limit {
+		bookmarks = bookmarks[:limit]
+	}
+
+	embed := &model.EmbedResponse{
+		Name:           collection.Name,
+		Description:    collection.Description,
+		Layout:         widget.Layout,
+		ShowFavicons:   widget.ShowFavicons,
+		Bookmarks:      model.NewSharedBookmarks(bookmarks),
+		AllowedOrigins: widget.AllowedOrigins,
+	}
+	if query.Layout != "" {
+		embed.Layout = query.Layout
+	}
+	if query.Favicons != nil {
+		embed.ShowFavicons = *query.Favicons
+	}
+	if !embed.ShowFavicons {
+		for i := range embed.Bookmarks {
+			embed.Bookmarks[i].Favicon = ""
+		}
+	}
+
+	return embed, nil
+}
@@ -0,0 +1,387 @@
+package service
+
+import (
+	"time"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"github.com/aerscs/theca-public/internal/utils/errors"
+)
+
+// workspaceRole возвращает роль пользователя в рабочем пространстве; для не-участника — CodeForbidden
+func (s *service) workspaceRole(userID, workspaceID uint) (string, error) {
+	membership, err := s.repo.GetWorkspaceMembership(workspaceID, userID)
+	if err != nil {
+		if errors.IsErrorCode(err, errors.CodeNotFound) {
+			return "", errors.New(errors.CodeForbidden, "Not a member of the workspace")
+		}
+		return "", err
+	}
+	return membership.Role, nil
+}
+
+// requireWorkspaceAdmin проверяет, что пользователь администрирует пространство
+func (s *service) requireWorkspaceAdmin(userID, workspaceID uint) error {
+	role, err := s.workspaceRole(userID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if role != model.WorkspaceAdmin {
+		return errors.New(errors.CodeForbidden, "Only workspace admins can do this")
+	}
+	return nil
+}
+
+// resolveWorkspace проверяет участие пользователя в пространстве; 0 и nil означают личные закладки
+func (s *service) resolveWorkspace(userID uint, workspaceID *uint) (*uint, error) {
+	if workspaceID == nil || *workspaceID == 0 {
+		return nil, nil
+	}
+	if _, err := s.workspaceRole(userID, *workspaceID); err != nil {
+		return nil, err
+	}
+	return workspaceID, nil
+}
+
+// scopeBookmarks возвращает личные закладки или закладки пространства, проверяя участие в нём
+func (s *service) scopeBookmarks(userID, workspaceID uint) ([]model.Bookmark, error) {
+	if workspaceID == 0 {
+		return s.repo.GetBookmarks(userID)
+	}
+	if _, err := s.workspaceRole(userID, workspaceID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetWorkspaceBookmarks(workspaceID)
+}
+
+// checkWorkspaceBookmarkLimit проверяет, что в пространство поместится ещё adding закладок по его тарифу
+func (s *service) checkWorkspaceBookmarkLimit(workspaceID uint, adding int) error {
+	workspace, err := s.repo.GetWorkspaceByID(workspaceID)
+	if err != nil {
+		return err
+	}
+	count, err := s.repo.CountWorkspaceBookmarks(workspaceID)
+	if err != nil {
+		return err
+	}
+	if count+int64(adding) > workspace.Limits().MaxBookmarks {
+		return errors.New(errors.CodeInvalidRequest, "Workspace plan bookmark limit reached")
+	}
+	return nil
+}
+
+// workspaceResponse формирует ответ с ролью пользователя и использованием лимитов тарифа
+func (s *service) workspaceResponse(workspace *model.Workspace, role string) (*model.WorkspaceResponse, error) {
+	members, err := s.repo.CountWorkspaceMembers(workspace.ID, false)
+	if err != nil {
+		return nil, err
+	}
+	bookmarks, err := s.repo.CountWorkspaceBookmarks(workspace.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.WorkspaceResponse{
+		ID:        workspace.ID,
+		Name:      workspace.Name,
+		Plan:      workspace.Plan,
+		Role:      role,
+		Limits:    workspace.Limits(),
+		Members:   members,
+		Bookmarks: bookmarks,
+		CreatedAt: workspace.CreatedAt,
+		UpdatedAt: workspace.UpdatedAt,
+	}, nil
+}
+
+// GetWorkspaces возвращает рабочие пространства, в которых состоит пользователь
+func (s *service) GetWorkspaces(userID uint) ([]model.WorkspaceResponse, error) {
+	const op = "service.GetWorkspaces"
+	log := s.log.With("op", op)
+
+	workspaces, memberships, err := s.repo.GetUserWorkspaces(userID)
+	if err != nil {
+		log.Error("failed to get workspaces", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	roles := make(map[uint]string, len(memberships))
+	for _, membership := range memberships {
+		roles[membership.WorkspaceID] = membership.Role
+	}
+
+	responses := make([]model.WorkspaceResponse, 0, len(workspaces))
+	for i := range workspaces {
+		response, err := s.workspaceResponse(&workspaces[i], roles[workspaces[i].ID])
+		if err != nil {
+			log.Error("failed to get workspace usage", "error", err, "workspace_id", workspaces[i].ID)
+			return nil, err
+		}
+		responses = append(responses, *response)
+	}
+	return responses, nil
+}
+
+// CreateWorkspace создаёт пространство на бесплатном тарифе; создатель становится его администратором
+func (s *service) CreateWorkspace(userID uint, req *model.WorkspaceRequest) (*model.WorkspaceResponse, error) {
+	const op = "service.CreateWorkspace"
+	log := s.log.With("op", op)
+
+	now := time.Now()
+	workspace := &model.Workspace{
+		Name:      req.Name,
+		Plan:      model.WorkspacePlanFree,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.repo.CreateWorkspace(workspace, userID); err != nil {
+		log.Error("failed to create workspace", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	return s.workspaceResponse(workspace, model.WorkspaceAdmin)
+}
+
+func (s *service) GetWorkspace(userID, workspaceID uint) (*model.WorkspaceResponse, error) {
+	role, err := s.workspaceRole(userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	workspace, err := s.repo.GetWorkspaceByID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.workspaceResponse(workspace, role)
+}
+
+func (s *service) UpdateWorkspace(userID, workspaceID uint, req *model.WorkspaceRequest) (*model.WorkspaceResponse, error) {
+	const op = "service.UpdateWorkspace"
+	log := s.log.With("op", op)
+
+	if err := s.requireWorkspaceAdmin(userID, workspaceID); err != nil {
+		return nil, err
+	}
+
+	workspace, err := s.repo.GetWorkspaceByID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	workspace.Name = req.Name
+	workspace.UpdatedAt = time.Now()
+	if err := s.repo.SaveWorkspace(workspace); err != nil {
+		log.Error("failed to update workspace", "error", err, "workspace_id", workspaceID)
+		return nil, err
+	}
+
+	return s.workspaceResponse(workspace, model.WorkspaceAdmin)
+}
+
+// DeleteWorkspace удаляет пространство; его закладки становятся личными закладками добавивших их пользователей
+func (s *service) DeleteWorkspace(userID, workspaceID uint) error {
+	const op = "service.DeleteWorkspace"
+	log := s.log.With("op", op)
+
+	if err := s.requireWorkspaceAdmin(userID, workspaceID); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteWorkspace(workspaceID); err != nil {
+		log.Error("failed to delete workspace", "error", err, "workspace_id", workspaceID)
+		return err
+	}
+
+	log.Debug("workspace deleted", "workspace_id", workspaceID, "user_id", userID)
+	return nil
+}
+
+// SetWorkspacePlan меняет тариф пространства; доступно только администраторам сервиса
+func (s *service) SetWorkspacePlan(workspaceID uint, req *model.WorkspacePlanRequest) (*model.Workspace, error) {
+	const op = "service.SetWorkspacePlan"
+	log := s.log.With("op", op)
+
+	if !model.IsValidWorkspacePlan(req.Plan) {
+		return nil, errors.New(errors.CodeInvalidRequest, "Unknown workspace plan")
+	}
+
+	workspace, err := s.repo.GetWorkspaceByID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	workspace.Plan = req.Plan
+	workspace.UpdatedAt = time.Now()
+	if err := s.repo.SaveWorkspace(workspace); err != nil {
+		log.Error("failed to update workspace plan", "error", err, "workspace_id", workspaceID)
+		return nil, err
+	}
+
+	log.Info("workspace plan changed", "workspace_id", workspaceID, "plan", req.Plan)
+	return workspace, nil
+}
+
+// GetWorkspaceMembers возвращает участников пространства. Email участников не раскрывается
+func (s *service) GetWorkspaceMembers(userID, workspaceID uint) ([]model.WorkspaceMemberResponse, error) {
+	const op = "service.GetWorkspaceMembers"
+	log := s.log.With("op", op)
+
+	if _, err := s.workspaceRole(userID, workspaceID); err != nil {
+		return nil, err
+	}
+
+	members, err := s.repo.GetWorkspaceMembers(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(members))
+	for _, member := range members {
+		ids = append(ids, member.UserID)
+	}
+	users, err := s.repo.GetUsersByIDs(ids)
+	if err != nil {
+		log.Error("failed to get member users", "error", err, "workspace_id", workspaceID)
+		return nil, err
+	}
+	usernames := make(map[uint]string, len(users))
+	for i := range users {
+		usernames[users[i].ID] = users[i].Username
+	}
+
+	responses := make([]model.WorkspaceMemberResponse, 0, len(members))
+	for _, member := range members {
+		responses = append(responses, model.WorkspaceMemberResponse{
+			UserID:   member.UserID,
+			Username: usernames[member.UserID],
+			Role:     member.Role,
+			JoinedAt: member.CreatedAt,
+		})
+	}
+	return responses, nil
+}
+
+// AddWorkspaceMember добавляет пользователя в пространство по username; состав участников ведут администраторы
+func (s *service) AddWorkspaceMember(userID, workspaceID uint, req *model.WorkspaceMemberRequest) (*model.WorkspaceMemberResponse, error) {
+	const op = "service.AddWorkspaceMember"
+	log := s.log.With("op", op)
+
+	if err := s.requireWorkspaceAdmin(userID, workspaceID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetUserByUsername(req.Username)
+	if err != nil || !user.IsVerified {
+		return nil, errors.New(errors.CodeNotFound, "User not found")
+	}
+	if _, err := s.repo.GetWorkspaceMembership(workspaceID, user.ID); err == nil {
+		return nil, errors.New(errors.CodeDataConflict, "User is already a member of the workspace")
+	}
+
+	workspace, err := s.repo.GetWorkspaceByID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	count, err := s.repo.CountWorkspaceMembers(workspaceID, false)
+	if err != nil {
+		return nil, err
+	}
+	if count >= int64(workspace.Limits().MaxMembers) {
+		return nil, errors.New(errors.CodeInvalidRequest, "Workspace plan member limit reached")
+	}
+
+	membership := &model.WorkspaceMembership{
+		WorkspaceID: workspaceID,
+		UserID:      user.ID,
+		Role:        req.Role,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.repo.SaveWorkspaceMember(membership); err != nil {
+		log.Error("failed to add workspace member", "error", err, "workspace_id", workspaceID, "member_id", user.ID)
+		return nil, err
+	}
+
+	log.Debug("workspace member added", "workspace_id", workspaceID, "member_id", user.ID, "user_id", userID)
+	return &model.WorkspaceMemberResponse{
+		UserID:   user.ID,
+		Username: user.Username,
+		Role:     membership.Role,
+		JoinedAt: membership.CreatedAt,
+	}, nil
+}
+
+// UpdateWorkspaceMember меняет роль участника; в пространстве всегда остаётся хотя бы один администратор
+func (s *service) UpdateWorkspaceMember(userID, workspaceID, memberID uint, req *model.WorkspaceRoleRequest) (*model.WorkspaceMemberResponse, error) {
+	if err := s.requireWorkspaceAdmin(userID, workspaceID); err != nil {
+		return nil, err
+	}
+
+	membership, err := s.repo.GetWorkspaceMembership(workspaceID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if membership.Role == model.WorkspaceAdmin && req.Role != model.WorkspaceAdmin {
+		if err := s.ensureAnotherWorkspaceAdmin(workspaceID); err != nil {
+			return nil, err
+		}
+	}
+
+	membership.Role = req.Role
+	if err := s.repo.SaveWorkspaceMember(membership); err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetUserByID(memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.WorkspaceMemberResponse{
+		UserID:   membership.UserID,
+		Username: user.Username,
+		Role:     membership.Role,
+		JoinedAt: membership.CreatedAt,
+	}, nil
+}
+
+// RemoveWorkspaceMember исключает участника. Администратор может исключить любого, остальные — только выйти сами.
+// Закладки участника остаются в пространстве
+func (s *service) RemoveWorkspaceMember(userID, workspaceID, memberID uint) error {
+	const op = "service.RemoveWorkspaceMember"
+	log := s.log.With("op", op)
+
+	if userID != memberID {
+		if err := s.requireWorkspaceAdmin(userID, workspaceID); err != nil {
+			return err
+		}
+	}
+
+	membership, err := s.repo.GetWorkspaceMembership(workspaceID, memberID)
+	if err != nil {
+		return err
+	}
+	if membership.Role == model.WorkspaceAdmin {
+		if err := s.ensureAnotherWorkspaceAdmin(workspaceID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.DeleteWorkspaceMember(workspaceID, memberID); err != nil {
+		return err
+	}
+
+	log.Debug("workspace member removed", "workspace_id", workspaceID, "member_id", memberID, "user_id", userID)
+	return nil
+}
+
+// ensureAnotherWorkspaceAdmin не даёт оставить пространство без администратора
+func (s *service) ensureAnotherWorkspaceAdmin(workspaceID uint) error {
+	admins, err := s.repo.CountWorkspaceMembers(workspaceID, true)
+	if err != nil {
+		return err
+	}
+	if admins <= 1 {
+		return errors.New(errors.CodeInvalidRequest, "Workspace must keep at least one admin; delete it instead")
+	}
+	return nil
+}
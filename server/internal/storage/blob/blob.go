@@ -0,0 +1,41 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aerscs/theca-public/internal/config"
+)
+
+// ErrNotFound возвращается, если объекта с таким ключом нет
+var ErrNotFound = errors.New("blob not found")
+
+// Storage defines the interface for storing binary objects such as page snapshots
+type Storage interface {
+	// Put saves the object under the key, replacing an existing one
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	// Get returns the object data and its content type
+	Get(ctx context.Context, key string) ([]byte, string, error)
+	// Delete removes the object; deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// New creates the storage configured with BLOB_STORAGE ("local" or "s3")
+func New(cfg *config.Config) (Storage, error) {
+	switch cfg.BlobStorage {
+	case "", "local":
+		return NewLocalStorage(cfg.BlobLocalDir)
+	case "s3":
+		return NewS3Storage(S3Options{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			PathStyle: cfg.S3PathStyle,
+		})
+	default:
+		return nil, fmt.Errorf("unknown blob storage: %s", cfg.BlobStorage)
+	}
+}
@@ -123,5 +123,12 @@ func (g *GormDatabase) CreateIndexes() error {
 	if err := g.Conn.Exec("CREATE INDEX IF NOT EXISTS idx_users_email_username ON users (email, username);").Error; err != nil {
 		return fmt.Errorf("failed to create composite email_username index: %w", err)
 	}
+
+	// Полнотекстовый индекс по тексту статей есть только в PostgreSQL
+	if g.Conn.Dialector.Name() == "postgres" {
+		if err := g.Conn.Exec("CREATE INDEX IF NOT EXISTS idx_bookmark_contents_text_fts ON bookmark_contents USING GIN (to_tsvector('simple', COALESCE(text, '')));").Error; err != nil {
+			return fmt.Errorf("failed to create bookmark contents full-text index: %w", err)
+		}
+	}
 	return nil
 }
@@ -0,0 +1,150 @@
+// Package feed формирует ленты RSS 2.0 и Atom (RFC 4287) для подписки из RSS-читалок
+package feed
+
+import (
+	"bytes"
+	"encoding/xml"
+	"time"
+)
+
+// Feed лента закладок. ID — постоянный идентификатор ленты, по нему читалки отличают ленты друг от друга;
+// Self — адрес, по которому лента запрошена
+type Feed struct {
+	Updated     time.Time
+	ID          string
+	Self        string
+	Title       string
+	Description string
+	Link        string
+	Items       []Item
+}
+
+// Item запись ленты; Saved — время сохранения закладки
+type Item struct {
+	Saved       time.Time
+	ID          string
+	Title       string
+	URL         string
+	Description string
+}
+
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Atom    string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Self          atomLink  `xml:"atom:link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link,omitempty"`
+	Description string  `xml:"description,omitempty"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Author  atomAuthor  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title     string     `xml:"title"`
+	ID        string     `xml:"id"`
+	Updated   string     `xml:"updated"`
+	Published string     `xml:"published"`
+	Links     []atomLink `xml:"link"`
+	Summary   string     `xml:"summary,omitempty"`
+}
+
+// RSS формирует ленту RSS 2.0
+func RSS(feed *Feed) []byte {
+	channel := rssChannel{
+		Title:         feed.Title,
+		Link:          feed.Link,
+		Self:          atomLink{Href: feed.Self, Rel: "self", Type: "application/rss+xml"},
+		Description:   feed.Description,
+		LastBuildDate: feed.Updated.UTC().Format(time.RFC1123Z),
+		Items:         make([]rssItem, 0, len(feed.Items)),
+	}
+	for _, item := range feed.Items {
+		channel.Items = append(channel.Items, rssItem{
+			Title:       item.Title,
+			Link:        item.URL,
+			Description: item.Description,
+			GUID:        rssGUID{Value: item.ID},
+			PubDate:     item.Saved.UTC().Format(time.RFC1123Z),
+		})
+	}
+
+	return encode(rss{Version: "2.0", Atom: "http://www.w3.org/2005/Atom", Channel: channel})
+}
+
+// Atom формирует ленту Atom
+func Atom(feed *Feed) []byte {
+	out := atomFeed{
+		Title:   feed.Title,
+		ID:      feed.ID,
+		Updated: feed.Updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: feed.Self, Rel: "self", Type: "application/atom+xml"},
+			{Href: feed.Link, Rel: "alternate", Type: "text/html"},
+		},
+		Author:  atomAuthor{Name: "Theca"},
+		Entries: make([]atomEntry, 0, len(feed.Items)),
+	}
+	for _, item := range feed.Items {
+		entry := atomEntry{
+			Title:     item.Title,
+			ID:        item.ID,
+			Updated:   item.Saved.UTC().Format(time.RFC3339),
+			Published: item.Saved.UTC().Format(time.RFC3339),
+			Summary:   item.Description,
+		}
+		if item.URL != "" {
+			entry.Links = []atomLink{{Href: item.URL, Rel: "alternate"}}
+		}
+		out.Entries = append(out.Entries, entry)
+	}
+
+	return encode(out)
+}
+
+// encode сериализует ленту с XML-заголовком; структуры ленты всегда сериализуемы
+func encode(v any) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	_ = enc.Encode(v)
+	return buf.Bytes()
+}
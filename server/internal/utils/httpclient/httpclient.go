@@ -0,0 +1,382 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// DefaultUserAgent используется, если User-Agent не задан в конфигурации
+const DefaultUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+
+// maxTrackedHosts ограничивает количество хостов, состояние которых хранится в памяти
+const maxTrackedHosts = 10000
+
+// ErrPrivateNetwork возвращается при попытке соединиться с внутренним адресом
+var ErrPrivateNetwork = errors.New("connections to private networks are not allowed")
+
+// ErrCircuitOpen возвращается, когда запросы к хосту временно заблокированы после серии ошибок
+var ErrCircuitOpen = errors.New("circuit breaker is open for host")
+
+// Options настройки исходящего HTTP-клиента
+type Options struct {
+	UserAgent string
+	// HostRateLimit максимальное число запросов к одному хосту в секунду, 0 - без ограничения
+	HostRateLimit float64
+	// BreakerCooldown время, на которое блокируются запросы к хосту после срабатывания предохранителя
+	BreakerCooldown time.Duration
+	// MaxConnsPerHost максимальное число одновременных запросов к одному хосту
+	MaxConnsPerHost int
+	// HostBurst допустимый всплеск запросов сверх HostRateLimit
+	HostBurst int
+	// BreakerThreshold число ошибок подряд, после которого срабатывает предохранитель
+	BreakerThreshold int
+	// AllowPrivateNetworks разрешает запросы к loopback и внутренним адресам.
+	// По умолчанию запрещено, чтобы пользовательские URL не открывали доступ к внутренней сети
+	AllowPrivateNetworks bool
+}
+
+// DefaultOptions возвращает настройки по умолчанию
+func DefaultOptions() Options {
+	return Options{
+		UserAgent:        DefaultUserAgent,
+		HostRateLimit:    5,
+		BreakerCooldown:  time.Minute,
+		MaxConnsPerHost:  4,
+		HostBurst:        5,
+		BreakerThreshold: 5,
+	}
+}
+
+// Client общий исходящий HTTP-клиент: переиспользует соединения,
+// ограничивает конкурентность и частоту запросов к каждому хосту
+// и временно отключает хосты, которые постоянно отвечают ошибками
+type Client struct {
+	transport http.RoundTripper
+	log       *slog.Logger
+	hosts     map[string]*hostState
+	metrics   *metrics
+	opts      Options
+	mu        sync.Mutex
+}
+
+// New создаёт клиент с общим пулом соединений
+func New(opts Options, log *slog.Logger) *Client {
+	defaults := DefaultOptions()
+	if opts.UserAgent == "" {
+		opts.UserAgent = defaults.UserAgent
+	}
+	if opts.MaxConnsPerHost <= 0 {
+		opts.MaxConnsPerHost = defaults.MaxConnsPerHost
+	}
+	if opts.HostBurst <= 0 {
+		opts.HostBurst = defaults.HostBurst
+	}
+	if opts.BreakerThreshold <= 0 {
+		opts.BreakerThreshold = defaults.BreakerThreshold
+	}
+	if opts.BreakerCooldown <= 0 {
+		opts.BreakerCooldown = defaults.BreakerCooldown
+	}
+	if log == nil {
+		log = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	if !opts.AllowPrivateNetworks {
+		dialer.Control = denyPrivateNetworks
+	}
+
+	transport := &http.Transport{
+		// Без прокси: через прокси dialer видит только его адрес, и проверка частных сетей не сработает
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          200,
+		MaxIdleConnsPerHost:   opts.MaxConnsPerHost,
+		MaxConnsPerHost:       opts.MaxConnsPerHost,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: 20 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+
+	return &Client{
+		transport: transport,
+		log:       log,
+		hosts:     make(map[string]*hostState),
+		metrics:   newMetrics(),
+		opts:      opts,
+	}
+}
+
+// HTTPClient возвращает http.Client поверх общего транспорта.
+// Сами http.Client дешёвые, поэтому каждый вызывающий может задать свой таймаут и политику редиректов
+func (c *Client) HTTPClient(timeout time.Duration, checkRedirect func(req *http.Request, via []*http.Request) error) *http.Client {
+	return &http.Client{
+		Transport:     c,
+		Timeout:       timeout,
+		CheckRedirect: checkRedirect,
+	}
+}
+
+// NoRedirects запрещает следовать редиректам
+func NoRedirects(req *http.Request, via []*http.Request) error {
+	return http.ErrUseLastResponse
+}
+
+// RoundTrip implements http.RoundTripper. Every hop of a redirect chain
+// goes through the per-host limits separately
+func (c *Client) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := strings.ToLower(req.URL.Hostname())
+	state := c.host(host)
+	ctx := req.Context()
+
+	if !state.allow(time.Now()) {
+		c.metrics.rejected(host)
+		return nil, ErrCircuitOpen
+	}
+
+	// Ограничиваем число одновременных запросов к хосту
+	select {
+	case state.sem <- struct{}{}:
+	case <-ctx.Done():
+		state.cancelProbe()
+		return nil, ctx.Err()
+	}
+
+	if err := state.wait(ctx, c.opts.HostRateLimit, c.opts.HostBurst); err != nil {
+		<-state.sem
+		state.cancelProbe()
+		return nil, err
+	}
+
+	// RoundTripper не должен изменять исходный запрос
+	if req.Header.Get("User-Agent") == "" {
+		req = req.Clone(ctx)
+		req.Header.Set("User-Agent", c.opts.UserAgent)
+	}
+
+	start := time.Now()
+	resp, err := c.transport.RoundTrip(req)
+	latency := time.Since(start)
+
+	failed := err != nil || resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+	// Отмена запроса вызывающей стороной не говорит о проблемах хоста
+	if err != nil && ctx.Err() != nil {
+		state.cancelProbe()
+	} else if state.record(!failed, c.opts.BreakerThreshold, c.opts.BreakerCooldown) {
+		c.log.Warn("circuit breaker opened", "host", host, "cooldown", c.opts.BreakerCooldown.String())
+	}
+	c.metrics.observe(host, !failed, latency)
+
+	if err != nil {
+		<-state.sem
+		return nil, err
+	}
+
+	// Слот освобождается, когда вызывающий дочитает и закроет тело ответа
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: func() { <-state.sem }}
+	return resp, nil
+}
+
+// Metrics возвращает снимок метрик клиента
+func (c *Client) Metrics() MetricsSnapshot {
+	snapshot := c.metrics.snapshot()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for host, state := range c.hosts {
+		if state.isOpen(now) {
+			snapshot.OpenCircuits = append(snapshot.OpenCircuits, host)
+		}
+	}
+
+	return snapshot
+}
+
+// host возвращает состояние хоста, создавая его при необходимости
+func (c *Client) host(host string) *hostState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if state, ok := c.hosts[host]; ok {
+		return state
+	}
+
+	if len(c.hosts) >= maxTrackedHosts {
+		c.evictIdleHosts()
+	}
+
+	state := newHostState(c.opts.MaxConnsPerHost, c.opts.HostBurst)
+	c.hosts[host] = state
+	return state
+}
+
+// evictIdleHosts удаляет хосты без активных запросов и с закрытым предохранителем
+func (c *Client) evictIdleHosts() {
+	now := time.Now()
+	for host, state := range c.hosts {
+		if len(state.sem) == 0 && !state.isOpen(now) {
+			delete(c.hosts, host)
+		}
+	}
+}
+
+// denyPrivateNetworks проверяет адрес уже после разрешения DNS,
+// поэтому домен, указывающий на внутренний IP, тоже отклоняется
+func denyPrivateNetworks(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	ip = ip.Unmap()
+
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsMulticast() || ip.IsUnspecified() || ip.IsInterfaceLocalMulticast() || sharedAddressSpace.Contains(ip) {
+		return ErrPrivateNetwork
+	}
+
+	return nil
+}
+
+// sharedAddressSpace диапазон CGNAT (RFC 6598), не входящий в IsPrivate
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// releaseOnClose освобождает слот хоста при закрытии тела ответа
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (r *releaseOnClose) Close() error {
+	err := r.ReadCloser.Close()
+	r.once.Do(r.release)
+	return err
+}
+
+// hostState лимиты и состояние предохранителя для одного хоста
+type hostState struct {
+	lastRefill time.Time
+	openUntil  time.Time
+	sem        chan struct{}
+	tokens     float64
+	failures   int
+	mu         sync.Mutex
+	probing    bool
+}
+
+func newHostState(maxConns, burst int) *hostState {
+	return &hostState{
+		sem:        make(chan struct{}, maxConns),
+		tokens:     float64(burst),
+		lastRefill: time.Now(),
+	}
+}
+
+// allow проверяет предохранитель. После истечения блокировки пропускается
+// один пробный запрос, по результату которого хост открывается или блокируется снова
+func (h *hostState) allow(now time.Time) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.openUntil.IsZero() {
+		return true
+	}
+	if now.Before(h.openUntil) || h.probing {
+		return false
+	}
+
+	h.probing = true
+	return true
+}
+
+// cancelProbe снимает отметку пробного запроса, если он не был выполнен
+func (h *hostState) cancelProbe() {
+	h.mu.Lock()
+	h.probing = false
+	h.mu.Unlock()
+}
+
+// record учитывает результат запроса и возвращает true, если предохранитель только что сработал
+func (h *hostState) record(success bool, threshold int, cooldown time.Duration) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	wasProbing := h.probing
+	h.probing = false
+
+	if success {
+		h.failures = 0
+		h.openUntil = time.Time{}
+		return false
+	}
+
+	h.failures++
+	if wasProbing || h.failures >= threshold {
+		h.openUntil = time.Now().Add(cooldown)
+		return true
+	}
+	return false
+}
+
+func (h *hostState) isOpen(now time.Time) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return !h.openUntil.IsZero() && now.Before(h.openUntil)
+}
+
+// wait ожидает свободный токен (token bucket) с учётом отмены контекста
+func (h *hostState) wait(ctx context.Context, rate float64, burst int) error {
+	if rate <= 0 {
+		return nil
+	}
+
+	h.mu.Lock()
+	now := time.Now()
+	h.tokens = min(float64(burst), h.tokens+now.Sub(h.lastRefill).Seconds()*rate)
+	h.lastRefill = now
+	// Токен резервируется сразу, даже если его ещё придётся подождать
+	h.tokens--
+	var delay time.Duration
+	if h.tokens < 0 {
+		delay = time.Duration(-h.tokens / rate * float64(time.Second))
+	}
+	h.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Возвращаем неиспользованный токен
+		h.mu.Lock()
+		h.tokens++
+		h.mu.Unlock()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,238 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBreakerOpensProbesAndCloses(t *testing.T) {
+	const threshold = 3
+	h := newHostState(1, 1)
+
+	for i := 1; i < threshold; i++ {
+		if h.record(false, threshold, time.Minute) {
+			t.Fatalf("breaker opened after %d failures, threshold is %d", i, threshold)
+		}
+	}
+	if !h.record(false, threshold, time.Minute) {
+		t.Fatal("breaker did not open at the threshold")
+	}
+
+	now := time.Now()
+	if h.allow(now) {
+		t.Fatal("request allowed while the breaker is open")
+	}
+	if !h.isOpen(now) {
+		t.Fatal("isOpen reports a closed breaker during cooldown")
+	}
+
+	afterCooldown := now.Add(2 * time.Minute)
+	if !h.allow(afterCooldown) {
+		t.Fatal("probe not allowed after cooldown")
+	}
+	if h.allow(afterCooldown) {
+		t.Fatal("second request allowed while the probe is in flight")
+	}
+
+	if h.record(true, threshold, time.Minute) {
+		t.Fatal("successful probe reported as opening the breaker")
+	}
+	if !h.allow(afterCooldown) || !h.allow(afterCooldown) {
+		t.Fatal("breaker did not close after a successful probe")
+	}
+}
+
+func TestBreakerFailedProbeReopens(t *testing.T) {
+	h := newHostState(1, 1)
+	h.record(false, 1, time.Minute)
+
+	afterCooldown := time.Now().Add(2 * time.Minute)
+	if !h.allow(afterCooldown) {
+		t.Fatal("probe not allowed after cooldown")
+	}
+	if !h.record(false, 1, time.Minute) {
+		t.Fatal("failed probe did not reopen the breaker")
+	}
+	if h.allow(time.Now()) {
+		t.Fatal("request allowed after a failed probe")
+	}
+}
+
+func TestBreakerCancelledProbeAllowsNextProbe(t *testing.T) {
+	h := newHostState(1, 1)
+	h.record(false, 1, time.Minute)
+
+	afterCooldown := time.Now().Add(2 * time.Minute)
+	if !h.allow(afterCooldown) {
+		t.Fatal("probe not allowed after cooldown")
+	}
+	h.cancelProbe()
+	if !h.allow(afterCooldown) {
+		t.Fatal("new probe not allowed after the previous one was cancelled")
+	}
+}
+
+func TestBreakerSingleProbeUnderConcurrency(t *testing.T) {
+	h := newHostState(1, 1)
+	h.record(false, 1, time.Minute)
+
+	const callers = 64
+	afterCooldown := time.Now().Add(2 * time.Minute)
+	var allowed atomic.Int32
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			if h.allow(afterCooldown) {
+				allowed.Add(1)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if got := allowed.Load(); got != 1 {
+		t.Fatalf("allowed %d probes, want 1", got)
+	}
+}
+
+func TestRoundTripOpensCircuit(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := New(Options{BreakerThreshold: 2, BreakerCooldown: time.Minute, AllowPrivateNetworks: true}, nil)
+	httpClient := client.HTTPClient(5*time.Second, nil)
+
+	for range 2 {
+		resp, err := httpClient.Get(server.URL)
+		if err != nil {
+			t.Fatalf("request before the breaker opened: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	_, err := httpClient.Get(server.URL)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got error %v, want ErrCircuitOpen", err)
+	}
+	if got := hits.Load(); got != 2 {
+		t.Fatalf("server got %d requests, want 2", got)
+	}
+	if open := client.Metrics().OpenCircuits; len(open) != 1 {
+		t.Fatalf("open circuits = %v, want one host", open)
+	}
+}
+
+func TestWaitTokenBucket(t *testing.T) {
+	h := newHostState(1, 2)
+	ctx := context.Background()
+
+	start := time.Now()
+	for range 2 {
+		if err := h.wait(ctx, 10, 2); err != nil {
+			t.Fatalf("wait within burst: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Fatalf("burst requests waited %v", elapsed)
+	}
+
+	start = time.Now()
+	if err := h.wait(ctx, 10, 2); err != nil {
+		t.Fatalf("wait over burst: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Fatalf("request over burst waited only %v", elapsed)
+	}
+}
+
+func TestWaitCancelReturnsToken(t *testing.T) {
+	h := newHostState(1, 1)
+	if err := h.wait(context.Background(), 1, 1); err != nil {
+		t.Fatalf("first wait: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := h.wait(ctx, 1, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want context.DeadlineExceeded", err)
+	}
+
+	h.mu.Lock()
+	tokens := h.tokens
+	h.mu.Unlock()
+	// Отменённый запрос не должен занимать токен: остаётся только долг первого запроса и пополнение
+	if tokens < -0.1 {
+		t.Fatalf("tokens = %v, cancelled wait kept its reservation", tokens)
+	}
+}
+
+func TestWaitWithoutRateLimit(t *testing.T) {
+	h := newHostState(1, 1)
+	for range 100 {
+		if err := h.wait(context.Background(), 0, 1); err != nil {
+			t.Fatalf("wait without limit: %v", err)
+		}
+	}
+}
+
+func TestDenyPrivateNetworks(t *testing.T) {
+	tests := []struct {
+		address string
+		denied  bool
+	}{
+		{"127.0.0.1:80", true},
+		{"127.10.0.1:80", true},
+		{"[::1]:443", true},
+		{"10.0.0.1:80", true},
+		{"172.16.5.4:80", true},
+		{"172.31.255.255:80", true},
+		{"192.168.1.1:80", true},
+		{"100.64.0.1:80", true},
+		{"100.127.255.254:80", true},
+		{"169.254.169.254:80", true},
+		{"0.0.0.0:80", true},
+		{"[::]:80", true},
+		{"[fc00::1]:80", true},
+		{"[fe80::1]:80", true},
+		{"[::ffff:127.0.0.1]:80", true},
+		{"[::ffff:10.0.0.1]:80", true},
+		{"[::ffff:100.64.0.1]:80", true},
+		{"224.0.0.1:80", true},
+		{"8.8.8.8:443", false},
+		{"172.32.0.1:80", false},
+		{"100.128.0.1:80", false},
+		{"[2606:4700::1111]:443", false},
+		{"[::ffff:8.8.8.8]:443", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			err := denyPrivateNetworks("tcp", tt.address, nil)
+			if denied := errors.Is(err, ErrPrivateNetwork); denied != tt.denied {
+				t.Fatalf("denied = %v (err %v), want %v", denied, err, tt.denied)
+			}
+		})
+	}
+}
+
+func TestDenyPrivateNetworksRejectsMalformedAddress(t *testing.T) {
+	for _, address := range []string{"127.0.0.1", "localhost:80"} {
+		if err := denyPrivateNetworks("tcp", address, nil); err == nil {
+			t.Fatalf("address %q accepted", address)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package httpclient
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxReportedHosts ограничивает количество хостов в снимке метрик
+const maxReportedHosts = 50
+
+// latencyBuckets верхние границы корзин гистограммы задержек
+var latencyBuckets = []time.Duration{
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// MetricsSnapshot метрики исходящих запросов
+type MetricsSnapshot struct {
+	// LatencyBuckets количество запросов с задержкой не больше ключа (в миллисекундах, "+Inf" - остальные)
+	LatencyBuckets map[string]int64 `json:"latency_buckets"`
+	OpenCircuits   []string         `json:"open_circuits"`
+	Hosts          []HostMetrics    `json:"hosts"`
+	Total          HostMetrics      `json:"total"`
+}
+
+// HostMetrics метрики запросов к одному хосту
+type HostMetrics struct {
+	Host          string  `json:"host,omitempty"`
+	Requests      int64   `json:"requests"`
+	Successes     int64   `json:"successes"`
+	Failures      int64   `json:"failures"`
+	Rejected      int64   `json:"rejected"`
+	AvgLatencyMS  float64 `json:"avg_latency_ms"`
+	SuccessRatio  float64 `json:"success_ratio"`
+	totalDuration time.Duration
+}
+
+func (m *HostMetrics) observe(success bool, latency time.Duration) {
+	m.Requests++
+	if success {
+		m.Successes++
+	} else {
+		m.Failures++
+	}
+	m.totalDuration += latency
+}
+
+// finalize вычисляет производные значения для снимка
+func (m HostMetrics) finalize() HostMetrics {
+	if m.Requests > 0 {
+		m.AvgLatencyMS = float64(m.totalDuration.Microseconds()) / float64(m.Requests) / 1000
+		m.SuccessRatio = float64(m.Successes) / float64(m.Requests)
+	}
+	return m
+}
+
+type metrics struct {
+	hosts   map[string]*HostMetrics
+	buckets []int64
+	total   HostMetrics
+	mu      sync.Mutex
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		hosts:   make(map[string]*HostMetrics),
+		buckets: make([]int64, len(latencyBuckets)+1),
+	}
+}
+
+func (m *metrics) hostMetrics(host string) *HostMetrics {
+	hm, ok := m.hosts[host]
+	if !ok {
+		if len(m.hosts) >= maxTrackedHosts {
+			// Сбрасываем статистику по хостам, чтобы не расти бесконечно; общие счётчики сохраняются
+			m.hosts = make(map[string]*HostMetrics)
+		}
+		hm = &HostMetrics{Host: host}
+		m.hosts[host] = hm
+	}
+	return hm
+}
+
+func (m *metrics) observe(host string, success bool, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.total.observe(success, latency)
+	m.hostMetrics(host).observe(success, latency)
+
+	bucket := len(latencyBuckets)
+	for i, bound := range latencyBuckets {
+		if latency <= bound {
+			bucket = i
+			break
+		}
+	}
+	m.buckets[bucket]++
+}
+
+func (m *metrics) rejected(host string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.total.Rejected++
+	m.hostMetrics(host).Rejected++
+}
+
+// snapshot возвращает копию метрик; хосты отсортированы по количеству запросов
+func (m *metrics) snapshot() MetricsSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := MetricsSnapshot{
+		LatencyBuckets: make(map[string]int64, len(m.buckets)),
+		Total:          m.total.finalize(),
+		Hosts:          make([]HostMetrics, 0, min(len(m.hosts), maxReportedHosts)),
+	}
+
+	for i, count := range m.buckets {
+		key := "+Inf"
+		if i < len(latencyBuckets) {
+			key = formatMillis(latencyBuckets[i])
+		}
+		snapshot.LatencyBuckets[key] = count
+	}
+
+	hosts := make([]HostMetrics, 0, len(m.hosts))
+	for _, hm := range m.hosts {
+		hosts = append(hosts, hm.finalize())
+	}
+	sort.Slice(hosts, func(i, j int) bool {
+		return hosts[i].Requests+hosts[i].Rejected > hosts[j].Requests+hosts[j].Rejected
+	})
+	if len(hosts) > maxReportedHosts {
+		hosts = hosts[:maxReportedHosts]
+	}
+	snapshot.Hosts = append(snapshot.Hosts, hosts...)
+
+	return snapshot
+}
+
+func formatMillis(d time.Duration) string {
+	return strconv.FormatInt(d.Milliseconds(), 10)
+}
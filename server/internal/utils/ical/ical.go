@@ -0,0 +1,89 @@
+// Package ical формирует календари iCalendar (RFC 5545) для подписки из календарных приложений
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// timeFormat формат даты и времени в UTC
+const timeFormat = "20060102T150405Z"
+
+// maxLineLength максимальная длина строки в октетах, длинные строки переносятся
+const maxLineLength = 75
+
+// Event событие календаря
+type Event struct {
+	Start       time.Time
+	UID         string
+	Summary     string
+	Description string
+	URL         string
+	Duration    time.Duration
+}
+
+// textEscaper экранирует спецсимволы значений типа TEXT
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// Calendar формирует календарь с событиями. name отображается как название подписки
+func Calendar(name string, events []Event, now time.Time) []byte {
+	var buf bytes.Buffer
+
+	writeLine(&buf, "BEGIN:VCALENDAR")
+	writeLine(&buf, "VERSION:2.0")
+	writeLine(&buf, "PRODID:-//Theca//Reminders//EN")
+	writeLine(&buf, "CALSCALE:GREGORIAN")
+	writeLine(&buf, "METHOD:PUBLISH")
+	writeLine(&buf, "X-WR-CALNAME:"+textEscaper.Replace(name))
+
+	stamp := now.UTC().Format(timeFormat)
+	for _, event := range events {
+		duration := event.Duration
+		if duration <= 0 {
+			duration = 15 * time.Minute
+		}
+
+		writeLine(&buf, "BEGIN:VEVENT")
+		writeLine(&buf, "UID:"+event.UID)
+		writeLine(&buf, "DTSTAMP:"+stamp)
+		writeLine(&buf, "DTSTART:"+event.Start.UTC().Format(timeFormat))
+		writeLine(&buf, "DTEND:"+event.Start.Add(duration).UTC().Format(timeFormat))
+		writeLine(&buf, "SUMMARY:"+textEscaper.Replace(event.Summary))
+		if event.Description != "" {
+			writeLine(&buf, "DESCRIPTION:"+textEscaper.Replace(event.Description))
+		}
+		if event.URL != "" {
+			writeLine(&buf, "URL:"+event.URL)
+		}
+		writeLine(&buf, "BEGIN:VALARM")
+		writeLine(&buf, "ACTION:DISPLAY")
+		writeLine(&buf, "DESCRIPTION:"+textEscaper.Replace(event.Summary))
+		writeLine(&buf, "TRIGGER:PT0M")
+		writeLine(&buf, "END:VALARM")
+		writeLine(&buf, "END:VEVENT")
+	}
+
+	writeLine(&buf, "END:VCALENDAR")
+	return buf.Bytes()
+}
+
+// writeLine записывает строку, перенося её по 75 октетов без разрыва UTF-8 символов
+func writeLine(buf *bytes.Buffer, line string) {
+	limit := maxLineLength
+	for len(line) > limit {
+		cut := limit
+		// Не разрываем многобайтовый символ
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		fmt.Fprintf(buf, "%s\r\n ", line[:cut])
+		line = line[cut:]
+		// Строка продолжения начинается с пробела, который входит в лимит
+		limit = maxLineLength - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
@@ -15,13 +15,67 @@ import (
 type Mailer interface {
 	SendVerificationEmail(email, code, username string) error
 	SendResetEmail(email, username, token string) error
+	SendReminderEmail(email, username string, bookmarks []Bookmark) error
+	SendDigestEmail(email string, digest *Digest) error
+	SendCollectionInviteEmail(email string, invite *CollectionInvite) error
+	SendCommentMentionEmail(email string, mention *CommentMention) error
+	SendInboxEmail(email string, item *InboxBookmark) error
 }
 
 // Mail структура для данных письма
 type Mail struct {
-	Email    string
-	Username string
-	Code     string
+	Email     string
+	Username  string
+	Code      string
+	Bookmarks []Bookmark
+}
+
+// Bookmark закладка в письме
+type Bookmark struct {
+	Title string
+	URL   string
+}
+
+// Digest данные еженедельной подборки закладок
+type Digest struct {
+	Username       string
+	Language       string
+	UnsubscribeURL string
+	New            []Bookmark
+	Unread         []Bookmark
+	Resurfaced     []Bookmark
+	UnreadTotal    int64
+}
+
+// CollectionInvite приглашение в общую коллекцию
+type CollectionInvite struct {
+	Inviter       string
+	Collection    string
+	Role          string
+	URL           string
+	ExpiresInDays int
+}
+
+// CommentMention упоминание пользователя в комментарии к закладке
+type CommentMention struct {
+	Author   string
+	Bookmark string
+	Comment  string
+	URL      string
+}
+
+// InboxBookmark закладка, которую пользователю отправил другой пользователь
+type InboxBookmark struct {
+	Sender string
+	Title  string
+	Note   string
+	URL    string
+}
+
+// digestSubjects темы письма с подборкой по языкам
+var digestSubjects = map[string]string{
+	"en": "Theca | Your weekly digest",
+	"ru": "Theca | Ваша подборка за неделю",
 }
 
 // mailer реализация интерфейса Mailer
@@ -39,7 +93,7 @@ func NewMailer(cfg *config.Config) Mailer {
 }
 
 // sendEmail общий метод для отправки почты с таймаутом 10 секунд
-func (m *mailer) sendEmail(to, subject, templatePath string, data Mail) error {
+func (m *mailer) sendEmail(to, subject, templatePath string, data any, headers map[string]string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -58,6 +112,7 @@ func (m *mailer) sendEmail(to, subject, templatePath string, data Mail) error {
 		To:      []string{to},
 		Html:    tpl.String(),
 		Subject: subject,
+		Headers: headers,
 	}
 
 	_, err = m.client.Emails.SendWithContext(ctx, params)
@@ -75,6 +130,7 @@ func (m *mailer) SendVerificationEmail(email, code, username string) error {
 		fmt.Sprintf("%s | Verification Code", code),
 		"templates/verifyMail.html",
 		Mail{Username: username, Code: code},
+		nil,
 	)
 }
 
@@ -85,5 +141,77 @@ func (m *mailer) SendResetEmail(email, username, token string) error {
 		"Theca | Reset Password",
 		"templates/resetEmail.html",
 		Mail{Username: username, Code: token},
+		nil,
+	)
+}
+
+// SendReminderEmail отправляет письмо с закладками, по которым наступило напоминание
+func (m *mailer) SendReminderEmail(email, username string, bookmarks []Bookmark) error {
+	subject := "Theca | Reminder"
+	if len(bookmarks) == 1 {
+		subject = fmt.Sprintf("Theca | Reminder: %s", bookmarks[0].Title)
+	}
+
+	return m.sendEmail(
+		email,
+		subject,
+		"templates/reminderMail.html",
+		Mail{Username: username, Bookmarks: bookmarks},
+		nil,
+	)
+}
+
+// SendDigestEmail отправляет еженедельную подборку на языке пользователя.
+// Заголовки List-Unsubscribe позволяют отписаться в один клик из почтового клиента
+func (m *mailer) SendDigestEmail(email string, digest *Digest) error {
+	subject, ok := digestSubjects[digest.Language]
+	templatePath := fmt.Sprintf("templates/digestMail.%s.html", digest.Language)
+	if !ok || digest.Language == "en" {
+		subject = digestSubjects["en"]
+		templatePath = "templates/digestMail.html"
+	}
+
+	return m.sendEmail(
+		email,
+		subject,
+		templatePath,
+		digest,
+		map[string]string{
+			"List-Unsubscribe":      "<" + digest.UnsubscribeURL + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	)
+}
+
+// SendCollectionInviteEmail отправляет приглашение в общую коллекцию
+func (m *mailer) SendCollectionInviteEmail(email string, invite *CollectionInvite) error {
+	return m.sendEmail(
+		email,
+		fmt.Sprintf("Theca | %s invited you to %s", invite.Inviter, invite.Collection),
+		"templates/collectionInviteMail.html",
+		invite,
+		nil,
+	)
+}
+
+// SendCommentMentionEmail уведомляет пользователя об упоминании в комментарии
+func (m *mailer) SendCommentMentionEmail(email string, mention *CommentMention) error {
+	return m.sendEmail(
+		email,
+		fmt.Sprintf("Theca | %s mentioned you in a comment", mention.Author),
+		"templates/commentMentionMail.html",
+		mention,
+		nil,
+	)
+}
+
+// SendInboxEmail уведомляет пользователя о закладке, отправленной ему во входящие
+func (m *mailer) SendInboxEmail(email string, item *InboxBookmark) error {
+	return m.sendEmail(
+		email,
+		fmt.Sprintf("Theca | %s sent you a bookmark", item.Sender),
+		"templates/inboxMail.html",
+		item,
+		nil,
 	)
 }
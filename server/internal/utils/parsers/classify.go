@@ -0,0 +1,255 @@
+package parsers
+
+import (
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/aerscs/theca-public/internal/model"
+)
+
+// articleMinWords минимальный объём основного текста, начиная с которого страница считается статьёй
+const articleMinWords = 400
+
+// ClassifyURL определяет вид содержимого только по адресу, без загрузки страницы
+func ClassifyURL(rawURL string) string {
+	return ClassifyPage(rawURL, nil)
+}
+
+// ClassifyPage определяет вид содержимого закладки. Признаки проверяются от самых надёжных
+// к эвристикам: Content-Type ответа, адреса известных сайтов, og:type и объём текста страницы
+func ClassifyPage(rawURL string, details *PageDetails) string {
+	if !strings.HasPrefix(rawURL, "http://") && !strings.HasPrefix(rawURL, "https://") {
+		rawURL = "https://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return model.KindOther
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(strings.ToLower(u.Path), "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	if details != nil {
+		if kind := classifyContentType(details.ContentType); kind != "" {
+			return kind
+		}
+	}
+	if kind := classifyExtension(u.Path); kind != "" {
+		return kind
+	}
+	if kind := classifyKnownHost(normalizeHost(u.Hostname()), segments); kind != "" {
+		return kind
+	}
+	if details != nil && details.Metadata != nil {
+		if kind := classifyOpenGraphType(details.Metadata.Type); kind != "" {
+			return kind
+		}
+	}
+	if kind := classifyPath(segments); kind != "" {
+		return kind
+	}
+	if details != nil && details.Article != nil && details.Article.WordCount >= articleMinWords {
+		return model.KindArticle
+	}
+
+	return model.KindOther
+}
+
+// classifyContentType распознаёт файлы, отданные сервером не как HTML-страница
+func classifyContentType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+
+	switch {
+	case mediaType == "application/pdf":
+		return model.KindPDF
+	case strings.HasPrefix(mediaType, "image/"):
+		return model.KindImage
+	case strings.HasPrefix(mediaType, "video/"):
+		return model.KindVideo
+	}
+	return ""
+}
+
+// classifyExtension распознаёт прямые ссылки на файлы по расширению
+func classifyExtension(urlPath string) string {
+	switch strings.ToLower(path.Ext(urlPath)) {
+	case ".pdf":
+		return model.KindPDF
+	case ".png", ".jpg", ".jpeg", ".gif", ".webp", ".avif", ".svg", ".bmp":
+		return model.KindImage
+	case ".mp4", ".webm", ".mov", ".m4v", ".mkv":
+		return model.KindVideo
+	}
+	return ""
+}
+
+// classifyOpenGraphType сопоставляет og:type с видом содержимого
+func classifyOpenGraphType(ogType string) string {
+	switch {
+	case ogType == "video" || strings.HasPrefix(ogType, "video."):
+		return model.KindVideo
+	case ogType == "article" || ogType == "blog":
+		return model.KindArticle
+	case ogType == "product" || strings.HasPrefix(ogType, "product.") || ogType == "og:product":
+		return model.KindShop
+	}
+	return ""
+}
+
+// githubReserved разделы GitHub, адреса которых похожи на репозитории, но ими не являются
+var githubReserved = map[string]bool{
+	"about": true, "apps": true, "collections": true, "customer-stories": true, "enterprise": true,
+	"explore": true, "features": true, "login": true, "marketplace": true, "notifications": true,
+	"orgs": true, "pricing": true, "search": true, "security": true, "settings": true,
+	"site": true, "sponsors": true, "topics": true, "trending": true,
+}
+
+// classifyKnownHost распознаёт страницы популярных сайтов по хосту и пути
+func classifyKnownHost(host string, segments []string) string {
+	first := ""
+	if len(segments) > 0 {
+		first = segments[0]
+	}
+	second := ""
+	if len(segments) > 1 {
+		second = segments[1]
+	}
+
+	switch {
+	// Видео
+	case host == "youtu.be" && first != "",
+		matchHost(host, "youtube.com") && (first == "watch" || first == "shorts" || first == "live" || first == "embed" || first == "playlist"),
+		matchHost(host, "vimeo.com") && isDigits(first),
+		matchHost(host, "twitch.tv") && (first == "videos" || second == "clip"),
+		host == "clips.twitch.tv",
+		matchHost(host, "dailymotion.com") && first == "video",
+		host == "dai.ly",
+		matchHost(host, "rutube.ru") && first == "video",
+		matchHost(host, "vkvideo.ru"),
+		matchHost(host, "vk.com") && strings.HasPrefix(first, "video"),
+		matchHost(host, "tiktok.com") && second == "video",
+		matchHost(host, "loom.com") && first == "share",
+		matchHost(host, "ted.com") && first == "talks":
+		return model.KindVideo
+
+	// Репозитории
+	case host == "github.com" && len(segments) >= 2 && !githubReserved[first],
+		host == "gist.github.com" && first != "",
+		host == "gitlab.com" && len(segments) >= 2 && first != "explore" && first != "users" && first != "help",
+		host == "bitbucket.org" && len(segments) >= 2,
+		host == "codeberg.org" && len(segments) >= 2,
+		host == "git.sr.ht" && len(segments) >= 2,
+		matchHost(host, "sourceforge.net") && first == "projects":
+		return model.KindRepository
+
+	// Документация
+	case host == "pkg.go.dev", host == "docs.rs", host == "devdocs.io",
+		host == "developer.mozilla.org",
+		host == "learn.microsoft.com",
+		host == "developer.apple.com" && first == "documentation",
+		matchHost(host, "readthedocs.io"), matchHost(host, "readthedocs.org"),
+		matchHost(host, "cppreference.com"),
+		strings.HasPrefix(host, "docs.") || strings.HasPrefix(host, "doc."):
+		return model.KindDocumentation
+
+	// Социальные сети
+	case matchHost(host, "twitter.com"), matchHost(host, "x.com"),
+		matchHost(host, "facebook.com"), host == "fb.com",
+		matchHost(host, "instagram.com"),
+		matchHost(host, "threads.net"), matchHost(host, "threads.com"),
+		host == "bsky.app",
+		matchHost(host, "linkedin.com") && (first == "posts" || first == "feed"),
+		matchHost(host, "reddit.com"),
+		host == "news.ycombinator.com" && first == "item",
+		host == "t.me",
+		matchHost(host, "vk.com"), host == "ok.ru",
+		matchHost(host, "pinterest.com") && first == "pin":
+		return model.KindSocial
+
+	// Магазины
+	case isSite(host, "amazon") && (first == "dp" || second == "dp" || first == "gp" && second == "product"),
+		isSite(host, "ebay") && first == "itm",
+		isSite(host, "aliexpress") && first == "item",
+		matchHost(host, "etsy.com") && first == "listing",
+		matchHost(host, "ozon.ru") && first == "product",
+		matchHost(host, "wildberries.ru") && first == "catalog",
+		host == "market.yandex.ru" && (first == "product" || first == "card"),
+		matchHost(host, "avito.ru"),
+		matchHost(host, "walmart.com") && first == "ip":
+		return model.KindShop
+
+	// Статьи
+	case matchHost(host, "medium.com") && first != "",
+		host == "dev.to" && len(segments) >= 2,
+		host == "habr.com" && slicesContain(segments, "articles", "post"),
+		matchHost(host, "substack.com") && first == "p",
+		matchHost(host, "wikipedia.org") && first == "wiki",
+		matchHost(host, "linkedin.com") && first == "pulse":
+		return model.KindArticle
+	}
+
+	return ""
+}
+
+// classifyPath распознаёт вид страницы по типичным разделам сайта
+func classifyPath(segments []string) string {
+	if len(segments) > 2 {
+		segments = segments[:2]
+	}
+
+	switch {
+	case slicesContain(segments, "docs", "doc", "documentation", "manual", "reference"):
+		return model.KindDocumentation
+	case slicesContain(segments, "product", "products", "shop"):
+		return model.KindShop
+	case len(segments) == 2 && strings.HasPrefix(segments[0], "@") && isDigits(segments[1]):
+		// Публикации в Mastodon и других сервисах федиверса: /@user/123456
+		return model.KindSocial
+	case slicesContain(segments, "blog", "article", "articles", "post", "posts", "news"):
+		return model.KindArticle
+	}
+	return ""
+}
+
+// matchHost проверяет, что хост совпадает с доменом или является его поддоменом
+func matchHost(host, domain string) bool {
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// isSite проверяет имя сайта с любым доменом верхнего уровня: amazon.com, amazon.co.uk
+func isSite(host, name string) bool {
+	return strings.HasPrefix(host, name+".") || strings.Contains(host, "."+name+".")
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// slicesContain проверяет, есть ли среди сегментов хотя бы одно из значений
+func slicesContain(segments []string, values ...string) bool {
+	for _, segment := range segments {
+		for _, value := range values {
+			if segment == value {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/aerscs/theca-public/internal/model"
+)
+
+func TestClassifyURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		// Файлы по расширению
+		{"https://example.com/paper.PDF", model.KindPDF},
+		{"https://example.com/photo.jpeg", model.KindImage},
+		{"https://example.com/clip.mp4", model.KindVideo},
+
+		// Видео
+		{"https://www.youtube.com/watch?v=abc", model.KindVideo},
+		{"https://m.youtube.com/shorts/abc", model.KindVideo},
+		{"https://youtu.be/abc", model.KindVideo},
+		{"https://vimeo.com/123456", model.KindVideo},
+		{"https://www.tiktok.com/@user/video/123", model.KindVideo},
+		{"https://www.youtube.com/@channel", model.KindOther},
+		{"https://vimeo.com/about", model.KindOther},
+
+		// Репозитории
+		{"https://github.com/golang/go", model.KindRepository},
+		{"https://github.com/golang/go/issues/1", model.KindRepository},
+		{"https://github.com/golang", model.KindOther},
+		{"https://github.com/topics/go", model.KindOther},
+		{"https://github.com/login/oauth", model.KindOther},
+		{"https://gitlab.com/group/project", model.KindRepository},
+		{"https://gitlab.com/explore/projects", model.KindOther},
+		{"https://gist.github.com/user", model.KindRepository},
+
+		// Документация
+		{"https://pkg.go.dev/net/http", model.KindDocumentation},
+		{"https://docs.python.org/3/", model.KindDocumentation},
+		{"https://requests.readthedocs.io/en/latest/", model.KindDocumentation},
+		{"https://example.com/docs/getting-started", model.KindDocumentation},
+
+		// Социальные сети
+		{"https://x.com/user/status/1", model.KindSocial},
+		{"https://old.reddit.com/r/golang", model.KindSocial},
+		{"https://news.ycombinator.com/item?id=1", model.KindSocial},
+		{"https://mastodon.social/@user/123456", model.KindSocial},
+		{"https://mastodon.social/@user", model.KindOther},
+
+		// Магазины
+		{"https://www.amazon.com/dp/B000", model.KindShop},
+		{"https://www.amazon.co.uk/Some-Book/dp/B000", model.KindShop},
+		{"https://www.amazon.de/gp/product/B000", model.KindShop},
+		{"https://smile.amazon.com/gp/help", model.KindOther},
+		{"https://www.ebay.com/itm/123", model.KindShop},
+		{"https://example.com/products/chair", model.KindShop},
+
+		// Статьи
+		{"https://medium.com/@user/post-123", model.KindArticle},
+		{"https://en.wikipedia.org/wiki/Go", model.KindArticle},
+		{"https://habr.com/ru/articles/123/", model.KindArticle},
+		{"https://example.substack.com/p/post", model.KindArticle},
+		{"https://example.com/blog/post", model.KindArticle},
+		{"https://example.com/2024/01/02/blog/post", model.KindOther},
+
+		// Без схемы и неразбираемые адреса
+		{"github.com/golang/go", model.KindRepository},
+		{"example.com", model.KindOther},
+		{"https://example.com/%zz", model.KindOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if got := ClassifyURL(tt.url); got != tt.want {
+				t.Fatalf("ClassifyURL(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifyPage(t *testing.T) {
+	longArticle := &Article{WordCount: articleMinWords}
+	shortArticle := &Article{WordCount: articleMinWords - 1}
+
+	tests := []struct {
+		name    string
+		url     string
+		details *PageDetails
+		want    string
+	}{
+		{"content type wins over host", "https://github.com/golang/go", &PageDetails{ContentType: "application/pdf"}, model.KindPDF},
+		{"image content type with parameters", "https://example.com/view", &PageDetails{ContentType: "image/png; charset=binary"}, model.KindImage},
+		{"html content type is ignored", "https://example.com/view", &PageDetails{ContentType: "text/html; charset=utf-8"}, model.KindOther},
+		{"malformed content type is ignored", "https://example.com/file.pdf", &PageDetails{ContentType: ";;"}, model.KindPDF},
+		{"host wins over og type", "https://github.com/golang/go", &PageDetails{Metadata: &PageMetadata{Type: "article"}}, model.KindRepository},
+		{"og video", "https://example.com/watch/1", &PageDetails{Metadata: &PageMetadata{Type: "video.movie"}}, model.KindVideo},
+		{"og product", "https://example.com/item/1", &PageDetails{Metadata: &PageMetadata{Type: "product.item"}}, model.KindShop},
+		{"og type wins over path", "https://example.com/blog/launch-video", &PageDetails{Metadata: &PageMetadata{Type: "video"}}, model.KindVideo},
+		{"unknown og type falls through to path", "https://example.com/blog/post", &PageDetails{Metadata: &PageMetadata{Type: "website"}}, model.KindArticle},
+		{"long text is an article", "https://example.com/essay", &PageDetails{Article: longArticle}, model.KindArticle},
+		{"short text is not an article", "https://example.com/essay", &PageDetails{Article: shortArticle}, model.KindOther},
+		{"no details", "https://example.com/essay", nil, model.KindOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyPage(tt.url, tt.details); got != tt.want {
+				t.Fatalf("ClassifyPage(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchHost(t *testing.T) {
+	tests := []struct {
+		host, domain string
+		want         bool
+	}{
+		{"youtube.com", "youtube.com", true},
+		{"m.youtube.com", "youtube.com", true},
+		{"notyoutube.com", "youtube.com", false},
+		{"youtube.com.evil.net", "youtube.com", false},
+	}
+	for _, tt := range tests {
+		if got := matchHost(tt.host, tt.domain); got != tt.want {
+			t.Errorf("matchHost(%q, %q) = %v, want %v", tt.host, tt.domain, got, tt.want)
+		}
+	}
+}
@@ -18,8 +18,8 @@ func NewBookmarkHTMLExporter() *BookmarkHTMLExporter {
 	return &BookmarkHTMLExporter{}
 }
 
-// ExportToHTML экспортирует закладки в HTML-формат
-func (e *BookmarkHTMLExporter) ExportToHTML(bookmarks []model.Bookmark) (string, error) {
+// ExportToHTML экспортирует закладки в HTML-формат. Комментарии к закладке попадают в её описание
+func (e *BookmarkHTMLExporter) ExportToHTML(bookmarks []model.ExportedBookmark) (string, error) {
 	var buffer bytes.Buffer
 
 	// HTML header
@@ -56,6 +56,9 @@ func (e *BookmarkHTMLExporter) ExportToHTML(bookmarks []model.Bookmark) (string,
 
 		buffer.WriteString(fmt.Sprintf(`<DT><A HREF="%s" ADD_DATE="%d" LAST_MODIFIED="%d" ICON_URI="%s">%s</A>
 `, url, addDate, lastModified, favicon, title))
+		if comments := exportComments(bookmark.Comments); comments != "" {
+			buffer.WriteString("<DD>" + comments + "\n")
+		}
 	}
 
 	// Закрытие HTML
@@ -67,6 +70,19 @@ func (e *BookmarkHTMLExporter) ExportToHTML(bookmarks []model.Bookmark) (string,
 	return base64.StdEncoding.EncodeToString(buffer.Bytes()), nil
 }
 
+// exportComments склеивает комментарии в описание закладки, по одному на строку; удалённые пропускаются
+func exportComments(comments []model.ExportedComment) string {
+	lines := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		if comment.Deleted {
+			continue
+		}
+		body := strings.Join(strings.Fields(comment.Body), " ")
+		lines = append(lines, sanitizeHTML(fmt.Sprintf("%s (%s): %s", comment.Author, comment.CreatedAt.UTC().Format(time.DateOnly), body)))
+	}
+	return strings.Join(lines, "<BR>")
+}
+
 // sanitizeHTML экранирует специальные символы HTML
 func sanitizeHTML(input string) string {
 	replacer := strings.NewReplacer(
@@ -80,7 +96,7 @@ func sanitizeHTML(input string) string {
 }
 
 // ExportBookmarksToHTML обертка для удобного экспорта закладок
-func ExportBookmarksToHTML(bookmarks []model.Bookmark) (string, error) {
+func ExportBookmarksToHTML(bookmarks []model.ExportedBookmark) (string, error) {
 	exporter := NewBookmarkHTMLExporter()
 	return exporter.ExportToHTML(bookmarks)
 }
@@ -1,8 +1,8 @@
 package parsers
 
 import (
+	"bytes"
 	"context"
-	"encoding/base64"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,15 +11,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aerscs/theca-public/internal/model"
 	"github.com/aerscs/theca-public/internal/repository"
+	"github.com/aerscs/theca-public/internal/utils/httpclient"
 	"golang.org/x/net/html"
 )
 
-type IconCandidate struct {
-	URL      string
-	Priority int
-}
-
 // normalizeURL normalizes the URL to be used as a cache key
 func normalizeURL(resourceURL string) string {
 	if !strings.HasPrefix(resourceURL, "http://") && !strings.HasPrefix(resourceURL, "https://") {
@@ -34,200 +31,329 @@ func normalizeURL(resourceURL string) string {
 	return u.Scheme + "://" + u.Host
 }
 
+// fetcher общий исходящий клиент для загрузки страниц и иконок
+var fetcher = httpclient.New(httpclient.DefaultOptions(), nil)
+
+// SetHTTPClient заменяет исходящий клиент, используемый парсерами
+func SetHTTPClient(client *httpclient.Client) {
+	if client != nil {
+		fetcher = client
+	}
+}
+
 // createHTTPClient creates HTTP client with reasonable defaults
 func createHTTPClient() *http.Client {
-	return &http.Client{
-		Timeout: 30 * time.Second,
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			// Follow up to 10 redirects, но игнорируем редиректы на авторизацию
-			if len(via) >= 10 {
-				return fmt.Errorf("too many redirects")
-			}
+	return fetcher.HTTPClient(30*time.Second, func(req *http.Request, via []*http.Request) error {
+		// Follow up to 10 redirects, но игнорируем редиректы на авторизацию
+		if len(via) >= 10 {
+			return fmt.Errorf("too many redirects")
+		}
 
-			// Если редиректит на авторизацию - останавливаемся
-			reqURL := req.URL.String()
-			if strings.Contains(reqURL, "login") ||
-				strings.Contains(reqURL, "signin") ||
-				strings.Contains(reqURL, "auth") ||
-				strings.Contains(reqURL, "accounts.google.com") {
-				return http.ErrUseLastResponse
-			}
+		// Если редиректит на авторизацию - останавливаемся
+		reqURL := req.URL.String()
+		if strings.Contains(reqURL, "login") ||
+			strings.Contains(reqURL, "signin") ||
+			strings.Contains(reqURL, "auth") ||
+			strings.Contains(reqURL, "accounts.google.com") {
+			return http.ErrUseLastResponse
+		}
 
-			return nil
-		},
-	}
+		return nil
+	})
+}
+
+// maxPageSize ограничивает размер загружаемой HTML-страницы
+const maxPageSize = 5 << 20
+
+// PageDetails содержит данные, полученные за одну загрузку страницы
+type PageDetails struct {
+	Metadata    *PageMetadata
+	Article     *Article
+	Favicon     string
+	ContentType string
+	Icons       []model.Icon
+}
+
+// fetchedPage содержит результат загрузки страницы ресурса
+type fetchedPage struct {
+	BaseURL     *url.URL
+	Location    string
+	ContentType string
+	Body        []byte
+	StatusCode  int
 }
 
 // FetchFaviconBase64 extracts favicon for the specified resource and returns it as base64 encoded string.
 // If favicon exists in cache, returns it, otherwise downloads and caches it
 func FetchFaviconBase64(ctx context.Context, cacheRepo repository.FaviconCacheRepository, resourceURL string) (string, error) {
+	icons, err := FetchIconSet(ctx, cacheRepo, resourceURL)
+	if err != nil {
+		return "", err
+	}
+
+	return PrimaryIcon(icons), nil
+}
+
+// FetchIconSet returns icons of several resolutions for the specified resource.
+// If the set exists in cache, returns it, otherwise downloads and caches it
+func FetchIconSet(ctx context.Context, cacheRepo repository.FaviconCacheRepository, resourceURL string) ([]model.Icon, error) {
 	normalizedURL := normalizeURL(resourceURL)
 
-	if cacheRepo != nil {
-		if cachedFaviconBase64, err := cacheRepo.GetFaviconBase64(ctx, normalizedURL); err == nil && cachedFaviconBase64 != "" {
-			return cachedFaviconBase64, nil
-		}
+	if !strings.HasPrefix(resourceURL, "http://") && !strings.HasPrefix(resourceURL, "https://") {
+		resourceURL = "https://" + resourceURL
+	}
+
+	if icons := getCachedOrKnownIcons(ctx, cacheRepo, normalizedURL, resourceURL); len(icons) > 0 {
+		return icons, nil
+	}
+
+	page, err := fetchPage(ctx, resourceURL)
+	if err != nil {
+		return nil, err
 	}
 
+	return resolveIcons(ctx, cacheRepo, normalizedURL, resourceURL, page, nil)
+}
+
+// FetchPageDetails загружает страницу один раз и извлекает из неё
+// метаданные, текст статьи и набор иконок. Иконки берутся из кэша, если они там есть
+func FetchPageDetails(ctx context.Context, cacheRepo repository.FaviconCacheRepository, resourceURL string) (*PageDetails, error) {
+	normalizedURL := normalizeURL(resourceURL)
+
 	if !strings.HasPrefix(resourceURL, "http://") && !strings.HasPrefix(resourceURL, "https://") {
 		resourceURL = "https://" + resourceURL
 	}
 
+	page, err := fetchPage(ctx, resourceURL)
+	if err != nil {
+		// Страница недоступна, но иконки могут быть в кэше
+		icons := getCachedOrKnownIcons(ctx, cacheRepo, normalizedURL, resourceURL)
+		return &PageDetails{Favicon: PrimaryIcon(icons), Icons: icons}, err
+	}
+
+	details := &PageDetails{ContentType: page.ContentType}
+
+	var doc *html.Node
+	if page.StatusCode == http.StatusOK && isHTMLContentType(page.ContentType) {
+		doc, err = html.Parse(bytes.NewReader(page.Body))
+		if err == nil {
+			details.Metadata = ExtractMetadata(doc, page.BaseURL)
+			details.Article = ExtractArticle(doc)
+		}
+	}
+
+	details.Icons = getCachedOrKnownIcons(ctx, cacheRepo, normalizedURL, resourceURL)
+	if len(details.Icons) == 0 {
+		details.Icons, err = resolveIcons(ctx, cacheRepo, normalizedURL, resourceURL, page, doc)
+		if err != nil {
+			return details, err
+		}
+	}
+	details.Favicon = PrimaryIcon(details.Icons)
+
+	return details, nil
+}
+
+// getCachedOrKnownIcons returns icons from cache or from the list of known services
+func getCachedOrKnownIcons(ctx context.Context, cacheRepo repository.FaviconCacheRepository, normalizedURL, resourceURL string) []model.Icon {
+	if cacheRepo != nil {
+		if icons, err := cacheRepo.GetFaviconSet(ctx, normalizedURL); err == nil && len(icons) > 0 {
+			return icons
+		}
+		// Записи, сохранённые до появления наборов иконок, содержат одну фавиконку
+		if cachedFaviconBase64, err := cacheRepo.GetFaviconBase64(ctx, normalizedURL); err == nil && cachedFaviconBase64 != "" {
+			return []model.Icon{{Data: cachedFaviconBase64}}
+		}
+	}
+
 	// Специальная обработка для известных сервисов
 	if faviconURL := getKnownServiceFavicon(resourceURL); faviconURL != "" {
-		faviconBase64, err := downloadAndEncodeToBase64(faviconURL)
-		if err == nil && faviconBase64 != "" {
-			if cacheRepo != nil {
-				_ = cacheRepo.StoreFaviconBase64(ctx, normalizedURL, faviconBase64)
-			}
-			return faviconBase64, nil
+		if icon, err := downloadIcon(ctx, faviconURL, 0); err == nil {
+			icons := []model.Icon{*icon}
+			storeIcons(ctx, cacheRepo, normalizedURL, icons)
+			return icons
 		}
 	}
 
+	return nil
+}
+
+// storeIcons кэширует набор иконок и основную фавиконку
+func storeIcons(ctx context.Context, cacheRepo repository.FaviconCacheRepository, normalizedURL string, icons []model.Icon) {
+	if cacheRepo == nil || len(icons) == 0 {
+		return
+	}
+	_ = cacheRepo.StoreFaviconSet(ctx, normalizedURL, icons)
+	_ = cacheRepo.StoreFaviconBase64(ctx, normalizedURL, PrimaryIcon(icons))
+}
+
+// fetchPage downloads the resource page and returns its body along with response details
+func fetchPage(ctx context.Context, resourceURL string) (*fetchedPage, error) {
 	client := createHTTPClient()
 
 	// Создаем запрос с User-Agent для получения полного HTML
 	req, err := http.NewRequestWithContext(ctx, "GET", resourceURL, nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Добавляем реалистичный User-Agent для обхода блокировок
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+	// User-Agent выставляет общий клиент.
+	// Accept-Encoding не выставляем: иначе транспорт не распакует gzip-ответ
 	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/webp,*/*;q=0.8")
 	req.Header.Set("Accept-Language", "en-US,en;q=0.5")
-	req.Header.Set("Accept-Encoding", "gzip, deflate, br")
 	req.Header.Set("DNT", "1")
 	req.Header.Set("Connection", "keep-alive")
 	req.Header.Set("Upgrade-Insecure-Requests", "1")
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch URL: %w", err)
+		return nil, fmt.Errorf("failed to fetch URL: %w", err)
 	}
 	defer resp.Body.Close()
 
+	page := &fetchedPage{
+		BaseURL:     resp.Request.URL,
+		Location:    resp.Header.Get("Location"),
+		ContentType: resp.Header.Get("Content-Type"),
+		StatusCode:  resp.StatusCode,
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		page.Body, err = io.ReadAll(io.LimitReader(resp.Body, maxPageSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+	}
+
+	return page, nil
+}
+
+// resolveIcons searches for icons using the already downloaded page.
+// doc may be nil, in which case the page body is parsed here
+func resolveIcons(ctx context.Context, cacheRepo repository.FaviconCacheRepository, normalizedURL, resourceURL string, page *fetchedPage, doc *html.Node) ([]model.Icon, error) {
 	// Если редирект на авторизацию - пробуем базовый домен
-	if resp.StatusCode >= 300 && resp.StatusCode < 400 {
-		location := resp.Header.Get("Location")
+	if page.StatusCode >= 300 && page.StatusCode < 400 {
+		location := page.Location
 		if strings.Contains(location, "login") || strings.Contains(location, "signin") || strings.Contains(location, "auth") {
 			// Пробуем получить favicon напрямую с базового домена
 			baseURL, _ := url.Parse(resourceURL)
 			if baseURL != nil {
-				return tryFaviconFromBaseDomain(ctx, cacheRepo, normalizedURL, baseURL)
+				return tryIconsFromBaseDomain(ctx, cacheRepo, normalizedURL, baseURL)
 			}
 		}
 	}
 
-	if resp.StatusCode != http.StatusOK {
+	if page.StatusCode != http.StatusOK {
 		// Если не удалось получить основную страницу, пробуем базовый домен
 		baseURL, _ := url.Parse(resourceURL)
 		if baseURL != nil {
-			return tryFaviconFromBaseDomain(ctx, cacheRepo, normalizedURL, baseURL)
+			return tryIconsFromBaseDomain(ctx, cacheRepo, normalizedURL, baseURL)
 		}
-		return "", fmt.Errorf("received non-200 response code: %d", resp.StatusCode)
+		return nil, fmt.Errorf("received non-200 response code: %d", page.StatusCode)
 	}
 
-	finalURL := resp.Request.URL.String()
-	baseURL, err := url.Parse(finalURL)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse URL: %w", err)
-	}
+	baseURL := page.BaseURL
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
+	// Парсим HTML и собираем иконки из link/meta-тегов и web app manifest
+	if doc == nil {
+		doc, _ = html.Parse(bytes.NewReader(page.Body))
 	}
 
-	// Сначала пробуем стандартные местоположения
-	standardIconURL := checkStandardFaviconLocations(baseURL)
-	if standardIconURL != "" {
-		faviconBase64, err := downloadAndEncodeToBase64(standardIconURL)
-		if err == nil && faviconBase64 != "" {
-			if cacheRepo != nil {
-				_ = cacheRepo.StoreFaviconBase64(ctx, normalizedURL, faviconBase64)
-			}
-			return faviconBase64, nil
+	if doc != nil {
+		candidates := findIconCandidates(doc, baseURL)
+
+		// Стандартные пути манифеста проверяем, только если страница не объявила крупных иконок
+		manifestURL := findManifestURL(doc, baseURL)
+		if manifestURL != "" || !hasLargeIconCandidate(candidates) {
+			candidates = append(candidates, fetchManifestIcons(ctx, manifestURL, baseURL)...)
+			sortIconCandidates(candidates)
 		}
-	}
 
-	// Парсим HTML и ищем иконки в мета-тегах
-	doc, err := html.Parse(strings.NewReader(string(body)))
-	if err != nil {
-		return "", fmt.Errorf("failed to parse HTML: %w", err)
+		if icons := downloadIconSet(ctx, candidates); len(icons) > 0 {
+			storeIcons(ctx, cacheRepo, normalizedURL, icons)
+			return icons, nil
+		}
 	}
 
-	candidates := findIconCandidates(doc, baseURL)
-	for _, candidate := range candidates {
-		faviconBase64, err := downloadAndEncodeToBase64(candidate.URL)
-		if err == nil && faviconBase64 != "" {
-			if cacheRepo != nil {
-				_ = cacheRepo.StoreFaviconBase64(ctx, normalizedURL, faviconBase64)
-			}
-			return faviconBase64, nil
+	// Пробуем стандартные местоположения
+	if standardIconURL := checkStandardFaviconLocations(baseURL); standardIconURL != "" {
+		if icon, err := downloadIcon(ctx, standardIconURL, 0); err == nil {
+			icons := []model.Icon{*icon}
+			storeIcons(ctx, cacheRepo, normalizedURL, icons)
+			return icons, nil
 		}
 	}
 
 	// Пробуем регулярные выражения для поиска в HTML
-	iconURL := findIconWithRegex(string(body), baseURL)
-	if iconURL != "" {
-		faviconBase64, err := downloadAndEncodeToBase64(iconURL)
-		if err == nil && faviconBase64 != "" {
-			if cacheRepo != nil {
-				_ = cacheRepo.StoreFaviconBase64(ctx, normalizedURL, faviconBase64)
-			}
-			return faviconBase64, nil
+	if iconURL := findIconWithRegex(string(page.Body), baseURL); iconURL != "" {
+		if icon, err := downloadIcon(ctx, iconURL, 0); err == nil {
+			icons := []model.Icon{*icon}
+			storeIcons(ctx, cacheRepo, normalizedURL, icons)
+			return icons, nil
 		}
 	}
 
 	// Последняя попытка - дефолтная иконка
 	defaultIconURL := baseURL.Scheme + "://" + baseURL.Host + "/favicon.ico"
-	faviconBase64, err := downloadAndEncodeToBase64(defaultIconURL)
-	if err == nil && faviconBase64 != "" {
-		if cacheRepo != nil {
-			_ = cacheRepo.StoreFaviconBase64(ctx, normalizedURL, faviconBase64)
-		}
-		return faviconBase64, nil
+	if icon, err := downloadIcon(ctx, defaultIconURL, 0); err == nil {
+		icons := []model.Icon{*icon}
+		storeIcons(ctx, cacheRepo, normalizedURL, icons)
+		return icons, nil
 	}
 
-	return "", fmt.Errorf("failed to find or download any valid favicon")
+	return nil, fmt.Errorf("failed to find or download any valid favicon")
 }
 
-// downloadAndEncodeToBase64 downloads an image from URL and converts it to base64
-func downloadAndEncodeToBase64(imageURL string) (string, error) {
-	client := &http.Client{
-		Timeout: 15 * time.Second,
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			return http.ErrUseLastResponse
-		},
+// isHTMLContentType checks whether the response looks like an HTML document
+func isHTMLContentType(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	contentType = strings.ToLower(contentType)
+	return strings.Contains(contentType, "text/html") || strings.Contains(contentType, "application/xhtml")
+}
+
+// maxFaviconSize ограничивает размер загружаемой фавиконки
+const maxFaviconSize = 1 << 20
+
+// downloadImage downloads an image without following redirects.
+// Images larger than maxSize bytes are rejected
+func downloadImage(ctx context.Context, imageURL string, maxSize int64) ([]byte, string, error) {
+	client := fetcher.HTTPClient(15*time.Second, httpclient.NoRedirects)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", imageURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := client.Get(imageURL)
+	resp, err := client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to download image: %w", err)
+		return nil, "", fmt.Errorf("failed to download image: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("received non-200 response code: %d", resp.StatusCode)
+		return nil, "", fmt.Errorf("received non-200 response code: %d", resp.StatusCode)
+	}
+
+	if resp.ContentLength > maxSize {
+		return nil, "", fmt.Errorf("image is too large: %d bytes", resp.ContentLength)
 	}
 
-	imageData, err := io.ReadAll(resp.Body)
+	imageData, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
 	if err != nil {
-		return "", fmt.Errorf("failed to read image data: %w", err)
+		return nil, "", fmt.Errorf("failed to read image data: %w", err)
 	}
 
-	if len(imageData) == 0 {
-		return "", fmt.Errorf("empty image data")
+	if int64(len(imageData)) > maxSize {
+		return nil, "", fmt.Errorf("image is too large: more than %d bytes", maxSize)
 	}
 
-	contentType := resp.Header.Get("Content-Type")
-	if contentType == "" {
-		contentType = "image/x-icon"
+	if len(imageData) == 0 {
+		return nil, "", fmt.Errorf("empty image data")
 	}
 
-	base64Data := base64.StdEncoding.EncodeToString(imageData)
-	return fmt.Sprintf("data:%s;base64,%s", contentType, base64Data), nil
+	return imageData, resp.Header.Get("Content-Type"), nil
 }
 
 func checkStandardFaviconLocations(baseURL *url.URL) string {
@@ -247,12 +373,7 @@ func checkStandardFaviconLocations(baseURL *url.URL) string {
 		"/favicon-16x16.png",
 	}
 
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			return http.ErrUseLastResponse
-		},
-	}
+	client := fetcher.HTTPClient(10*time.Second, httpclient.NoRedirects)
 
 	for _, path := range standardPaths {
 		iconURL := baseURL.Scheme + "://" + baseURL.Host + path
@@ -270,74 +391,6 @@ func checkStandardFaviconLocations(baseURL *url.URL) string {
 	return ""
 }
 
-// findIconCandidates searches for icon links in HTML document and returns them sorted by priority
-func findIconCandidates(doc *html.Node, baseURL *url.URL) []IconCandidate {
-	var candidates []IconCandidate
-
-	// Исправленные приоритеты: меньшее число = больший приоритет
-	relPriorities := map[string]int{
-		"icon":                         1, // Самый высокий приоритет
-		"shortcut icon":                2,
-		"apple-touch-icon":             3,
-		"apple-touch-icon-precomposed": 4,
-		"fluid-icon":                   5,
-		"mask-icon":                    6,
-		"alternate icon":               7, // Самый низкий приоритет
-	}
-
-	var traverse func(*html.Node)
-	traverse = func(n *html.Node) {
-		if n.Type == html.ElementNode && n.Data == "link" {
-			var rel, href, sizes string
-			for _, attr := range n.Attr {
-				switch attr.Key {
-				case "rel":
-					rel = strings.ToLower(strings.TrimSpace(attr.Val))
-				case "href":
-					href = strings.TrimSpace(attr.Val)
-				case "sizes":
-					sizes = strings.TrimSpace(attr.Val)
-				}
-			}
-
-			priority, isIcon := relPriorities[rel]
-			if isIcon && href != "" {
-				iconURL, err := url.Parse(href)
-				if err == nil {
-					absoluteURL := baseURL.ResolveReference(iconURL).String()
-
-					// Повышаем приоритет для больших размеров
-					if sizes != "" && (strings.Contains(sizes, "32x32") || strings.Contains(sizes, "64x64") || strings.Contains(sizes, "128x128")) {
-						priority -= 1 // Увеличиваем приоритет
-					}
-
-					candidates = append(candidates, IconCandidate{
-						URL:      absoluteURL,
-						Priority: priority,
-					})
-				}
-			}
-		}
-
-		for c := n.FirstChild; c != nil; c = c.NextSibling {
-			traverse(c)
-		}
-	}
-
-	traverse(doc)
-
-	// Сортируем по приоритету (меньшее число = больший приоритет)
-	for i := range len(candidates) - 1 {
-		for j := i + 1; j < len(candidates); j++ {
-			if candidates[i].Priority > candidates[j].Priority {
-				candidates[i], candidates[j] = candidates[j], candidates[i]
-			}
-		}
-	}
-
-	return candidates
-}
-
 // findIconWithRegex attempts to find icon URLs using regex patterns when HTML parsing fails
 func findIconWithRegex(html string, baseURL *url.URL) string {
 	patterns := []string{
@@ -375,65 +428,23 @@ func findIconWithRegex(html string, baseURL *url.URL) string {
 	return ""
 }
 
-// getKnownServiceFavicon returns direct favicon URL for known services
-func getKnownServiceFavicon(resourceURL string) string {
-	// Парсим URL для определения домена
-	u, err := url.Parse(resourceURL)
-	if err != nil {
-		return ""
-	}
-
-	domain := strings.ToLower(u.Host)
-	if strings.HasPrefix(domain, "www.") {
-		domain = domain[4:]
-	}
-
-	// Известные сервисы с прямыми ссылками на фавиконки
-	knownServices := map[string]string{
-		"gmail.com":         "https://ssl.gstatic.com/ui/v1/icons/mail/rfr/gmail.ico",
-		"mail.google.com":   "https://ssl.gstatic.com/ui/v1/icons/mail/rfr/gmail.ico",
-		"google.com":        "https://www.google.com/favicon.ico",
-		"youtube.com":       "https://www.youtube.com/favicon.ico",
-		"github.com":        "https://github.com/favicon.ico",
-		"stackoverflow.com": "https://cdn.sstatic.net/Sites/stackoverflow/Img/favicon.ico",
-		"twitter.com":       "https://abs.twimg.com/favicons/twitter.ico",
-		"facebook.com":      "https://static.xx.fbcdn.net/rsrc.php/yV/r/hzMapiNYYpW.ico",
-		"linkedin.com":      "https://static.licdn.com/sc/h/1bt1uwq5akv756knzdj4l6cdc",
-	}
-
-	if faviconURL, exists := knownServices[domain]; exists {
-		return faviconURL
-	}
-
-	return ""
-}
+// tryIconsFromBaseDomain tries to get icons directly from base domain without redirects
+func tryIconsFromBaseDomain(ctx context.Context, cacheRepo repository.FaviconCacheRepository, normalizedURL string, baseURL *url.URL) ([]model.Icon, error) {
+	// Сначала проверяем известные сервисы, затем стандартные местоположения
+	iconURLs := []string{getKnownServiceFavicon(baseURL.String()), checkStandardFaviconLocations(baseURL)}
 
-// tryFaviconFromBaseDomain tries to get favicon directly from base domain without redirects
-func tryFaviconFromBaseDomain(ctx context.Context, cacheRepo repository.FaviconCacheRepository, normalizedURL string, baseURL *url.URL) (string, error) {
-	// Сначала проверяем известные сервисы
-	if faviconURL := getKnownServiceFavicon(baseURL.String()); faviconURL != "" {
-		faviconBase64, err := downloadAndEncodeToBase64(faviconURL)
-		if err == nil && faviconBase64 != "" {
-			if cacheRepo != nil {
-				_ = cacheRepo.StoreFaviconBase64(ctx, normalizedURL, faviconBase64)
-			}
-			return faviconBase64, nil
+	for _, iconURL := range iconURLs {
+		if iconURL == "" {
+			continue
 		}
-	}
-
-	// Пробуем стандартные местоположения
-	standardIconURL := checkStandardFaviconLocations(baseURL)
-	if standardIconURL != "" {
-		faviconBase64, err := downloadAndEncodeToBase64(standardIconURL)
-		if err == nil && faviconBase64 != "" {
-			if cacheRepo != nil {
-				_ = cacheRepo.StoreFaviconBase64(ctx, normalizedURL, faviconBase64)
-			}
-			return faviconBase64, nil
+		if icon, err := downloadIcon(ctx, iconURL, 0); err == nil {
+			icons := []model.Icon{*icon}
+			storeIcons(ctx, cacheRepo, normalizedURL, icons)
+			return icons, nil
 		}
 	}
 
-	return "", fmt.Errorf("failed to find favicon from base domain")
+	return nil, fmt.Errorf("failed to find favicon from base domain")
 }
 
 func FetchFavicon(ctx context.Context, cacheRepo repository.FaviconCacheRepository, resourceURL string) (string, error) {
@@ -0,0 +1,450 @@
+package parsers
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"image"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aerscs/theca-public/internal/model"
+	"golang.org/x/net/html"
+)
+
+const (
+	// maxManifestSize ограничивает размер загружаемого web app manifest
+	maxManifestSize = 256 << 10
+	// maxIconDownloads ограничивает количество скачиваемых кандидатов для одного сайта
+	maxIconDownloads = 6
+	// scalableIconSize условный размер векторной иконки (sizes="any")
+	scalableIconSize = 512
+	// PrimaryIconSize размер, под который выбирается основная фавиконка закладки
+	PrimaryIconSize = 64
+)
+
+// IconTargetSizes размеры, под которые подбирается набор иконок для экранов с высокой плотностью
+var IconTargetSizes = []int{32, 64, 128, 192}
+
+// IconCandidate describes an icon declared by the page or its manifest
+type IconCandidate struct {
+	URL    string
+	Type   string
+	Source string
+	Size   int
+	Score  int
+}
+
+// Базовые оценки источников иконок: чем больше, тем предпочтительнее
+var iconSourceScores = map[string]int{
+	"manifest":                     50,
+	"apple-touch-icon":             45,
+	"apple-touch-icon-precomposed": 44,
+	"icon":                         40,
+	"shortcut icon":                38,
+	"fluid-icon":                   30,
+	"msapplication-tileimage":      30,
+	"alternate icon":               20,
+	"mask-icon":                    5, // монохромный SVG, годится только как крайний вариант
+}
+
+// findIconCandidates searches for icon links and meta tags in HTML document
+// and returns them sorted by score, best first
+func findIconCandidates(doc *html.Node, baseURL *url.URL) []IconCandidate {
+	var candidates []IconCandidate
+
+	var traverse func(*html.Node)
+	traverse = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "link":
+				rel := strings.Join(strings.Fields(strings.ToLower(getAttr(n, "rel"))), " ")
+				if _, isIcon := iconSourceScores[rel]; isIcon {
+					if iconURL := resolveHTTPURL(baseURL, getAttr(n, "href")); iconURL != "" {
+						candidates = append(candidates, newIconCandidate(iconURL, rel, getAttr(n, "type"), getAttr(n, "sizes"), ""))
+					}
+				}
+			case "meta":
+				if strings.EqualFold(getAttr(n, "name"), "msapplication-TileImage") {
+					if iconURL := resolveHTTPURL(baseURL, getAttr(n, "content")); iconURL != "" {
+						// Плитки Windows обычно 144x144
+						candidates = append(candidates, newIconCandidate(iconURL, "msapplication-tileimage", "", "144x144", ""))
+					}
+				}
+			}
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			traverse(c)
+		}
+	}
+
+	traverse(doc)
+	sortIconCandidates(candidates)
+
+	return candidates
+}
+
+// findManifestURL returns the web app manifest URL declared with <link rel="manifest">
+func findManifestURL(doc *html.Node, baseURL *url.URL) string {
+	var manifestURL string
+
+	var traverse func(*html.Node)
+	traverse = func(n *html.Node) {
+		if manifestURL != "" {
+			return
+		}
+		if n.Type == html.ElementNode && n.Data == "link" && hasRelToken(getAttr(n, "rel"), "manifest") {
+			manifestURL = resolveHTTPURL(baseURL, getAttr(n, "href"))
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			traverse(c)
+		}
+	}
+
+	traverse(doc)
+	return manifestURL
+}
+
+// webManifest описывает интересующую нас часть web app manifest
+type webManifest struct {
+	Icons []struct {
+		Src     string `json:"src"`
+		Sizes   string `json:"sizes"`
+		Type    string `json:"type"`
+		Purpose string `json:"purpose"`
+	} `json:"icons"`
+}
+
+// fetchManifestIcons downloads the web app manifest and returns its icons.
+// If manifestURL is empty, the well-known /manifest.json and /site.webmanifest are tried
+func fetchManifestIcons(ctx context.Context, manifestURL string, baseURL *url.URL) []IconCandidate {
+	manifestURLs := []string{manifestURL}
+	if manifestURL == "" {
+		root := baseURL.Scheme + "://" + baseURL.Host
+		manifestURLs = []string{root + "/manifest.json", root + "/site.webmanifest"}
+	}
+
+	for _, u := range manifestURLs {
+		data, _, err := downloadImage(ctx, u, maxManifestSize)
+		if err != nil {
+			continue
+		}
+
+		var manifest webManifest
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			continue
+		}
+
+		// Относительные пути в манифесте разрешаются от адреса самого манифеста
+		manifestBase, err := url.Parse(u)
+		if err != nil {
+			continue
+		}
+
+		candidates := make([]IconCandidate, 0, len(manifest.Icons))
+		for _, icon := range manifest.Icons {
+			iconURL := resolveHTTPURL(manifestBase, icon.Src)
+			if iconURL == "" {
+				continue
+			}
+			candidates = append(candidates, newIconCandidate(iconURL, "manifest", icon.Type, icon.Sizes, icon.Purpose))
+		}
+
+		if len(candidates) > 0 {
+			return candidates
+		}
+	}
+
+	return nil
+}
+
+// newIconCandidate вычисляет размер, формат и оценку кандидата
+func newIconCandidate(iconURL, source, iconType, sizes, purpose string) IconCandidate {
+	candidate := IconCandidate{
+		URL:    iconURL,
+		Source: source,
+		Type:   iconFormat(iconType, iconURL),
+		Size:   parseIconSizes(sizes),
+	}
+
+	score := iconSourceScores[source]
+
+	switch candidate.Type {
+	case "png":
+		score += 10
+	case "svg":
+		score += 8
+	case "ico":
+		score += 4
+	case "jpeg", "webp":
+		score += 2
+	}
+
+	switch size := candidate.Size; {
+	case size == 0 && candidate.Type == "svg":
+		candidate.Size = scalableIconSize
+		score += 15
+	case size == 0:
+		score += 5
+	case size >= PrimaryIconSize && size <= 256:
+		score += 20
+	case size > 256:
+		score += 12
+	case size >= 32:
+		score += 10
+	}
+
+	// Маскируемые и монохромные иконки манифеста рассчитаны на обрезку или перекраску
+	purpose = strings.ToLower(purpose)
+	if purpose != "" && !strings.Contains(purpose, "any") {
+		if strings.Contains(purpose, "monochrome") {
+			score -= 40
+		} else {
+			score -= 20
+		}
+	}
+
+	candidate.Score = score
+	return candidate
+}
+
+// parseIconSizes возвращает наибольший из объявленных размеров ("16x16 32x32", "any")
+func parseIconSizes(sizes string) int {
+	largest := 0
+	for _, size := range strings.Fields(strings.ToLower(sizes)) {
+		if size == "any" {
+			return scalableIconSize
+		}
+		w, h, ok := strings.Cut(size, "x")
+		if !ok {
+			continue
+		}
+		width, errW := strconv.Atoi(w)
+		height, errH := strconv.Atoi(h)
+		if errW != nil || errH != nil {
+			continue
+		}
+		largest = max(largest, min(width, height))
+	}
+	return largest
+}
+
+// iconFormat определяет формат иконки по MIME-типу или расширению файла
+func iconFormat(mimeType, iconURL string) string {
+	mimeType = strings.ToLower(strings.TrimSpace(mimeType))
+	switch {
+	case strings.Contains(mimeType, "png"):
+		return "png"
+	case strings.Contains(mimeType, "svg"):
+		return "svg"
+	case strings.Contains(mimeType, "icon"):
+		return "ico"
+	case strings.Contains(mimeType, "jpeg"), strings.Contains(mimeType, "jpg"):
+		return "jpeg"
+	case strings.Contains(mimeType, "webp"):
+		return "webp"
+	case strings.Contains(mimeType, "gif"):
+		return "gif"
+	}
+
+	if u, err := url.Parse(iconURL); err == nil {
+		switch strings.ToLower(path.Ext(u.Path)) {
+		case ".png":
+			return "png"
+		case ".svg":
+			return "svg"
+		case ".ico":
+			return "ico"
+		case ".jpg", ".jpeg":
+			return "jpeg"
+		case ".webp":
+			return "webp"
+		case ".gif":
+			return "gif"
+		}
+	}
+
+	return ""
+}
+
+// sortIconCandidates сортирует кандидатов по убыванию оценки
+func sortIconCandidates(candidates []IconCandidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+}
+
+// downloadIconSet downloads the best candidates and selects a set of icons
+// covering IconTargetSizes. The result is sorted by size ascending
+func downloadIconSet(ctx context.Context, candidates []IconCandidate) []model.Icon {
+	seen := make(map[string]bool, len(candidates))
+	downloaded := make([]model.Icon, 0, maxIconDownloads)
+
+	for _, candidate := range candidates {
+		if len(downloaded) >= maxIconDownloads {
+			break
+		}
+		if seen[candidate.URL] {
+			continue
+		}
+		seen[candidate.URL] = true
+
+		icon, err := downloadIcon(ctx, candidate.URL, candidate.Size)
+		if err != nil {
+			continue
+		}
+		downloaded = append(downloaded, *icon)
+	}
+
+	return selectIconSet(downloaded)
+}
+
+// downloadIcon downloads a single icon and determines its real size.
+// declaredSize is used when the size can't be detected from the data
+func downloadIcon(ctx context.Context, iconURL string, declaredSize int) (*model.Icon, error) {
+	data, contentType, err := downloadImage(ctx, iconURL, maxFaviconSize)
+	if err != nil {
+		return nil, err
+	}
+
+	format := iconFormat(contentType, iconURL)
+	if format == "" {
+		format = "ico"
+	}
+	if strings.HasPrefix(strings.ToLower(contentType), "text/") && format != "svg" {
+		return nil, fmt.Errorf("not an image: %s", contentType)
+	}
+
+	size := detectIconSize(data, format)
+	if size == 0 {
+		size = declaredSize
+	}
+
+	if contentType == "" || !strings.HasPrefix(strings.ToLower(contentType), "image/") {
+		contentType = iconMimeTypes[format]
+	}
+
+	return &model.Icon{
+		Data: fmt.Sprintf("data:%s;base64,%s", contentType, base64.StdEncoding.EncodeToString(data)),
+		Size: size,
+	}, nil
+}
+
+var iconMimeTypes = map[string]string{
+	"png":  "image/png",
+	"svg":  "image/svg+xml",
+	"ico":  "image/x-icon",
+	"jpeg": "image/jpeg",
+	"webp": "image/webp",
+	"gif":  "image/gif",
+}
+
+// detectIconSize определяет реальный размер изображения (меньшую из сторон)
+func detectIconSize(data []byte, format string) int {
+	switch format {
+	case "svg":
+		return scalableIconSize
+	case "ico":
+		return icoSize(data)
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		// Многие сайты отдают ICO под видом PNG и наоборот
+		return icoSize(data)
+	}
+	return min(cfg.Width, cfg.Height)
+}
+
+// icoSize читает каталог ICO-файла и возвращает наибольший размер среди вложенных изображений
+func icoSize(data []byte) int {
+	const headerSize, entrySize = 6, 16
+	if len(data) < headerSize || binary.LittleEndian.Uint16(data[0:2]) != 0 || binary.LittleEndian.Uint16(data[2:4]) != 1 {
+		return 0
+	}
+
+	count := int(binary.LittleEndian.Uint16(data[4:6]))
+	largest := 0
+	for i := range count {
+		offset := headerSize + i*entrySize
+		if offset+entrySize > len(data) {
+			break
+		}
+		// Значение 0 в каталоге означает 256 пикселей
+		width, height := int(data[offset]), int(data[offset+1])
+		if width == 0 {
+			width = 256
+		}
+		if height == 0 {
+			height = 256
+		}
+		largest = max(largest, min(width, height))
+	}
+	return largest
+}
+
+// selectIconSet picks for every target size the smallest icon that is not smaller
+// than the target, or the largest available one
+func selectIconSet(icons []model.Icon) []model.Icon {
+	if len(icons) == 0 {
+		return nil
+	}
+
+	sorted := make([]model.Icon, len(icons))
+	copy(sorted, icons)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Size < sorted[j].Size
+	})
+
+	picked := make(map[int]bool, len(IconTargetSizes))
+	for _, target := range IconTargetSizes {
+		idx := len(sorted) - 1
+		for i, icon := range sorted {
+			if icon.Size >= target {
+				idx = i
+				break
+			}
+		}
+		picked[idx] = true
+	}
+
+	set := make([]model.Icon, 0, len(picked))
+	for i, icon := range sorted {
+		if picked[i] {
+			set = append(set, icon)
+		}
+	}
+	return set
+}
+
+// PrimaryIcon returns the icon that best fits a regular tile (PrimaryIconSize)
+func PrimaryIcon(icons []model.Icon) string {
+	if len(icons) == 0 {
+		return ""
+	}
+
+	for _, icon := range icons {
+		if icon.Size >= PrimaryIconSize {
+			return icon.Data
+		}
+	}
+	return icons[len(icons)-1].Data
+}
+
+// hasLargeIconCandidate проверяет, объявлена ли иконка не меньше основного размера
+func hasLargeIconCandidate(candidates []IconCandidate) bool {
+	for _, candidate := range candidates {
+		if candidate.Size >= PrimaryIconSize && candidate.Source != "mask-icon" {
+			return true
+		}
+	}
+	return false
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/base64"
 	"net/url"
+	"strings"
 	"sync"
 
 	"github.com/aerscs/theca-public/internal/model"
@@ -40,7 +41,8 @@ func (p *BookmarkHTMLParser) ParseHTML(ctx context.Context, base64Data string) (
 
 	// extract bookmarks (без фавиконок)
 	bookmarks := make([]model.Bookmark, 0)
-	p.traverseHTML(ctx, doc, &bookmarks)
+	var section importSection
+	p.traverseHTML(ctx, doc, &bookmarks, &section)
 
 	// параллельно получаем фавиконки
 	p.fetchFaviconsParallel(ctx, bookmarks)
@@ -48,18 +50,18 @@ func (p *BookmarkHTMLParser) ParseHTML(ctx context.Context, base64Data string) (
 	return bookmarks, nil
 }
 
-// getFavicon получает favicon по URL закладки в формате base64
-func (p *BookmarkHTMLParser) getFavicon(ctx context.Context, bookmarkURL string) string {
+// getIcons получает набор иконок по URL закладки в формате base64
+func (p *BookmarkHTMLParser) getIcons(ctx context.Context, bookmarkURL string) []model.Icon {
 	if bookmarkURL == "" {
-		return ""
+		return nil
 	}
 
-	favicon, err := FetchFaviconBase64(ctx, p.faviconCache, bookmarkURL)
+	icons, err := FetchIconSet(ctx, p.faviconCache, bookmarkURL)
 	if err != nil {
-		return ""
+		return nil
 	}
 
-	return favicon
+	return icons
 }
 
 // fetchFaviconsParallel параллельно получает фавиконки для всех закладок
@@ -80,26 +82,58 @@ func (p *BookmarkHTMLParser) fetchFaviconsParallel(ctx context.Context, bookmark
 			semaphore <- struct{}{}
 			defer func() { <-semaphore }()
 
-			favicon := p.getFavicon(ctx, bookmarks[idx].URL)
+			icons := p.getIcons(ctx, bookmarks[idx].URL)
 
-			bookmarks[idx].Favicon = favicon
+			bookmarks[idx].Icons = icons
+			bookmarks[idx].Favicon = PrimaryIcon(icons)
 		}(i)
 	}
 
 	wg.Wait()
 }
 
-// traverseHTML рекурсивно обходит HTML-дерево и извлекает закладки
-func (p *BookmarkHTMLParser) traverseHTML(ctx context.Context, n *html.Node, bookmarks *[]model.Bookmark) {
+// importSection состояние закладок, заданное заголовком раздела экспорта.
+// Pocket и Instapaper группируют ссылки под заголовками «Unread», «Archive», «Starred»
+type importSection struct {
+	state    string
+	favorite bool
+}
+
+// sectionFromHeading определяет состояние закладок по тексту заголовка раздела.
+// Для обычных папок возвращается пустое состояние
+func sectionFromHeading(heading string) importSection {
+	switch strings.ToLower(strings.TrimSpace(heading)) {
+	case "unread", "read later", "reading list":
+		return importSection{state: model.StateUnread}
+	case "archive", "read archive", "archived":
+		return importSection{state: model.StateArchived}
+	case "starred", "favorites", "favourites", "liked":
+		return importSection{favorite: true}
+	}
+	return importSection{}
+}
+
+// traverseHTML рекурсивно обходит HTML-дерево и извлекает закладки.
+// section хранит состояние последнего встреченного заголовка раздела
+func (p *BookmarkHTMLParser) traverseHTML(ctx context.Context, n *html.Node, bookmarks *[]model.Bookmark, section *importSection) {
+	if n.Type == html.ElementNode && (n.Data == "h1" || n.Data == "h2" || n.Data == "h3") {
+		*section = sectionFromHeading(textContent(n))
+	}
+
 	if n.Type == html.ElementNode && n.Data == "a" {
 		// this is a bookmark (tag <a>)
 		var bookmarkURL, title string
+		state := section.state
 
-		// extract URL
+		// extract URL and read state (Pinboard marks unread links with toread="1")
 		for _, attr := range n.Attr {
-			if attr.Key == "href" {
+			switch attr.Key {
+			case "href":
 				bookmarkURL = attr.Val
-				break
+			case "toread":
+				if attr.Val == "1" || strings.EqualFold(attr.Val, "yes") {
+					state = model.StateUnread
+				}
 			}
 		}
 
@@ -118,8 +152,10 @@ func (p *BookmarkHTMLParser) traverseHTML(ctx context.Context, n *html.Node, boo
 
 			// создаем закладку без фавиконки
 			*bookmarks = append(*bookmarks, model.Bookmark{
-				Title: title,
-				URL:   bookmarkURL,
+				Title:    title,
+				URL:      bookmarkURL,
+				State:    state,
+				Favorite: section.favorite,
 			})
 		}
 	}
@@ -127,7 +163,7 @@ func (p *BookmarkHTMLParser) traverseHTML(ctx context.Context, n *html.Node, boo
 nextNode:
 	// recursively traverse all child elements
 	for c := n.FirstChild; c != nil; c = c.NextSibling {
-		p.traverseHTML(ctx, c, bookmarks)
+		p.traverseHTML(ctx, c, bookmarks, section)
 	}
 }
 
@@ -0,0 +1,108 @@
+package parsers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aerscs/theca-public/internal/utils/httpclient"
+)
+
+// LinkCheck результат проверки доступности страницы
+type LinkCheck struct {
+	// Err ошибка сети или DNS, если ответ не получен
+	Err      error
+	FinalURL string
+	// PermanentURL адрес, до которого ведут только постоянные редиректы (301, 308).
+	// Пусто, если первый же редирект временный или редиректов не было
+	PermanentURL string
+	Redirects    []RedirectHop
+	StatusCode   int
+}
+
+// RetryLater сообщает, что состояние ссылки не определено и проверку нужно повторить позже:
+// хост ограничил частоту запросов (429) или запрос не отправлялся из-за сработавшего предохранителя
+func (c *LinkCheck) RetryLater() bool {
+	return c.StatusCode == http.StatusTooManyRequests || errors.Is(c.Err, httpclient.ErrCircuitOpen)
+}
+
+// RedirectHop один шаг цепочки редиректов
+type RedirectHop struct {
+	URL        string
+	Location   string
+	StatusCode int
+}
+
+// isPermanentRedirect проверяет, что редирект постоянный
+func isPermanentRedirect(statusCode int) bool {
+	return statusCode == http.StatusMovedPermanently || statusCode == http.StatusPermanentRedirect
+}
+
+// maxLinkCheckRedirects ограничивает длину цепочки редиректов при проверке ссылки
+const maxLinkCheckRedirects = 10
+
+// CheckLink requests the page and follows redirects to find its final URL.
+// HEAD is tried first; servers that don't support it are retried with GET
+func CheckLink(ctx context.Context, resourceURL string) *LinkCheck {
+	check := doLinkCheck(ctx, http.MethodHead, resourceURL)
+	switch check.StatusCode {
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented, http.StatusForbidden, http.StatusNotFound:
+		// Часть серверов отвечает на HEAD иначе, чем на GET
+		if getCheck := doLinkCheck(ctx, http.MethodGet, resourceURL); getCheck.Err == nil {
+			return getCheck
+		}
+	}
+
+	return check
+}
+
+func doLinkCheck(ctx context.Context, method, resourceURL string) *LinkCheck {
+	var redirects []RedirectHop
+	client := fetcher.HTTPClient(20*time.Second, func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxLinkCheckRedirects {
+			return fmt.Errorf("too many redirects")
+		}
+		// req.Response — ответ с редиректом, который привёл к этому запросу
+		if req.Response != nil {
+			redirects = append(redirects, RedirectHop{
+				URL:        via[len(via)-1].URL.String(),
+				Location:   req.URL.String(),
+				StatusCode: req.Response.StatusCode,
+			})
+		}
+		return nil
+	})
+
+	req, err := http.NewRequestWithContext(ctx, method, resourceURL, nil)
+	if err != nil {
+		return &LinkCheck{Err: fmt.Errorf("failed to create request: %w", err)}
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return &LinkCheck{Err: err, Redirects: redirects}
+	}
+	defer resp.Body.Close()
+
+	// Дочитываем немного тела, чтобы соединение вернулось в пул
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	check := &LinkCheck{
+		FinalURL:   resp.Request.URL.String(),
+		Redirects:  redirects,
+		StatusCode: resp.StatusCode,
+	}
+
+	for _, hop := range redirects {
+		if !isPermanentRedirect(hop.StatusCode) {
+			break
+		}
+		check.PermanentURL = hop.Location
+	}
+
+	return check
+}
@@ -0,0 +1,219 @@
+package parsers
+
+import (
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+const (
+	maxMetadataTitleLength       = 512
+	maxMetadataDescriptionLength = 2048
+)
+
+// PageMetadata описывает метаданные страницы, извлечённые из её HTML
+type PageMetadata struct {
+	Title        string
+	Description  string
+	Image        string
+	SiteName     string
+	Language     string
+	CanonicalURL string
+	Type         string
+}
+
+// ExtractMetadata извлекает заголовок, OpenGraph/Twitter-карточку,
+// язык и канонический URL из HTML-документа
+func ExtractMetadata(doc *html.Node, baseURL *url.URL) *PageMetadata {
+	var (
+		htmlTitle, ogTitle, twitterTitle            string
+		metaDescription, ogDescription, twitterDesc string
+		ogImage, twitterImage                       string
+		ogSiteName, applicationName                 string
+		htmlLang, contentLanguage, ogLocale         string
+		canonical, ogType                           string
+	)
+
+	var traverse func(*html.Node, bool)
+	traverse = func(n *html.Node, inSVG bool) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "html":
+				if htmlLang == "" {
+					htmlLang = getAttr(n, "lang")
+				}
+			case "svg":
+				inSVG = true
+			case "title":
+				// <title> внутри SVG не является заголовком страницы
+				if !inSVG && htmlTitle == "" && n.FirstChild != nil {
+					htmlTitle = textContent(n)
+				}
+			case "meta":
+				key := strings.ToLower(getAttr(n, "property"))
+				if key == "" {
+					key = strings.ToLower(getAttr(n, "name"))
+				}
+				content := strings.TrimSpace(getAttr(n, "content"))
+				if httpEquiv := strings.ToLower(getAttr(n, "http-equiv")); httpEquiv == "content-language" && contentLanguage == "" {
+					contentLanguage = content
+				}
+				if content == "" {
+					break
+				}
+
+				switch key {
+				case "og:title":
+					setOnce(&ogTitle, content)
+				case "twitter:title":
+					setOnce(&twitterTitle, content)
+				case "description":
+					setOnce(&metaDescription, content)
+				case "og:description":
+					setOnce(&ogDescription, content)
+				case "twitter:description":
+					setOnce(&twitterDesc, content)
+				case "og:image", "og:image:url", "og:image:secure_url":
+					setOnce(&ogImage, content)
+				case "twitter:image", "twitter:image:src":
+					setOnce(&twitterImage, content)
+				case "og:site_name":
+					setOnce(&ogSiteName, content)
+				case "application-name":
+					setOnce(&applicationName, content)
+				case "og:locale":
+					setOnce(&ogLocale, content)
+				case "og:type":
+					setOnce(&ogType, content)
+				}
+			case "link":
+				if hasRelToken(getAttr(n, "rel"), "canonical") && canonical == "" {
+					canonical = strings.TrimSpace(getAttr(n, "href"))
+				}
+			}
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			traverse(c, inSVG)
+		}
+	}
+
+	traverse(doc, false)
+
+	return &PageMetadata{
+		Title:        truncateRunes(normalizeSpace(firstNonEmpty(ogTitle, twitterTitle, htmlTitle)), maxMetadataTitleLength),
+		Description:  truncateRunes(normalizeSpace(firstNonEmpty(ogDescription, twitterDesc, metaDescription)), maxMetadataDescriptionLength),
+		Image:        resolveHTTPURL(baseURL, firstNonEmpty(ogImage, twitterImage)),
+		SiteName:     truncateRunes(normalizeSpace(firstNonEmpty(ogSiteName, applicationName)), maxMetadataTitleLength),
+		Language:     normalizeLanguage(firstNonEmpty(htmlLang, contentLanguage, ogLocale)),
+		CanonicalURL: resolveHTTPURL(baseURL, canonical),
+		Type:         strings.ToLower(strings.TrimSpace(ogType)),
+	}
+}
+
+// getAttr возвращает значение атрибута элемента
+func getAttr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+// hasRelToken проверяет, содержит ли атрибут rel указанное значение
+func hasRelToken(rel, token string) bool {
+	for _, part := range strings.Fields(strings.ToLower(rel)) {
+		if part == token {
+			return true
+		}
+	}
+	return false
+}
+
+// textContent собирает текст всех дочерних текстовых узлов
+func textContent(n *html.Node) string {
+	var sb strings.Builder
+	var collect func(*html.Node)
+	collect = func(node *html.Node) {
+		if node.Type == html.TextNode {
+			sb.WriteString(node.Data)
+		}
+		for c := node.FirstChild; c != nil; c = c.NextSibling {
+			collect(c)
+		}
+	}
+	collect(n)
+	return sb.String()
+}
+
+// resolveHTTPURL превращает ссылку в абсолютный http(s) URL
+func resolveHTTPURL(baseURL *url.URL, ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return ""
+	}
+
+	u, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	if baseURL != nil {
+		u = baseURL.ResolveReference(u)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ""
+	}
+
+	return u.String()
+}
+
+// normalizeLanguage приводит код языка к виду "en" или "en-US"
+func normalizeLanguage(lang string) string {
+	lang = strings.TrimSpace(lang)
+	if i := strings.IndexAny(lang, ", ;"); i >= 0 {
+		lang = lang[:i]
+	}
+	lang = strings.ReplaceAll(lang, "_", "-")
+	if len(lang) < 2 || len(lang) > 35 {
+		return ""
+	}
+
+	parts := strings.Split(lang, "-")
+	parts[0] = strings.ToLower(parts[0])
+	if len(parts) > 1 && len(parts[1]) == 2 {
+		parts[1] = strings.ToUpper(parts[1])
+	}
+	return strings.Join(parts, "-")
+}
+
+// normalizeSpace схлопывает пробельные символы
+func normalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// truncateRunes обрезает строку до указанного количества символов
+func truncateRunes(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	runes := []rune(s)
+	return strings.TrimSpace(string(runes[:limit]))
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func setOnce(dst *string, value string) {
+	if *dst == "" {
+		*dst = value
+	}
+}
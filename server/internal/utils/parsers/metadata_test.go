@@ -0,0 +1,187 @@
+package parsers
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func parseMetadata(t *testing.T, page, base string) *PageMetadata {
+	t.Helper()
+	doc, err := html.Parse(strings.NewReader(page))
+	if err != nil {
+		t.Fatalf("parse html: %v", err)
+	}
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		t.Fatalf("parse base URL: %v", err)
+	}
+	return ExtractMetadata(doc, baseURL)
+}
+
+func TestExtractMetadata(t *testing.T) {
+	const base = "https://example.com/blog/post"
+
+	tests := []struct {
+		name string
+		page string
+		want PageMetadata
+	}{
+		{
+			name: "open graph wins over other sources",
+			page: `<html lang="en"><head>
+				<title>HTML title</title>
+				<meta name="twitter:title" content="Twitter title">
+				<meta property="og:title" content="OG title">
+				<meta name="description" content="Meta description">
+				<meta property="og:description" content="OG description">
+				<meta property="og:image" content="/images/cover.png">
+				<meta name="twitter:image" content="https://cdn.example.com/twitter.png">
+				<meta property="og:site_name" content="Example">
+				<meta property="og:type" content=" Article ">
+				<link rel="canonical" href="/blog/post-canonical">
+			</head></html>`,
+			want: PageMetadata{
+				Title:        "OG title",
+				Description:  "OG description",
+				Image:        "https://example.com/images/cover.png",
+				SiteName:     "Example",
+				Language:     "en",
+				CanonicalURL: "https://example.com/blog/post-canonical",
+				Type:         "article",
+			},
+		},
+		{
+			name: "falls back to twitter card",
+			page: `<head>
+				<title>HTML title</title>
+				<meta name="twitter:title" content="Twitter title">
+				<meta name="twitter:description" content="Twitter description">
+				<meta name="twitter:image:src" content="//cdn.example.com/card.png">
+			</head>`,
+			want: PageMetadata{
+				Title:       "Twitter title",
+				Description: "Twitter description",
+				Image:       "https://cdn.example.com/card.png",
+			},
+		},
+		{
+			name: "falls back to html title and meta description",
+			page: `<head><title>
+				Plain   title
+			</title><meta name="Description" content="Plain description"><meta name="application-name" content="App"></head>`,
+			want: PageMetadata{
+				Title:       "Plain title",
+				Description: "Plain description",
+				SiteName:    "App",
+			},
+		},
+		{
+			name: "svg title is not the page title",
+			page: `<body><svg><title>Icon</title></svg><title>Real title</title></body>`,
+			want: PageMetadata{Title: "Real title"},
+		},
+		{
+			name: "first value wins",
+			page: `<head><meta property="og:title" content="First"><meta property="og:title" content="Second"></head>`,
+			want: PageMetadata{Title: "First"},
+		},
+		{
+			name: "empty content is skipped",
+			page: `<head><meta property="og:title" content="  "><meta property="og:title" content="Filled"></head>`,
+			want: PageMetadata{Title: "Filled"},
+		},
+		{
+			name: "non-http image and canonical are dropped",
+			page: `<head><meta property="og:image" content="javascript:alert(1)"><link rel="canonical" href="data:text/html,hi"></head>`,
+			want: PageMetadata{},
+		},
+		{
+			name: "canonical among several rel tokens",
+			page: `<head><link rel="alternate CANONICAL" href="https://example.com/canonical"></head>`,
+			want: PageMetadata{CanonicalURL: "https://example.com/canonical"},
+		},
+		{
+			name: "content-language header",
+			page: `<head><meta http-equiv="Content-Language" content="de-de, en"></head>`,
+			want: PageMetadata{Language: "de-DE"},
+		},
+		{
+			name: "og locale",
+			page: `<head><meta property="og:locale" content="ru_RU"></head>`,
+			want: PageMetadata{Language: "ru-RU"},
+		},
+		{
+			name: "html lang wins over og locale",
+			page: `<html lang="fr"><head><meta property="og:locale" content="en_US"></head></html>`,
+			want: PageMetadata{Language: "fr"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseMetadata(t, tt.page, base); *got != tt.want {
+				t.Fatalf("ExtractMetadata() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractMetadataTruncates(t *testing.T) {
+	long := strings.Repeat("я", maxMetadataTitleLength+10)
+	page := `<head><title>` + long + `</title><meta name="description" content="` + strings.Repeat("d ", maxMetadataDescriptionLength) + `"></head>`
+
+	got := parseMetadata(t, page, "https://example.com/")
+	if n := len([]rune(got.Title)); n != maxMetadataTitleLength {
+		t.Fatalf("title has %d runes, want %d", n, maxMetadataTitleLength)
+	}
+	if n := len([]rune(got.Description)); n > maxMetadataDescriptionLength {
+		t.Fatalf("description has %d runes, want at most %d", n, maxMetadataDescriptionLength)
+	}
+	if strings.HasSuffix(got.Description, " ") {
+		t.Fatal("truncated description ends with a space")
+	}
+}
+
+func TestNormalizeLanguage(t *testing.T) {
+	tests := map[string]string{
+		"en":                    "en",
+		"EN":                    "en",
+		"en-us":                 "en-US",
+		"en_US":                 "en-US",
+		" pt-BR ":               "pt-BR",
+		"de-DE, en;q=0.8":       "de-DE",
+		"zh-Hant-TW":            "zh-Hant-TW",
+		"sr-latn":               "sr-latn",
+		"e":                     "",
+		"":                      "",
+		strings.Repeat("a", 36): "",
+	}
+	for lang, want := range tests {
+		if got := normalizeLanguage(lang); got != want {
+			t.Errorf("normalizeLanguage(%q) = %q, want %q", lang, got, want)
+		}
+	}
+}
+
+func TestResolveHTTPURL(t *testing.T) {
+	base, _ := url.Parse("https://example.com/a/b")
+	tests := map[string]string{
+		"":                        "",
+		"   ":                     "",
+		"/img.png":                "https://example.com/img.png",
+		"img.png":                 "https://example.com/a/img.png",
+		"//cdn.example.com/x.png": "https://cdn.example.com/x.png",
+		"http://other.com/x":      "http://other.com/x",
+		"javascript:alert(1)":     "",
+		"ftp://example.com/file":  "",
+		"%zz":                     "",
+	}
+	for ref, want := range tests {
+		if got := resolveHTTPURL(base, ref); got != want {
+			t.Errorf("resolveHTTPURL(%q) = %q, want %q", ref, got, want)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package parsers
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/aerscs/theca-public/internal/model"
+)
+
+// iconOverrides переопределения иконок известных сервисов, загружаемые во время работы
+var iconOverrides = struct {
+	exact    map[string]string
+	wildcard map[string]string
+	mu       sync.RWMutex
+}{
+	exact:    make(map[string]string),
+	wildcard: make(map[string]string),
+}
+
+func init() {
+	SetIconOverrides(DefaultIconOverrides())
+}
+
+// DefaultIconOverrides возвращает встроенный список переопределений,
+// которым таблица заполняется при первом запуске
+func DefaultIconOverrides() []model.IconOverride {
+	return []model.IconOverride{
+		{Pattern: "gmail.com", IconURL: "https://ssl.gstatic.com/ui/v1/icons/mail/rfr/gmail.ico"},
+		{Pattern: "mail.google.com", IconURL: "https://ssl.gstatic.com/ui/v1/icons/mail/rfr/gmail.ico"},
+		{Pattern: "google.com", IconURL: "https://www.google.com/favicon.ico"},
+		{Pattern: "youtube.com", IconURL: "https://www.youtube.com/favicon.ico"},
+		{Pattern: "github.com", IconURL: "https://github.com/favicon.ico"},
+		{Pattern: "stackoverflow.com", IconURL: "https://cdn.sstatic.net/Sites/stackoverflow/Img/favicon.ico"},
+		{Pattern: "twitter.com", IconURL: "https://abs.twimg.com/favicons/twitter.ico"},
+		{Pattern: "facebook.com", IconURL: "https://static.xx.fbcdn.net/rsrc.php/yV/r/hzMapiNYYpW.ico"},
+		{Pattern: "linkedin.com", IconURL: "https://static.licdn.com/sc/h/1bt1uwq5akv756knzdj4l6cdc"},
+	}
+}
+
+// SetIconOverrides заменяет текущий набор переопределений
+func SetIconOverrides(overrides []model.IconOverride) {
+	exact := make(map[string]string, len(overrides))
+	wildcard := make(map[string]string)
+
+	for _, override := range overrides {
+		pattern, ok := NormalizeIconPattern(override.Pattern)
+		if !ok {
+			continue
+		}
+		if suffix, isWildcard := strings.CutPrefix(pattern, "*."); isWildcard {
+			wildcard[suffix] = override.IconURL
+		} else {
+			exact[pattern] = override.IconURL
+		}
+	}
+
+	iconOverrides.mu.Lock()
+	iconOverrides.exact = exact
+	iconOverrides.wildcard = wildcard
+	iconOverrides.mu.Unlock()
+}
+
+// NormalizeIconPattern приводит шаблон домена к нижнему регистру без "www."
+// и проверяет его: допускается только маска "*." в начале
+func NormalizeIconPattern(pattern string) (string, bool) {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	pattern = strings.TrimSuffix(pattern, ".")
+
+	domain, isWildcard := strings.CutPrefix(pattern, "*.")
+	if !isWildcard {
+		domain = strings.TrimPrefix(domain, "www.")
+	}
+
+	if domain == "" || len(domain) > 253 || strings.ContainsAny(domain, "*/:?#@ ") ||
+		strings.HasPrefix(domain, ".") || strings.Contains(domain, "..") {
+		return "", false
+	}
+	if isWildcard && !strings.Contains(domain, ".") {
+		// "*.com" задел бы целую доменную зону
+		return "", false
+	}
+
+	if isWildcard {
+		return "*." + domain, true
+	}
+	return domain, true
+}
+
+// MatchIconPattern проверяет, подходит ли хост под нормализованный шаблон.
+// "*.google.com" подходит для поддоменов и самого google.com
+func MatchIconPattern(pattern, host string) bool {
+	host = normalizeHost(host)
+	if suffix, isWildcard := strings.CutPrefix(pattern, "*."); isWildcard {
+		return host == suffix || strings.HasSuffix(host, "."+suffix)
+	}
+	return host == pattern
+}
+
+// getKnownServiceFavicon returns direct favicon URL for known services.
+// Exact domains take precedence over wildcards, longer wildcards over shorter ones
+func getKnownServiceFavicon(resourceURL string) string {
+	u, err := url.Parse(resourceURL)
+	if err != nil {
+		return ""
+	}
+
+	domain := normalizeHost(u.Hostname())
+
+	iconOverrides.mu.RLock()
+	defer iconOverrides.mu.RUnlock()
+
+	if faviconURL, exists := iconOverrides.exact[domain]; exists {
+		return faviconURL
+	}
+
+	// Перебираем суффиксы от самого длинного: a.b.example.com -> b.example.com -> example.com
+	for suffix := domain; suffix != ""; {
+		if faviconURL, exists := iconOverrides.wildcard[suffix]; exists {
+			return faviconURL
+		}
+		_, rest, found := strings.Cut(suffix, ".")
+		if !found {
+			break
+		}
+		suffix = rest
+	}
+
+	return ""
+}
+
+func normalizeHost(host string) string {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	return strings.TrimPrefix(host, "www.")
+}
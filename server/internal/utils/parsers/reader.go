@@ -0,0 +1,607 @@
+package parsers
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	// maxArticleTextLength ограничивает объём сохраняемого текста статьи в символах
+	maxArticleTextLength = 200_000
+	// minParagraphLength абзацы короче этого не учитываются при поиске основного блока
+	minParagraphLength = 25
+	maxBylineLength    = 100
+)
+
+var (
+	// Блоки, которые почти наверняка не относятся к тексту статьи (по мотивам Readability)
+	unlikelyCandidateRegex = regexp.MustCompile(`(?i)-ad-|ai2html|banner|breadcrumb|combx|comment|community|cookie|cover-wrap|disqus|extra|footer|gdpr|header|legends|menu|newsletter|related|remark|replies|rss|share|shoutbox|sidebar|skyscraper|social|sponsor|subscribe|supplemental|ad-break|agegate|pagination|pager|popup`)
+	maybeCandidateRegex    = regexp.MustCompile(`(?i)and|article|body|column|content|main|shadow`)
+	positiveClassRegex     = regexp.MustCompile(`(?i)article|body|content|entry|hentry|h-entry|main|page|post|text|blog|story`)
+	negativeClassRegex     = regexp.MustCompile(`(?i)-ad-|hidden|banner|combx|comment|com-|contact|foot|footnote|gdpr|masthead|media|meta|outbrain|promo|related|scroll|share|shoutbox|sidebar|skyscraper|sponsor|shopping|tags|tool|widget`)
+	bylinePrefixRegex      = regexp.MustCompile(`(?i)^(by|автор|от)[:\s]+`)
+)
+
+// skippedReaderElements элементы, текст которых никогда не попадает в статью
+var skippedReaderElements = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Noscript: true,
+	atom.Nav:      true,
+	atom.Header:   true,
+	atom.Footer:   true,
+	atom.Aside:    true,
+	atom.Form:     true,
+	atom.Iframe:   true,
+	atom.Svg:      true,
+	atom.Button:   true,
+	atom.Select:   true,
+	atom.Textarea: true,
+	atom.Input:    true,
+	atom.Template: true,
+	atom.Object:   true,
+	atom.Embed:    true,
+	atom.Canvas:   true,
+	atom.Dialog:   true,
+	atom.Menu:     true,
+}
+
+// readerBlockElements элементы, текст которых становится отдельным абзацем
+var readerBlockElements = map[atom.Atom]bool{
+	atom.P:          true,
+	atom.H1:         true,
+	atom.H2:         true,
+	atom.H3:         true,
+	atom.H4:         true,
+	atom.H5:         true,
+	atom.H6:         true,
+	atom.Li:         true,
+	atom.Blockquote: true,
+	atom.Pre:        true,
+	atom.Dd:         true,
+	atom.Dt:         true,
+	atom.Figcaption: true,
+	atom.Td:         true,
+	atom.Th:         true,
+	atom.Caption:    true,
+}
+
+// readerContainerElements элементы, внутри которых абзацы ищутся рекурсивно
+var readerContainerElements = map[atom.Atom]bool{
+	atom.Div:     true,
+	atom.Section: true,
+	atom.Article: true,
+	atom.Main:    true,
+	atom.Body:    true,
+	atom.Ul:      true,
+	atom.Ol:      true,
+	atom.Dl:      true,
+	atom.Table:   true,
+	atom.Thead:   true,
+	atom.Tbody:   true,
+	atom.Tfoot:   true,
+	atom.Tr:      true,
+	atom.Figure:  true,
+	atom.Details: true,
+	atom.Center:  true,
+	atom.Hgroup:  true,
+}
+
+// Article основной текст страницы для режима чтения
+type Article struct {
+	PublishedAt *time.Time
+	Author      string
+	// Text абзацы статьи, разделённые пустой строкой
+	Text      string
+	WordCount int
+}
+
+// ExtractArticle finds the main article of the page and returns its text along with
+// the author and publish date. Returns nil if the document has no body
+func ExtractArticle(doc *html.Node) *Article {
+	body := findElement(doc, atom.Body)
+	if body == nil {
+		return nil
+	}
+
+	author, published := extractArticleMeta(doc)
+
+	var blocks []string
+	for _, root := range findArticleRoots(body) {
+		collectReaderBlocks(root, &blocks)
+	}
+	text := truncateRunes(strings.Join(dedupeBlocks(blocks), "\n\n"), maxArticleTextLength)
+
+	return &Article{
+		Author:      author,
+		PublishedAt: published,
+		Text:        text,
+		WordCount:   CountWords(text),
+	}
+}
+
+// CountWords считает слова в тексте. Иероглифы и кана считаются по одному слову на символ,
+// так как в этих языках слова не разделяются пробелами
+func CountWords(text string) int {
+	count := 0
+	inWord := false
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana):
+			count++
+			inWord = false
+		case unicode.IsSpace(r):
+			inWord = false
+		case !inWord && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			count++
+			inWord = true
+		}
+	}
+	return count
+}
+
+// findArticleRoots оценивает блоки страницы по количеству и длине абзацев и возвращает
+// лучший из них вместе с соседними блоками, которые тоже похожи на часть статьи
+func findArticleRoots(body *html.Node) []*html.Node {
+	scores := make(map[*html.Node]float64)
+	addScore := func(n *html.Node, score float64) {
+		if _, ok := scores[n]; !ok {
+			scores[n] = initialReaderScore(n)
+		}
+		scores[n] += score
+	}
+
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode || isUnlikelyReaderNode(c) {
+				continue
+			}
+
+			switch c.DataAtom {
+			case atom.P, atom.Pre, atom.Td, atom.Blockquote:
+				text := normalizeSpace(readerText(c))
+				length := utf8.RuneCountInString(text)
+				if length < minParagraphLength {
+					break
+				}
+				commas := strings.Count(text, ",") + strings.Count(text, "，") + strings.Count(text, "、")
+				score := 1 + float64(commas) + min(float64(length)/100, 3)
+				if parent := c.Parent; parent != nil {
+					addScore(parent, score)
+					if grandparent := parent.Parent; grandparent != nil {
+						addScore(grandparent, score/2)
+					}
+				}
+			}
+
+			walk(c)
+		}
+	}
+	walk(body)
+
+	var top *html.Node
+	var topScore float64
+	for n, score := range scores {
+		score *= 1 - linkDensity(n)
+		scores[n] = score
+		if top == nil || score > topScore {
+			top, topScore = n, score
+		}
+	}
+	if top == nil || top.Parent == nil {
+		return []*html.Node{body}
+	}
+
+	// Статья может быть разбита на несколько соседних блоков
+	threshold := max(10, topScore*0.2)
+	var roots []*html.Node
+	for c := top.Parent.FirstChild; c != nil; c = c.NextSibling {
+		if c == top {
+			roots = append(roots, c)
+			continue
+		}
+		if score, ok := scores[c]; ok && score >= threshold {
+			roots = append(roots, c)
+		}
+	}
+	return roots
+}
+
+// initialReaderScore начальная оценка блока по тегу и классам
+func initialReaderScore(n *html.Node) float64 {
+	score := classWeight(n)
+	switch n.DataAtom {
+	case atom.Article:
+		score += 10
+	case atom.Div, atom.Main:
+		score += 5
+	case atom.Pre, atom.Td, atom.Blockquote, atom.Section:
+		score += 3
+	case atom.Ol, atom.Ul, atom.Dl, atom.Dd, atom.Dt, atom.Li, atom.Form, atom.Address:
+		score -= 3
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Th:
+		score -= 5
+	}
+	return score
+}
+
+// classWeight повышает оценку блоков с «контентными» классами и понижает служебные
+func classWeight(n *html.Node) float64 {
+	var weight float64
+	for _, value := range []string{getAttr(n, "class"), getAttr(n, "id")} {
+		if value == "" {
+			continue
+		}
+		if negativeClassRegex.MatchString(value) {
+			weight -= 25
+		}
+		if positiveClassRegex.MatchString(value) {
+			weight += 25
+		}
+	}
+	return weight
+}
+
+// isUnlikelyReaderNode проверяет, что элемент скрыт или является навигацией, рекламой и т.п.
+func isUnlikelyReaderNode(n *html.Node) bool {
+	if skippedReaderElements[n.DataAtom] {
+		return true
+	}
+
+	for _, attr := range n.Attr {
+		switch attr.Key {
+		case "hidden":
+			return true
+		case "aria-hidden":
+			if attr.Val == "true" {
+				return true
+			}
+		case "style":
+			style := strings.ReplaceAll(strings.ToLower(attr.Val), " ", "")
+			if strings.Contains(style, "display:none") || strings.Contains(style, "visibility:hidden") {
+				return true
+			}
+		case "role":
+			switch strings.ToLower(attr.Val) {
+			case "navigation", "complementary", "dialog", "alertdialog", "menu", "menubar", "banner", "contentinfo":
+				return true
+			}
+		}
+	}
+
+	switch n.DataAtom {
+	case atom.Body, atom.A, atom.Article, atom.Main:
+		return false
+	}
+
+	match := getAttr(n, "class") + " " + getAttr(n, "id")
+	return unlikelyCandidateRegex.MatchString(match) && !maybeCandidateRegex.MatchString(match)
+}
+
+// collectReaderBlocks собирает абзацы текста из блока, пропуская служебные элементы
+func collectReaderBlocks(n *html.Node, blocks *[]string) {
+	var inline strings.Builder
+	flush := func() {
+		if text := normalizeSpace(inline.String()); text != "" {
+			*blocks = append(*blocks, text)
+		}
+		inline.Reset()
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		switch c.Type {
+		case html.TextNode:
+			inline.WriteString(c.Data)
+		case html.ElementNode:
+			if isUnlikelyReaderNode(c) {
+				continue
+			}
+
+			switch {
+			case c.DataAtom == atom.Br || c.DataAtom == atom.Hr:
+				flush()
+			case readerBlockElements[c.DataAtom]:
+				flush()
+				if text := readerBlockText(c); text != "" {
+					*blocks = append(*blocks, text)
+				}
+			case readerContainerElements[c.DataAtom]:
+				flush()
+				collectReaderBlocks(c, blocks)
+			default:
+				inline.WriteString(readerText(c))
+			}
+		}
+	}
+	flush()
+}
+
+// readerBlockText возвращает текст абзаца; списки ссылок (меню, теги) отбрасываются
+func readerBlockText(n *html.Node) string {
+	if n.DataAtom == atom.Pre {
+		return strings.TrimSpace(readerText(n))
+	}
+
+	text := normalizeSpace(readerText(n))
+	if text == "" {
+		return ""
+	}
+	if utf8.RuneCountInString(text) < 80 && linkDensity(n) > 0.5 {
+		return ""
+	}
+	return text
+}
+
+// readerText собирает текст элемента без скрытых и служебных вложенных элементов
+func readerText(n *html.Node) string {
+	var sb strings.Builder
+	var collect func(*html.Node)
+	collect = func(node *html.Node) {
+		for c := node.FirstChild; c != nil; c = c.NextSibling {
+			switch c.Type {
+			case html.TextNode:
+				sb.WriteString(c.Data)
+			case html.ElementNode:
+				if isUnlikelyReaderNode(c) {
+					continue
+				}
+				if c.DataAtom == atom.Br {
+					sb.WriteString("\n")
+					continue
+				}
+				collect(c)
+			}
+		}
+	}
+	collect(n)
+	return sb.String()
+}
+
+// linkDensity доля текста блока, находящаяся внутри ссылок
+func linkDensity(n *html.Node) float64 {
+	total := utf8.RuneCountInString(normalizeSpace(textContent(n)))
+	if total == 0 {
+		return 0
+	}
+
+	var links int
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		for c := node.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.ElementNode && c.DataAtom == atom.A {
+				links += utf8.RuneCountInString(normalizeSpace(textContent(c)))
+				continue
+			}
+			walk(c)
+		}
+	}
+	walk(n)
+
+	return float64(links) / float64(total)
+}
+
+// dedupeBlocks убирает подряд идущие повторы абзацев
+func dedupeBlocks(blocks []string) []string {
+	result := blocks[:0]
+	for i, block := range blocks {
+		if i > 0 && block == blocks[i-1] {
+			continue
+		}
+		result = append(result, block)
+	}
+	return result
+}
+
+// extractArticleMeta ищет автора и дату публикации в мета-тегах, микроразметке и JSON-LD
+func extractArticleMeta(doc *html.Node) (string, *time.Time) {
+	var metaAuthor, itemAuthor, ldAuthor, relAuthor, bylineAuthor string
+	var metaDate, itemDate, ldDate, timeDate string
+
+	var walk func(*html.Node, bool)
+	walk = func(n *html.Node, inArticle bool) {
+		if n.Type == html.ElementNode {
+			switch n.DataAtom {
+			case atom.Article:
+				inArticle = true
+			case atom.Meta:
+				key := strings.ToLower(firstNonEmpty(getAttr(n, "property"), getAttr(n, "name")))
+				content := strings.TrimSpace(getAttr(n, "content"))
+				switch key {
+				case "author", "article:author", "byl", "sailthru.author", "parsely-author", "dc.creator":
+					// article:author часто содержит ссылку на профиль, а не имя
+					if !strings.HasPrefix(content, "http") {
+						setOnce(&metaAuthor, content)
+					}
+				case "article:published_time", "og:article:published_time", "datepublished", "pubdate",
+					"publishdate", "date", "dc.date", "dc.date.issued", "sailthru.date", "parsely-pub-date":
+					setOnce(&metaDate, content)
+				}
+			case atom.Script:
+				if strings.EqualFold(getAttr(n, "type"), "application/ld+json") && n.FirstChild != nil {
+					author, date := ldJSONArticleMeta(n.FirstChild.Data)
+					setOnce(&ldAuthor, author)
+					setOnce(&ldDate, date)
+				}
+				return
+			case atom.Time:
+				if inArticle || hasAttr(n, "pubdate") {
+					setOnce(&timeDate, getAttr(n, "datetime"))
+				}
+			case atom.A:
+				if hasRelToken(getAttr(n, "rel"), "author") {
+					setOnce(&relAuthor, normalizeSpace(textContent(n)))
+				}
+			}
+
+			switch getAttr(n, "itemprop") {
+			case "author":
+				setOnce(&itemAuthor, firstNonEmpty(getAttr(n, "content"), itemPropName(n)))
+			case "datePublished":
+				setOnce(&itemDate, firstNonEmpty(getAttr(n, "content"), getAttr(n, "datetime")))
+			}
+
+			if bylineAuthor == "" && strings.Contains(strings.ToLower(getAttr(n, "class")), "byline") {
+				bylineAuthor = normalizeSpace(textContent(n))
+			}
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c, inArticle)
+		}
+	}
+	walk(doc, false)
+
+	var author string
+	for _, candidate := range []string{ldAuthor, metaAuthor, itemAuthor, relAuthor, bylineAuthor} {
+		candidate = strings.TrimSpace(bylinePrefixRegex.ReplaceAllString(normalizeSpace(candidate), ""))
+		if candidate != "" && utf8.RuneCountInString(candidate) <= maxBylineLength {
+			author = candidate
+			break
+		}
+	}
+
+	for _, candidate := range []string{ldDate, metaDate, itemDate, timeDate} {
+		if published := parseArticleDate(candidate); published != nil {
+			return author, published
+		}
+	}
+	return author, nil
+}
+
+// itemPropName возвращает имя автора из вложенного itemprop="name" или текст элемента
+func itemPropName(n *html.Node) string {
+	if name := findByItemProp(n, "name"); name != nil {
+		return firstNonEmpty(getAttr(name, "content"), textContent(name))
+	}
+	return textContent(n)
+}
+
+func findByItemProp(n *html.Node, prop string) *html.Node {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && getAttr(c, "itemprop") == prop {
+			return c
+		}
+		if found := findByItemProp(c, prop); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// ldJSONArticleMeta извлекает автора и дату публикации из JSON-LD, включая @graph
+func ldJSONArticleMeta(data string) (string, string) {
+	var root any
+	if err := json.Unmarshal([]byte(data), &root); err != nil {
+		return "", ""
+	}
+
+	var author, date string
+	var walk func(any)
+	walk = func(v any) {
+		switch value := v.(type) {
+		case []any:
+			for _, item := range value {
+				walk(item)
+			}
+		case map[string]any:
+			if published, ok := value["datePublished"].(string); ok {
+				setOnce(&date, published)
+			}
+			if a, ok := value["author"]; ok {
+				setOnce(&author, ldJSONName(a))
+			}
+			if graph, ok := value["@graph"]; ok {
+				walk(graph)
+			}
+		}
+	}
+	walk(root)
+
+	return author, date
+}
+
+// ldJSONName возвращает имя из строки, объекта Person или списка авторов
+func ldJSONName(v any) string {
+	switch value := v.(type) {
+	case string:
+		return value
+	case map[string]any:
+		name, _ := value["name"].(string)
+		return name
+	case []any:
+		names := make([]string, 0, len(value))
+		for _, item := range value {
+			if name := ldJSONName(item); name != "" {
+				names = append(names, name)
+			}
+		}
+		return strings.Join(names, ", ")
+	}
+	return ""
+}
+
+// articleDateLayouts форматы дат, встречающиеся в мета-тегах
+var articleDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"2006/01/02",
+	time.RFC1123Z,
+	time.RFC1123,
+	"January 2, 2006",
+	"Jan 2, 2006",
+}
+
+// parseArticleDate разбирает дату публикации; явно неправдоподобные даты отбрасываются
+func parseArticleDate(value string) *time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+
+	for _, layout := range articleDateLayouts {
+		t, err := time.Parse(layout, value)
+		if err != nil {
+			continue
+		}
+		if t.Year() < 1990 || t.After(time.Now().Add(24*time.Hour)) {
+			return nil
+		}
+		t = t.UTC()
+		return &t
+	}
+	return nil
+}
+
+// findElement возвращает первый элемент с указанным тегом
+func findElement(n *html.Node, a atom.Atom) *html.Node {
+	if n.Type == html.ElementNode && n.DataAtom == a {
+		return n
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := findElement(c, a); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func hasAttr(n *html.Node, key string) bool {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return true
+		}
+	}
+	return false
+}
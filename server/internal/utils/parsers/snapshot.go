@@ -0,0 +1,468 @@
+package parsers
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	// maxSnapshotResourceSize ограничивает размер одного встраиваемого ресурса (стиля, изображения, шрифта)
+	maxSnapshotResourceSize = 2 << 20
+	// maxSnapshotResources ограничивает количество загружаемых ресурсов страницы
+	maxSnapshotResources = 150
+	// maxCSSImportDepth глубина разворачивания @import
+	maxCSSImportDepth = 2
+
+	// Кодировку в заголовке не указываем: она объявлена в самом документе
+	snapshotContentType = "text/html"
+)
+
+// ErrSnapshotTooLarge возвращается, если сама страница не помещается в лимит снимка
+var ErrSnapshotTooLarge = errors.New("page is too large for a snapshot")
+
+var (
+	cssURLRegex    = regexp.MustCompile(`url\(\s*(?:"([^"]*)"|'([^']*)'|([^)"'\s]*))\s*\)`)
+	cssImportRegex = regexp.MustCompile(`@import\s+(?:url\(\s*)?["']?([^"')\s;]+)["']?\s*\)?[^;]*;`)
+)
+
+// droppedSnapshotElements элементы, которые удаляются из снимка: скрипты и встроенные окна
+var droppedSnapshotElements = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Noscript: true,
+	atom.Iframe:   true,
+	atom.Frame:    true,
+	atom.Frameset: true,
+	atom.Object:   true,
+	atom.Embed:    true,
+	atom.Applet:   true,
+	atom.Base:     true,
+	atom.Template: true,
+	atom.Video:    true,
+	atom.Audio:    true,
+}
+
+// Snapshot самодостаточная HTML-копия страницы: стили и изображения встроены как data URI
+type Snapshot struct {
+	ContentType string
+	Data        []byte
+	// Resources количество встроенных ресурсов
+	Resources int
+	// Truncated часть ресурсов не поместилась в лимит и была пропущена
+	Truncated bool
+}
+
+// snapshotBuilder собирает снимок в пределах общего бюджета по размеру
+type snapshotBuilder struct {
+	// cache загруженные ресурсы по URL, чтобы не скачивать одно и то же дважды
+	cache     map[string]string
+	remaining int64
+	resources int
+	truncated bool
+}
+
+// TakeSnapshot downloads the page and produces a self-contained HTML copy of it.
+// Scripts, frames and event handlers are removed, stylesheets and images are inlined.
+// The result never exceeds maxSize bytes: resources that do not fit are skipped
+func TakeSnapshot(ctx context.Context, resourceURL string, maxSize int64) (*Snapshot, error) {
+	page, err := fetchPage(ctx, resourceURL)
+	if err != nil {
+		return nil, err
+	}
+	if page.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("received non-200 response code: %d", page.StatusCode)
+	}
+	if !isHTMLContentType(page.ContentType) {
+		return nil, fmt.Errorf("unsupported content type: %s", page.ContentType)
+	}
+	if int64(len(page.Body)) > maxSize {
+		return nil, ErrSnapshotTooLarge
+	}
+
+	doc, err := html.Parse(bytes.NewReader(page.Body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse page: %w", err)
+	}
+
+	b := &snapshotBuilder{
+		cache:     make(map[string]string),
+		remaining: maxSize - int64(len(page.Body)),
+	}
+	b.process(ctx, doc, page.BaseURL)
+	addSnapshotHeader(doc, page.BaseURL.String())
+
+	var buf bytes.Buffer
+	if err := html.Render(&buf, doc); err != nil {
+		return nil, fmt.Errorf("failed to render snapshot: %w", err)
+	}
+	// Бюджет считается по исходной странице, а разметка после обработки может немного вырасти
+	if int64(buf.Len()) > maxSize {
+		return nil, ErrSnapshotTooLarge
+	}
+
+	return &Snapshot{
+		ContentType: snapshotContentType,
+		Data:        buf.Bytes(),
+		Resources:   b.resources,
+		Truncated:   b.truncated,
+	}, nil
+}
+
+// process обходит дерево документа, удаляя активное содержимое и встраивая ресурсы
+func (b *snapshotBuilder) process(ctx context.Context, n *html.Node, baseURL *url.URL) {
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+
+		if c.Type == html.ElementNode {
+			if b.processElement(ctx, c, baseURL) {
+				n.RemoveChild(c)
+				c = next
+				continue
+			}
+		}
+		if c.Type == html.CommentNode {
+			n.RemoveChild(c)
+			c = next
+			continue
+		}
+
+		b.process(ctx, c, baseURL)
+		c = next
+	}
+}
+
+// processElement обрабатывает один элемент и возвращает true, если его нужно удалить
+func (b *snapshotBuilder) processElement(ctx context.Context, n *html.Node, baseURL *url.URL) bool {
+	if droppedSnapshotElements[n.DataAtom] {
+		return true
+	}
+
+	sanitizeAttributes(n)
+
+	switch n.DataAtom {
+	case atom.Meta:
+		// Автоматическое перенаправление в снимке не нужно
+		return strings.EqualFold(getAttr(n, "http-equiv"), "refresh")
+	case atom.Link:
+		return b.processLink(ctx, n, baseURL)
+	case atom.Style:
+		if n.FirstChild != nil && n.FirstChild.Type == html.TextNode {
+			n.FirstChild.Data = b.inlineCSS(ctx, n.FirstChild.Data, baseURL, 0)
+		}
+	case atom.Img:
+		b.processImage(ctx, n, baseURL)
+	case atom.Source:
+		// Варианты изображений из <picture> не встраиваем, остаётся запасной <img>
+		return true
+	case atom.A, atom.Area:
+		if href := getAttr(n, "href"); href != "" && !strings.HasPrefix(href, "#") {
+			setAttr(n, "href", resolveHTTPURL(baseURL, href))
+		}
+	case atom.Form:
+		removeAttr(n, "action")
+	}
+
+	if style := getAttr(n, "style"); style != "" {
+		setAttr(n, "style", b.inlineCSSURLs(ctx, style, baseURL))
+	}
+
+	return false
+}
+
+// processLink заменяет подключённую таблицу стилей встроенным <style>,
+// остальные <link> (preload, manifest и т.п.) удаляет
+func (b *snapshotBuilder) processLink(ctx context.Context, n *html.Node, baseURL *url.URL) bool {
+	rel := getAttr(n, "rel")
+	if !hasRelToken(rel, "stylesheet") || hasRelToken(rel, "alternate") {
+		return true
+	}
+
+	cssURL := resolveHTTPURL(baseURL, getAttr(n, "href"))
+	if cssURL == "" {
+		return true
+	}
+
+	css, ok := b.fetchText(ctx, cssURL)
+	if !ok {
+		return true
+	}
+	parsedCSSURL, err := url.Parse(cssURL)
+	if err != nil {
+		return true
+	}
+
+	style := &html.Node{Type: html.ElementNode, DataAtom: atom.Style, Data: "style"}
+	if media := getAttr(n, "media"); media != "" {
+		style.Attr = append(style.Attr, html.Attribute{Key: "media", Val: media})
+	}
+	// Содержимое <style> выводится как есть, поэтому не даём таблице стилей закрыть тег
+	css = strings.ReplaceAll(b.inlineCSS(ctx, css, parsedCSSURL, 0), "</", `<\/`)
+	style.AppendChild(&html.Node{Type: html.TextNode, Data: css})
+	n.Parent.InsertBefore(style, n)
+
+	return true
+}
+
+// processImage встраивает изображение; для ленивой загрузки берётся адрес из data-src
+func (b *snapshotBuilder) processImage(ctx context.Context, n *html.Node, baseURL *url.URL) {
+	src := getAttr(n, "src")
+	if src == "" || strings.HasPrefix(src, "data:") {
+		if lazy := firstNonEmpty(getAttr(n, "data-src"), getAttr(n, "data-lazy-src"), getAttr(n, "data-original")); lazy != "" {
+			src = lazy
+		}
+	}
+
+	for _, key := range []string{"srcset", "sizes", "loading", "data-src", "data-lazy-src", "data-original", "data-srcset"} {
+		removeAttr(n, key)
+	}
+
+	if strings.HasPrefix(src, "data:image/") {
+		return
+	}
+
+	dataURI := ""
+	if imageURL := resolveHTTPURL(baseURL, src); imageURL != "" {
+		dataURI = b.fetchDataURI(ctx, imageURL)
+	}
+	setAttr(n, "src", dataURI)
+}
+
+// inlineCSS разворачивает @import и встраивает ресурсы, на которые ссылается таблица стилей
+func (b *snapshotBuilder) inlineCSS(ctx context.Context, css string, baseURL *url.URL, depth int) string {
+	css = cssImportRegex.ReplaceAllStringFunc(css, func(rule string) string {
+		match := cssImportRegex.FindStringSubmatch(rule)
+		importURL := resolveHTTPURL(baseURL, match[1])
+		if importURL == "" || depth >= maxCSSImportDepth {
+			return ""
+		}
+
+		imported, ok := b.fetchText(ctx, importURL)
+		if !ok {
+			return ""
+		}
+		parsedImportURL, err := url.Parse(importURL)
+		if err != nil {
+			return ""
+		}
+		// Медиа-условия @import теряются: правила импортируются безусловно
+		return b.inlineCSS(ctx, imported, parsedImportURL, depth+1)
+	})
+
+	return b.inlineCSSURLs(ctx, css, baseURL)
+}
+
+// inlineCSSURLs заменяет url(...) на data URI; ресурсы, которые не удалось встроить, убираются
+func (b *snapshotBuilder) inlineCSSURLs(ctx context.Context, css string, baseURL *url.URL) string {
+	return cssURLRegex.ReplaceAllStringFunc(css, func(ref string) string {
+		match := cssURLRegex.FindStringSubmatch(ref)
+		target := strings.TrimSpace(firstNonEmpty(match[1], match[2], match[3]))
+
+		if strings.HasPrefix(target, "data:") || strings.HasPrefix(target, "#") {
+			return ref
+		}
+
+		resourceURL := resolveHTTPURL(baseURL, target)
+		if resourceURL == "" {
+			return "url()"
+		}
+
+		dataURI := b.fetchDataURI(ctx, resourceURL)
+		if dataURI == "" {
+			return "url()"
+		}
+		return `url("` + dataURI + `")`
+	})
+}
+
+// fetchText загружает текстовый ресурс (таблицу стилей) с учётом бюджета снимка
+func (b *snapshotBuilder) fetchText(ctx context.Context, resourceURL string) (string, bool) {
+	data, _, ok := b.download(ctx, resourceURL)
+	if !ok {
+		return "", false
+	}
+	return string(data), true
+}
+
+// fetchDataURI загружает изображение или шрифт и возвращает его в виде data URI
+func (b *snapshotBuilder) fetchDataURI(ctx context.Context, resourceURL string) string {
+	if dataURI, ok := b.cache[resourceURL]; ok {
+		if dataURI == "" || !b.charge(int64(len(dataURI))) {
+			return ""
+		}
+		return dataURI
+	}
+
+	data, contentType, ok := b.download(ctx, resourceURL)
+	if !ok {
+		b.cache[resourceURL] = ""
+		return ""
+	}
+
+	mimeType := snapshotMimeType(data, contentType)
+	if mimeType == "" {
+		b.cache[resourceURL] = ""
+		return ""
+	}
+
+	dataURI := "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data)
+	// Загруженные байты уже учтены, доплачиваем за увеличение размера при кодировании
+	if !b.charge(int64(len(dataURI) - len(data))) {
+		return ""
+	}
+	b.cache[resourceURL] = dataURI
+
+	return dataURI
+}
+
+// download загружает ресурс, если он помещается в оставшийся бюджет
+func (b *snapshotBuilder) download(ctx context.Context, resourceURL string) ([]byte, string, bool) {
+	if b.resources >= maxSnapshotResources || b.remaining <= 0 {
+		b.truncated = true
+		return nil, "", false
+	}
+	if ctx.Err() != nil {
+		return nil, "", false
+	}
+
+	limit := min(int64(maxSnapshotResourceSize), b.remaining)
+	b.resources++
+
+	resourceCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	data, contentType, err := downloadImage(resourceCtx, resourceURL, limit)
+	if err != nil {
+		if limit < maxSnapshotResourceSize && strings.Contains(err.Error(), "too large") {
+			b.truncated = true
+		}
+		return nil, "", false
+	}
+
+	b.remaining -= int64(len(data))
+	return data, contentType, true
+}
+
+// charge списывает байты из бюджета снимка
+func (b *snapshotBuilder) charge(size int64) bool {
+	if size > b.remaining {
+		b.truncated = true
+		return false
+	}
+	b.remaining -= size
+	return true
+}
+
+// snapshotMimeType определяет тип встраиваемого ресурса.
+// Разрешены только изображения и шрифты, чтобы data URI не превратился в исполняемый документ
+func snapshotMimeType(data []byte, contentType string) string {
+	mimeType, _, _ := mime.ParseMediaType(contentType)
+	mimeType = strings.ToLower(mimeType)
+	if mimeType == "" || mimeType == "application/octet-stream" || mimeType == "text/plain" {
+		mimeType, _, _ = mime.ParseMediaType(http.DetectContentType(data))
+	}
+
+	switch {
+	case strings.HasPrefix(mimeType, "image/"), strings.HasPrefix(mimeType, "font/"):
+		return mimeType
+	case mimeType == "application/font-woff", mimeType == "application/x-font-ttf",
+		mimeType == "application/x-font-woff", mimeType == "application/vnd.ms-fontobject":
+		return mimeType
+	}
+	return ""
+}
+
+// sanitizeAttributes удаляет обработчики событий и javascript:-ссылки
+func sanitizeAttributes(n *html.Node) {
+	attrs := n.Attr[:0]
+	for _, attr := range n.Attr {
+		key := strings.ToLower(attr.Key)
+		if strings.HasPrefix(key, "on") {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(attr.Val)), "javascript:") {
+			continue
+		}
+		attrs = append(attrs, attr)
+	}
+	n.Attr = attrs
+}
+
+// addSnapshotHeader добавляет кодировку, если страница её не указала, и комментарий с адресом оригинала
+func addSnapshotHeader(doc *html.Node, sourceURL string) {
+	var htmlNode, head *html.Node
+	for c := doc.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.DataAtom == atom.Html {
+			htmlNode = c
+			break
+		}
+	}
+	if htmlNode == nil {
+		return
+	}
+	for c := htmlNode.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.DataAtom == atom.Head {
+			head = c
+			break
+		}
+	}
+
+	// Исходные байты страницы сохраняются как есть, поэтому объявленную кодировку не трогаем
+	if head != nil && !hasCharsetMeta(head) {
+		meta := &html.Node{
+			Type:     html.ElementNode,
+			DataAtom: atom.Meta,
+			Data:     "meta",
+			Attr:     []html.Attribute{{Key: "charset", Val: "utf-8"}},
+		}
+		head.InsertBefore(meta, head.FirstChild)
+	}
+
+	comment := fmt.Sprintf(" Snapshot of %s taken at %s ", strings.ReplaceAll(sourceURL, "--", "%2D%2D"),
+		time.Now().UTC().Format(time.RFC3339))
+	doc.InsertBefore(&html.Node{Type: html.CommentNode, Data: comment}, htmlNode)
+}
+
+// hasCharsetMeta проверяет, объявлена ли кодировка в <head>
+func hasCharsetMeta(head *html.Node) bool {
+	for c := head.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode || c.DataAtom != atom.Meta {
+			continue
+		}
+		if getAttr(c, "charset") != "" || strings.EqualFold(getAttr(c, "http-equiv"), "content-type") {
+			return true
+		}
+	}
+	return false
+}
+
+// setAttr задаёт значение атрибута, добавляя его при необходимости
+func setAttr(n *html.Node, key, val string) {
+	for i := range n.Attr {
+		if n.Attr[i].Key == key {
+			n.Attr[i].Val = val
+			return
+		}
+	}
+	n.Attr = append(n.Attr, html.Attribute{Key: key, Val: val})
+}
+
+// removeAttr удаляет атрибут элемента
+func removeAttr(n *html.Node, key string) {
+	for i := range n.Attr {
+		if n.Attr[i].Key == key {
+			n.Attr = append(n.Attr[:i], n.Attr[i+1:]...)
+			return
+		}
+	}
+}
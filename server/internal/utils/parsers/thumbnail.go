@@ -0,0 +1,138 @@
+package parsers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+
+	// Регистрируем декодеры для image.Decode
+	_ "image/gif"
+	_ "image/png"
+)
+
+const (
+	// maxPreviewSourceSize ограничивает размер исходного изображения превью
+	maxPreviewSourceSize = 10 << 20
+	// maxPreviewSourcePixels защищает от изображений-«бомб» с огромным разрешением
+	maxPreviewSourcePixels = 40_000_000
+
+	// ThumbnailMaxWidth максимальная ширина миниатюры превью
+	ThumbnailMaxWidth = 640
+	// ThumbnailMaxHeight максимальная высота миниатюры превью
+	ThumbnailMaxHeight = 400
+
+	thumbnailJPEGQuality = 80
+	thumbnailContentType = "image/jpeg"
+)
+
+// Thumbnail миниатюра превью страницы
+type Thumbnail struct {
+	ContentType string
+	Data        []byte
+	Width       int
+	Height      int
+}
+
+// FetchThumbnail downloads the preview image (og:image / twitter:image)
+// and produces a JPEG thumbnail bounded by ThumbnailMaxWidth x ThumbnailMaxHeight
+func FetchThumbnail(ctx context.Context, imageURL string) (*Thumbnail, error) {
+	imageData, _, err := downloadImage(ctx, imageURL, maxPreviewSourceSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return MakeThumbnail(imageData, ThumbnailMaxWidth, ThumbnailMaxHeight)
+}
+
+// MakeThumbnail decodes the image and scales it down to fit into maxWidth x maxHeight.
+// Images that are already small enough are only re-encoded
+func MakeThumbnail(imageData []byte, maxWidth, maxHeight int) (*Thumbnail, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(imageData))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported image format: %w", err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxPreviewSourcePixels {
+		return nil, fmt.Errorf("invalid image dimensions: %dx%d", cfg.Width, cfg.Height)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(imageData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	width, height := fitSize(cfg.Width, cfg.Height, maxWidth, maxHeight)
+	dst := scaleDown(src, width, height)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: thumbnailJPEGQuality}); err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+
+	return &Thumbnail{
+		ContentType: thumbnailContentType,
+		Data:        buf.Bytes(),
+		Width:       width,
+		Height:      height,
+	}, nil
+}
+
+// fitSize вписывает размеры изображения в заданные границы с сохранением пропорций
+func fitSize(width, height, maxWidth, maxHeight int) (int, int) {
+	if width <= maxWidth && height <= maxHeight {
+		return width, height
+	}
+
+	scale := min(float64(maxWidth)/float64(width), float64(maxHeight)/float64(height))
+	return max(1, int(float64(width)*scale)), max(1, int(float64(height)*scale))
+}
+
+// scaleDown уменьшает изображение усреднением по площади.
+// Прозрачные области заливаются белым, так как JPEG не поддерживает альфа-канал
+func scaleDown(src image.Image, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+
+	// Приводим исходник к RGBA на белом фоне
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Over)
+
+	if width == bounds.Dx() && height == bounds.Dy() {
+		return rgba
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+
+	for y := range height {
+		y0 := y * srcH / height
+		y1 := max(y0+1, (y+1)*srcH/height)
+		for x := range width {
+			x0 := x * srcW / width
+			x1 := max(x0+1, (x+1)*srcW/width)
+
+			var r, g, b, count uint64
+			for sy := y0; sy < y1; sy++ {
+				offset := sy*rgba.Stride + x0*4
+				for sx := x0; sx < x1; sx++ {
+					r += uint64(rgba.Pix[offset])
+					g += uint64(rgba.Pix[offset+1])
+					b += uint64(rgba.Pix[offset+2])
+					count++
+					offset += 4
+				}
+			}
+
+			i := y*dst.Stride + x*4
+			dst.Pix[i] = uint8(r / count)
+			dst.Pix[i+1] = uint8(g / count)
+			dst.Pix[i+2] = uint8(b / count)
+			dst.Pix[i+3] = 0xff
+		}
+	}
+
+	return dst
+}